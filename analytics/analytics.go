@@ -0,0 +1,286 @@
+// Package analytics computes how reliable and how fast a workflow is from
+// its latest runs and their jobs: success rates, median and 95th
+// percentile durations per job and per step, queue times and their trend.
+// It works on loaded data only and does no I/O besides writing CSV.
+package analytics
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/jjournet/tgr/github"
+)
+
+// MinTrendRuns is the number of completed runs needed to compare the older
+// and the newer half of the trend
+const MinTrendRuns = 4
+
+// Run is a run with its jobs. Jobs may be empty when they could not be
+// loaded, the run then only counts in the workflow figures.
+type Run struct {
+	github.RunInfo
+	Jobs []github.JobInfo
+}
+
+// Stats summarizes a set of durations
+type Stats struct {
+	Count  int
+	Median time.Duration
+	P95    time.Duration
+}
+
+// Group holds the figures of the workflow, a job or a step, across runs
+type Group struct {
+	Name        string
+	Runs        int     // runs it appeared in
+	Concluded   int     // runs where it succeeded or failed, the base of SuccessRate
+	SuccessRate float64 // from 0 to 1
+	Duration    Stats
+	Queue       Stats   // time waited for a runner, jobs only
+	Steps       []Group // jobs only
+}
+
+// Point is a completed run in the trend
+type Point struct {
+	RunNumber  int
+	CreatedAt  time.Time
+	Conclusion string
+	Duration   time.Duration
+}
+
+// Report is the analysis of the runs of a workflow
+type Report struct {
+	Workflow Group
+	Jobs     []Group // in the order they first appear, newest run first
+	Trend    []Point // completed runs, oldest first
+
+	// Change between the older and the newer half of the trend: relative
+	// for the median duration, in points for the success rate. They are 0
+	// with fewer than MinTrendRuns runs, or when a half has nothing to
+	// compare.
+	DurationChange float64
+	SuccessChange  float64
+}
+
+// Analyze computes the report of runs, given newest first as the API
+// lists them. Runs still in progress only count in Workflow.Runs, and
+// skipped jobs and steps are left out.
+func Analyze(runs []Run) Report {
+	workflow := newCollector("")
+	var jobs []*collector
+	jobIndex := map[string]*collector{}
+
+	var trend []Point
+	for _, run := range runs {
+		workflow.runs++
+		if run.Status != "completed" {
+			continue
+		}
+		workflow.conclude(run.Conclusion)
+		start := run.StartedAt
+		if start.IsZero() {
+			start = run.CreatedAt
+		}
+		if d, ok := elapsed(start, run.UpdatedAt); ok {
+			workflow.durations = append(workflow.durations, d)
+			trend = append(trend, Point{RunNumber: run.RunNumber, CreatedAt: run.CreatedAt, Conclusion: run.Conclusion, Duration: d})
+		}
+
+		for _, job := range run.Jobs {
+			if job.Conclusion == "skipped" {
+				continue
+			}
+			c, ok := jobIndex[job.Name]
+			if !ok {
+				c = newCollector(job.Name)
+				jobIndex[job.Name] = c
+				jobs = append(jobs, c)
+			}
+			c.add(job.Conclusion, job.StartedAt, job.CompletedAt)
+			if d, ok := elapsed(job.CreatedAt, job.StartedAt); ok {
+				c.queue = append(c.queue, d)
+				workflow.queue = append(workflow.queue, d)
+			}
+			for _, step := range job.Steps {
+				if step.Conclusion == "skipped" {
+					continue
+				}
+				c.step(step.Name).add(step.Conclusion, step.StartedAt, step.CompletedAt)
+			}
+		}
+	}
+
+	slices.SortStableFunc(trend, func(a, b Point) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	report := Report{Workflow: workflow.group(), Trend: trend}
+	for _, c := range jobs {
+		report.Jobs = append(report.Jobs, c.group())
+	}
+
+	if len(trend) >= MinTrendRuns {
+		older, newer := trend[:len(trend)/2], trend[len(trend)/2:]
+		if before := median(pointDurations(older)); before > 0 {
+			report.DurationChange = float64(median(pointDurations(newer))-before) / float64(before)
+		}
+		before, after := successRate(older), successRate(newer)
+		if before.Concluded > 0 && after.Concluded > 0 {
+			report.SuccessChange = after.SuccessRate - before.SuccessRate
+		}
+	}
+	return report
+}
+
+// collector accumulates the figures of a group
+type collector struct {
+	name      string
+	runs      int
+	concluded int
+	successes int
+	durations []time.Duration
+	queue     []time.Duration
+	steps     []*collector
+}
+
+func newCollector(name string) *collector {
+	return &collector{name: name}
+}
+
+// conclude counts a conclusion. Only successes and failures tell how
+// reliable a workflow is, cancelled or neutral runs are not counted.
+func (c *collector) conclude(conclusion string) {
+	switch conclusion {
+	case "success":
+		c.successes++
+		c.concluded++
+	case "failure", "timed_out", "startup_failure":
+		c.concluded++
+	}
+}
+
+// add counts one occurrence of a job or a step
+func (c *collector) add(conclusion string, start, end time.Time) {
+	c.runs++
+	c.conclude(conclusion)
+	if d, ok := elapsed(start, end); ok {
+		c.durations = append(c.durations, d)
+	}
+}
+
+// step returns the collector of the step named name, created on first use
+func (c *collector) step(name string) *collector {
+	for _, s := range c.steps {
+		if s.name == name {
+			return s
+		}
+	}
+	s := newCollector(name)
+	c.steps = append(c.steps, s)
+	return s
+}
+
+func (c *collector) group() Group {
+	g := Group{
+		Name:      c.name,
+		Runs:      c.runs,
+		Concluded: c.concluded,
+		Duration:  Summarize(c.durations),
+		Queue:     Summarize(c.queue),
+	}
+	if c.concluded > 0 {
+		g.SuccessRate = float64(c.successes) / float64(c.concluded)
+	}
+	for _, s := range c.steps {
+		g.Steps = append(g.Steps, s.group())
+	}
+	return g
+}
+
+// elapsed returns the time from start to end, when both are known and in
+// order
+func elapsed(start, end time.Time) (time.Duration, bool) {
+	if start.IsZero() || end.IsZero() || end.Before(start) {
+		return 0, false
+	}
+	return end.Sub(start), true
+}
+
+// Summarize returns the median and the 95th percentile of durations
+func Summarize(durations []time.Duration) Stats {
+	sorted := slices.Sorted(slices.Values(durations))
+	return Stats{
+		Count:  len(sorted),
+		Median: Percentile(sorted, 50),
+		P95:    Percentile(sorted, 95),
+	}
+}
+
+// Percentile returns the p-th percentile of sorted durations, interpolated
+// between the closest ranks. It is 0 for no durations.
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lo, hi := int(math.Floor(rank)), int(math.Ceil(rank))
+	return sorted[lo] + time.Duration(float64(sorted[hi]-sorted[lo])*(rank-float64(lo)))
+}
+
+func median(durations []time.Duration) time.Duration {
+	return Summarize(durations).Median
+}
+
+func pointDurations(points []Point) []time.Duration {
+	durations := make([]time.Duration, len(points))
+	for i, p := range points {
+		durations[i] = p.Duration
+	}
+	return durations
+}
+
+// successRate returns the success rate of points, in a Group
+func successRate(points []Point) Group {
+	c := newCollector("")
+	for _, p := range points {
+		c.conclude(p.Conclusion)
+	}
+	return c.group()
+}
+
+// WriteCSV writes the figures of the workflow, of each job and of each of
+// their steps, one per line. Durations are in seconds.
+func WriteCSV(w io.Writer, report Report) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"job", "step", "runs", "concluded", "success_rate",
+		"median_seconds", "p95_seconds", "queue_median_seconds", "queue_p95_seconds"})
+
+	row := func(job, step string, g Group) {
+		out.Write([]string{
+			job, step,
+			strconv.Itoa(g.Runs), strconv.Itoa(g.Concluded),
+			strconv.FormatFloat(g.SuccessRate, 'f', 3, 64),
+			seconds(g.Duration.Median), seconds(g.Duration.P95),
+			seconds(g.Queue.Median), seconds(g.Queue.P95),
+		})
+	}
+	row("", "", report.Workflow)
+	for _, job := range report.Jobs {
+		row(job.Name, "", job)
+		for _, step := range job.Steps {
+			row(job.Name, step.Name, step)
+		}
+	}
+
+	out.Flush()
+	if err := out.Error(); err != nil {
+		return fmt.Errorf("writing CSV: %w", err)
+	}
+	return nil
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 1, 64)
+}
@@ -0,0 +1,85 @@
+package analytics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jjournet/tgr/github"
+)
+
+func TestPercentile(t *testing.T) {
+	sorted := []time.Duration{10, 20, 30, 40}
+	for _, tc := range []struct {
+		p    float64
+		want time.Duration
+	}{{0, 10}, {50, 25}, {95, 38}, {100, 40}} {
+		if got := Percentile(sorted, tc.p); got != tc.want {
+			t.Errorf("Percentile(%v) = %v, want %v", tc.p, got, tc.want)
+		}
+	}
+	if got := Percentile(nil, 50); got != 0 {
+		t.Errorf("Percentile of nothing = %v, want 0", got)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	run := func(number int, conclusion string, minutes int, queue time.Duration) Run {
+		created := base.Add(time.Duration(number) * time.Hour)
+		started := created.Add(queue)
+		return Run{
+			RunInfo: github.RunInfo{RunNumber: number, Status: "completed", Conclusion: conclusion,
+				CreatedAt: created, StartedAt: created, UpdatedAt: created.Add(time.Duration(minutes) * time.Minute)},
+			Jobs: []github.JobInfo{{
+				Name: "test", Conclusion: conclusion, CreatedAt: created, StartedAt: started,
+				CompletedAt: started.Add(time.Duration(minutes-1) * time.Minute),
+				Steps: []github.StepInfo{
+					{Name: "go test", Conclusion: conclusion, StartedAt: started, CompletedAt: started.Add(time.Minute)},
+					{Name: "upload", Conclusion: "skipped"},
+				},
+			}},
+		}
+	}
+	// Newest first, as listed by the API
+	runs := []Run{
+		run(4, "failure", 10, 30*time.Second),
+		run(3, "success", 8, 10*time.Second),
+		run(2, "cancelled", 1, 0),
+		run(1, "success", 4, 10*time.Second),
+		{RunInfo: github.RunInfo{RunNumber: 5, Status: "in_progress"}},
+	}
+
+	r := Analyze(runs)
+	if r.Workflow.Runs != 5 || r.Workflow.Concluded != 3 {
+		t.Errorf("runs = %d, concluded = %d, want 5 and 3", r.Workflow.Runs, r.Workflow.Concluded)
+	}
+	if got := r.Workflow.SuccessRate; got < 0.66 || got > 0.67 {
+		t.Errorf("success rate = %v, want 2/3", got)
+	}
+	if r.Workflow.Duration.Median != 6*time.Minute {
+		t.Errorf("median duration = %v, want 6m", r.Workflow.Duration.Median)
+	}
+	if r.Workflow.Queue.Median != 10*time.Second {
+		t.Errorf("median queue = %v, want 10s", r.Workflow.Queue.Median)
+	}
+	if len(r.Trend) != 4 || r.Trend[0].RunNumber != 1 || r.Trend[3].RunNumber != 4 {
+		t.Errorf("trend is not the completed runs oldest first: %+v", r.Trend)
+	}
+	// Medians of 4m and 1m, then of 8m and 10m
+	if r.DurationChange != 2.6 || r.SuccessChange != -0.5 {
+		t.Errorf("changes = %v and %v, want 2.6 and -0.5", r.DurationChange, r.SuccessChange)
+	}
+	if len(r.Jobs) != 1 || len(r.Jobs[0].Steps) != 1 || r.Jobs[0].Steps[0].Name != "go test" {
+		t.Fatalf("unexpected jobs %+v", r.Jobs)
+	}
+
+	var out strings.Builder
+	if err := WriteCSV(&out, r); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 || lines[2] != "test,,4,3,0.667,300.0,522.0,10.0,27.0" {
+		t.Errorf("unexpected CSV:\n%s", out.String())
+	}
+}
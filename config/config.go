@@ -1,45 +1,319 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
 )
 
+// EnvConfig names the environment variable overriding the config file path
+const EnvConfig = "TGR_CONFIG"
+
+// fileNames are the config files looked up in Dir(), in order
+var fileNames = []string{"config.yaml", "config.yml", "config.toml", "config.json"}
+
 type Config struct {
-	LogLevel string `json:"log_level"`
+	LogLevel string     `json:"log_level" yaml:"log_level" toml:"log_level"`
+	Keys     KeysConfig `json:"keys,omitempty" yaml:"keys,omitempty" toml:"keys,omitempty"`
+	// Theme is a built-in theme (dark, light, high-contrast, colorblind)
+	// or the name of a user theme; empty follows the terminal background
+	Theme string `json:"theme,omitempty" yaml:"theme,omitempty" toml:"theme,omitempty"`
+	// Icons is "nerd" (needs a Nerd Font) or "ascii"
+	Icons string `json:"icons,omitempty" yaml:"icons,omitempty" toml:"icons,omitempty"`
+	// DefaultOwner is the user or organization opened at startup
+	DefaultOwner string        `json:"default_owner,omitempty" yaml:"default_owner,omitempty" toml:"default_owner,omitempty"`
+	Refresh      RefreshConfig `json:"refresh" yaml:"refresh" toml:"refresh"`
+	PageSize     PageSize      `json:"page_size" yaml:"page_size" toml:"page_size"`
+	// Favorites lists repositories as "owner/repo"
+	Favorites []string      `json:"favorites,omitempty" yaml:"favorites,omitempty" toml:"favorites,omitempty"`
+	Monitor   MonitorConfig `json:"monitor,omitempty" yaml:"monitor,omitempty" toml:"monitor,omitempty"`
+	// Notifications picks how to notify the end of a watched run
+	Notifications NotificationsConfig `json:"notifications" yaml:"notifications" toml:"notifications"`
 }
 
-func LoadConfig() (*Config, error) {
-	// Default config
-	config := &Config{
+// KeysConfig customizes the key bindings of the TUI
+type KeysConfig struct {
+	// Preset is the base key set: "default", "vim" or "emacs"
+	Preset string `json:"preset,omitempty" yaml:"preset,omitempty" toml:"preset,omitempty"`
+	// Bindings overrides keys by binding name in every view
+	Bindings map[string][]string `json:"bindings,omitempty" yaml:"bindings,omitempty" toml:"bindings,omitempty"`
+	// Views overrides keys by binding name for a single view
+	Views map[string]map[string][]string `json:"views,omitempty" yaml:"views,omitempty" toml:"views,omitempty"`
+}
+
+// RefreshConfig holds the polling intervals
+type RefreshConfig struct {
+	// RunWatch is the refresh interval of the run watch view
+	RunWatch Duration `json:"run_watch" yaml:"run_watch" toml:"run_watch"`
+	// Monitor is the refresh interval of the run monitor while runs are
+	// active; it slows down while every run is completed
+	Monitor Duration `json:"monitor" yaml:"monitor" toml:"monitor"`
+}
+
+// MonitorConfig configures the multi-repository run monitor
+type MonitorConfig struct {
+	// Repos lists the watched repositories as "owner/repo"; empty watches
+	// the favorites
+	Repos []string `json:"repos,omitempty" yaml:"repos,omitempty" toml:"repos,omitempty"`
+}
+
+// NotificationMethods are the ways to notify the end of a run
+var NotificationMethods = []string{"bell", "osc9", "osc777", "desktop"}
+
+// NotificationsConfig lists the notification methods used for each run
+// conclusion; an empty list disables notifications for that conclusion
+type NotificationsConfig struct {
+	Success   []string `json:"success" yaml:"success" toml:"success"`
+	Failure   []string `json:"failure" yaml:"failure" toml:"failure"`
+	Cancelled []string `json:"cancelled" yaml:"cancelled" toml:"cancelled"`
+	// Other covers the remaining conclusions: timed_out, action_required...
+	Other []string `json:"other" yaml:"other" toml:"other"`
+}
+
+// PageSize holds the number of items requested per API page
+type PageSize struct {
+	Repos  int `json:"repos" yaml:"repos" toml:"repos"`
+	Runs   int `json:"runs" yaml:"runs" toml:"runs"`
+	Issues int `json:"issues" yaml:"issues" toml:"issues"`
+}
+
+// Duration is a time.Duration written as "5s" or "1m30s" in config files
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return fmt.Errorf("invalid duration %q (expected a value like 5s or 1m)", text)
+	}
+	d.Duration = v
+	return nil
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// Default returns the configuration used when no file overrides it
+func Default() *Config {
+	return &Config{
 		LogLevel: "INFO",
+		Refresh: RefreshConfig{
+			RunWatch: Duration{5 * time.Second},
+			Monitor:  Duration{15 * time.Second},
+		},
+		PageSize: PageSize{
+			Repos:  100,
+			Runs:   30,
+			Issues: 30,
+		},
+		Notifications: NotificationsConfig{
+			Success: []string{"bell"},
+			Failure: []string{"bell"},
+			Other:   []string{"bell"},
+		},
+	}
+}
+
+// Dir returns the tgr configuration directory, e.g. ~/.config/tgr
+func Dir() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		home, _ := os.UserHomeDir()
+		configDir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configDir, "tgr")
+}
+
+// LogPath returns the log file location, e.g. ~/.cache/tgr/tgr.log
+func LogPath() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		home, _ := os.UserHomeDir()
+		cacheDir = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(cacheDir, "tgr", "tgr.log")
+}
+
+// Path returns the config file to read: $TGR_CONFIG when set, else the
+// first existing config file in Dir(). It returns "" when there is none.
+func Path() (string, error) {
+	if path := os.Getenv(EnvConfig); path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("%s=%s: %w", EnvConfig, path, err)
+		}
+		return path, nil
+	}
+
+	for _, name := range fileNames {
+		path := filepath.Join(Dir(), name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
 	}
+	return "", nil
+}
 
-	file, err := os.Open("config.json")
-	if os.IsNotExist(err) {
-		// Create default config file if it doesn't exist
-		return config, SaveConfig(config)
-	} else if err != nil {
+// LegacyPath returns the config.json of the working directory, read by
+// earlier versions of tgr, when it exists and is not the file in use.
+// It returns "" otherwise.
+func LegacyPath(used string) string {
+	legacy, err := filepath.Abs("config.json")
+	if err != nil {
+		return ""
+	}
+	if _, err := os.Stat(legacy); err != nil {
+		return ""
+	}
+	if used != "" {
+		if abs, err := filepath.Abs(used); err == nil && abs == legacy {
+			return ""
+		}
+	}
+	return legacy
+}
+
+// LoadConfig reads and validates the config file, falling back to the
+// defaults when there is none
+func LoadConfig() (*Config, error) {
+	path, err := Path()
+	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
+	if path == "" {
+		return Default(), nil
+	}
+	return LoadFile(path)
+}
 
-	decoder := json.NewDecoder(file)
-	if err := decoder.Decode(config); err != nil {
+// LoadFile reads and validates a config file. The format is taken from
+// the extension: .yaml, .yml, .toml or .json.
+func LoadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
 		return nil, err
 	}
 
+	config := Default()
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("config %s: %w", path, err)
+		}
+	case ".toml":
+		meta, err := toml.Decode(string(data), config)
+		if err != nil {
+			return nil, fmt.Errorf("config %s: %w", path, err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("config %s: unknown field %q", path, undecoded[0].String())
+		}
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(config); err != nil {
+			return nil, fmt.Errorf("config %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("config %s: unsupported format %q (expected .yaml, .yml, .toml or .json)", path, filepath.Ext(path))
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("config %s:\n%w", path, err)
+	}
 	return config, nil
 }
 
-func SaveConfig(config *Config) error {
-	file, err := os.Create("config.json")
-	if err != nil {
-		return err
+// Validate checks the values of the configuration, reporting every
+// invalid field at once
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(field, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("  %s: %s", field, fmt.Sprintf(format, args...)))
+	}
+
+	levels := []string{"DEBUG", "INFO", "WARN", "ERROR"}
+	if !slices.Contains(levels, strings.ToUpper(c.LogLevel)) {
+		fail("log_level", "unknown level %q (expected one of %s)", c.LogLevel, strings.Join(levels, ", "))
 	}
-	defer file.Close()
 
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(config)
+	if c.Icons != "" && c.Icons != "nerd" && c.Icons != "ascii" {
+		fail("icons", "unknown icon set %q (expected nerd or ascii)", c.Icons)
+	}
+
+	if c.Refresh.RunWatch.Duration < time.Second {
+		fail("refresh.run_watch", "must be at least 1s, got %s", c.Refresh.RunWatch)
+	}
+	if c.Refresh.Monitor.Duration < time.Second {
+		fail("refresh.monitor", "must be at least 1s, got %s", c.Refresh.Monitor)
+	}
+
+	for _, p := range []struct {
+		field string
+		value int
+	}{
+		{"page_size.repos", c.PageSize.Repos},
+		{"page_size.runs", c.PageSize.Runs},
+		{"page_size.issues", c.PageSize.Issues},
+	} {
+		if p.value < 1 || p.value > 100 {
+			fail(p.field, "must be between 1 and 100, got %d", p.value)
+		}
+	}
+
+	if strings.Contains(c.DefaultOwner, "/") {
+		fail("default_owner", "expected a user or organization name, got %q", c.DefaultOwner)
+	}
+
+	for i, fav := range c.Favorites {
+		if _, _, ok := SplitRepo(fav); !ok {
+			fail(fmt.Sprintf("favorites[%d]", i), "expected \"owner/repo\", got %q", fav)
+		}
+	}
+
+	for _, n := range []struct {
+		field   string
+		methods []string
+	}{
+		{"notifications.success", c.Notifications.Success},
+		{"notifications.failure", c.Notifications.Failure},
+		{"notifications.cancelled", c.Notifications.Cancelled},
+		{"notifications.other", c.Notifications.Other},
+	} {
+		for _, method := range n.methods {
+			if !slices.Contains(NotificationMethods, method) {
+				fail(n.field, "unknown method %q (expected one of %s)", method, strings.Join(NotificationMethods, ", "))
+			}
+		}
+	}
+
+	for i, repo := range c.Monitor.Repos {
+		if _, _, ok := SplitRepo(repo); !ok {
+			fail(fmt.Sprintf("monitor.repos[%d]", i), "expected \"owner/repo\", got %q", repo)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// SplitRepo splits an "owner/repo" reference
+func SplitRepo(fullName string) (owner, repo string, ok bool) {
+	owner, repo, ok = strings.Cut(fullName, "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", false
+	}
+	return owner, repo, true
 }
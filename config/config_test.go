@@ -0,0 +1,106 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFile(t *testing.T) {
+	files := map[string]string{
+		"config.yaml": "log_level: DEBUG\ndefault_owner: acme\nrefresh:\n  run_watch: 10s\npage_size:\n  runs: 50\nfavorites: [acme/api]\n",
+		"config.toml": "log_level = \"DEBUG\"\ndefault_owner = \"acme\"\nfavorites = [\"acme/api\"]\n[refresh]\nrun_watch = \"10s\"\n[page_size]\nruns = 50\n",
+		"config.json": `{"log_level": "DEBUG", "default_owner": "acme", "refresh": {"run_watch": "10s"}, "page_size": {"runs": 50}, "favorites": ["acme/api"]}`,
+	}
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := LoadFile(writeConfig(t, name, content))
+			if err != nil {
+				t.Fatalf("LoadFile: %v", err)
+			}
+			if cfg.LogLevel != "DEBUG" || cfg.DefaultOwner != "acme" || len(cfg.Favorites) != 1 {
+				t.Errorf("unexpected config: %+v", cfg)
+			}
+			if cfg.Refresh.RunWatch.Duration != 10*time.Second {
+				t.Errorf("refresh.run_watch = %s, want 10s", cfg.Refresh.RunWatch)
+			}
+			// Unset fields keep their defaults
+			if cfg.PageSize.Runs != 50 || cfg.PageSize.Repos != 100 {
+				t.Errorf("unexpected page sizes: %+v", cfg.PageSize)
+			}
+		})
+	}
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	tests := []struct {
+		name, file, content, want string
+	}{
+		{"unknown yaml field", "config.yaml", "log_levl: DEBUG\n", "log_levl"},
+		{"unknown toml field", "config.toml", "colour = \"red\"\n", "colour"},
+		{"unknown json field", "config.json", `{"thme": "dark"}`, "thme"},
+		{"bad duration", "config.yaml", "refresh:\n  run_watch: often\n", "often"},
+		{"short refresh", "config.yaml", "refresh:\n  run_watch: 100ms\n", "refresh.run_watch"},
+		{"page size", "config.yaml", "page_size:\n  repos: 500\n", "page_size.repos"},
+		{"favorite", "config.yaml", "favorites: [api]\n", "favorites[0]"},
+		{"format", "config.ini", "", "unsupported format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFile(writeConfig(t, tt.file, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadFile error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigEnv(t *testing.T) {
+	t.Setenv(EnvConfig, writeConfig(t, "tgr.yml", "theme: light\n"))
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.Theme != "light" {
+		t.Errorf("theme = %q, want light", cfg.Theme)
+	}
+
+	t.Setenv(EnvConfig, filepath.Join(t.TempDir(), "missing.yaml"))
+	if _, err := LoadConfig(); err == nil {
+		t.Error("LoadConfig succeeded with a missing $TGR_CONFIG file")
+	}
+}
+
+func TestLegacyPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if got := LegacyPath(""); got != "" {
+		t.Errorf("LegacyPath without config.json = %q, want none", got)
+	}
+
+	legacy := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(legacy, []byte(`{"log_level": "DEBUG"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got := LegacyPath(""); got != legacy {
+		t.Errorf("LegacyPath = %q, want %s", got, legacy)
+	}
+	if got := LegacyPath(writeConfig(t, "config.yaml", "")); got != legacy {
+		t.Errorf("LegacyPath with another config = %q, want %s", got, legacy)
+	}
+	// The file in use, e.g. through $TGR_CONFIG, is not a leftover
+	if got := LegacyPath("config.json"); got != "" {
+		t.Errorf("LegacyPath of the file in use = %q, want none", got)
+	}
+}
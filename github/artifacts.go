@@ -0,0 +1,238 @@
+package github
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	gh "github.com/google/go-github/v69/github"
+)
+
+// maxExtractedSize bounds what a downloaded artifact may expand to, so a
+// small archive cannot fill the disk
+const maxExtractedSize = 20 << 30
+
+// ListRunArtifacts loads the artifacts uploaded by a workflow run
+func (s *GitHubService) ListRunArtifacts(ctx context.Context, owner, repoName string, runID int64) ([]ArtifactInfo, error) {
+	list, _, err := s.client.Actions.ListWorkflowRunArtifacts(ctx, owner, repoName, runID, &gh.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts := make([]ArtifactInfo, len(list.Artifacts))
+	for i, artifact := range list.Artifacts {
+		artifacts[i] = ArtifactInfo{
+			ID:        artifact.GetID(),
+			Name:      artifact.GetName(),
+			Size:      artifact.GetSizeInBytes(),
+			Expired:   artifact.GetExpired(),
+			CreatedAt: artifact.GetCreatedAt().Time,
+			ExpiresAt: artifact.GetExpiresAt().Time,
+		}
+	}
+	return artifacts, nil
+}
+
+// DeleteArtifact deletes an artifact of a repository
+func (s *GitHubService) DeleteArtifact(ctx context.Context, owner, repoName string, artifactID int64) error {
+	_, err := s.client.Actions.DeleteArtifact(ctx, owner, repoName, artifactID)
+	return err
+}
+
+// DownloadArtifact downloads an artifact and extracts it into dir. progress,
+// when not nil, is called as the archive is received. It returns the
+// paths of the extracted files.
+func (s *GitHubService) DownloadArtifact(ctx context.Context, owner, repoName string, artifactID int64, dir string, progress func(done, total int64)) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	archive, err := os.CreateTemp(dir, ".artifact-*.zip")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+
+	if err := s.fetchArtifact(ctx, owner, repoName, artifactID, archive, progress); err != nil {
+		return nil, err
+	}
+	size, err := archive.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := zip.NewReader(archive, size)
+	if err != nil {
+		return nil, err
+	}
+	return extractZip(reader, dir, maxExtractedSize)
+}
+
+// PreviewArtifact reads the files of an artifact in memory. Artifacts
+// larger than limit bytes, compressed or not, are refused.
+func (s *GitHubService) PreviewArtifact(ctx context.Context, owner, repoName string, artifactID, limit int64) ([]ArtifactFile, error) {
+	buf := &limitedBuffer{limit: limit}
+	if err := s.fetchArtifact(ctx, owner, repoName, artifactID, buf, nil); err != nil {
+		return nil, err
+	}
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		return nil, err
+	}
+	return readZip(reader, limit)
+}
+
+// fetchArtifact writes the zip archive of an artifact to w. GitHub
+// redirects to a short lived storage URL which must not get the token.
+func (s *GitHubService) fetchArtifact(ctx context.Context, owner, repoName string, artifactID int64, w io.Writer, progress func(done, total int64)) error {
+	u, _, err := s.client.Actions.DownloadArtifact(ctx, owner, repoName, artifactID, 1)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("downloading artifact: %s", resp.Status)
+	}
+
+	if progress != nil {
+		w = &progressWriter{w: w, total: resp.ContentLength, progress: progress}
+	}
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// progressWriter reports the bytes written through it
+type progressWriter struct {
+	w        io.Writer
+	done     int64
+	total    int64 // -1 when unknown
+	progress func(done, total int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.done += int64(n)
+	p.progress(p.done, p.total)
+	return n, err
+}
+
+// limitedBuffer is a buffer refusing to grow past its limit
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int64
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if int64(b.Len()+len(p)) > b.limit {
+		return 0, fmt.Errorf("artifact is larger than %d bytes", b.limit)
+	}
+	return b.Buffer.Write(p)
+}
+
+// extractZip extracts an archive into dir, refusing entries which would
+// land outside of it and archives expanding to more than limit bytes
+func extractZip(reader *zip.Reader, dir string, limit int64) ([]string, error) {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, file := range reader.File {
+		path := filepath.Join(root, filepath.FromSlash(file.Name))
+		if !strings.HasPrefix(path, root+string(filepath.Separator)) {
+			return paths, fmt.Errorf("archive entry %s is outside of the destination", file.Name)
+		}
+		if file.FileInfo().IsDir() {
+			if err := os.MkdirAll(path, 0o755); err != nil {
+				return paths, err
+			}
+			continue
+		}
+		if err := extractFile(file, path, &limit); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// extractFile writes an archive entry to path, taking its size from the
+// bytes left in remaining
+func extractFile(file *zip.File, path string, remaining *int64) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := copyEntry(dst, src, remaining); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// copyEntry copies an archive entry, failing once more than remaining bytes
+// are expanded. The sizes in the archive headers are not trusted.
+func copyEntry(dst io.Writer, src io.Reader, remaining *int64) error {
+	n, err := io.Copy(dst, io.LimitReader(src, *remaining+1))
+	*remaining -= n
+	if err != nil {
+		return err
+	}
+	if *remaining < 0 {
+		return errors.New("archive expands past the size limit")
+	}
+	return nil
+}
+
+// readZip reads the files of an archive, flagging those which are not
+// text. Archives expanding to more than limit bytes are refused.
+func readZip(reader *zip.Reader, limit int64) ([]ArtifactFile, error) {
+	var files []ArtifactFile
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		src, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		err = copyEntry(&buf, src, &limit)
+		src.Close()
+		if err != nil {
+			return nil, err
+		}
+		data := buf.Bytes()
+		if !utf8.Valid(data) || bytes.IndexByte(data, 0) >= 0 {
+			files = append(files, ArtifactFile{Name: file.Name, Binary: true})
+			continue
+		}
+		files = append(files, ArtifactFile{Name: file.Name, Content: string(data)})
+	}
+	return files, nil
+}
@@ -0,0 +1,87 @@
+package github
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newZip(t *testing.T, files map[string]string) *zip.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestExtractZip(t *testing.T) {
+	dir := t.TempDir()
+	paths, err := extractZip(newZip(t, map[string]string{"reports/junit.xml": "<testsuite/>"}), dir, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := filepath.Join(dir, "reports", "junit.xml")
+	if len(paths) != 1 || paths[0] != want {
+		t.Fatalf("extractZip() = %v, want [%s]", paths, want)
+	}
+	if data, err := os.ReadFile(want); err != nil || string(data) != "<testsuite/>" {
+		t.Errorf("extracted file = %q, %v", data, err)
+	}
+}
+
+func TestExtractZipOutsideDestination(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := extractZip(newZip(t, map[string]string{"../escape.txt": "x"}), filepath.Join(dir, "out"), 1024); err == nil {
+		t.Error("extractZip() accepted an entry outside of the destination")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "escape.txt")); err == nil {
+		t.Error("entry written outside of the destination")
+	}
+}
+
+func TestReadZip(t *testing.T) {
+	files, err := readZip(newZip(t, map[string]string{"summary.txt": "3 passed", "app.bin": "\x00\x01"}), 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]ArtifactFile{}
+	for _, f := range files {
+		got[f.Name] = f
+	}
+	if f := got["summary.txt"]; f.Binary || f.Content != "3 passed" {
+		t.Errorf("summary.txt = %+v", f)
+	}
+	if f := got["app.bin"]; !f.Binary || f.Content != "" {
+		t.Errorf("app.bin = %+v", f)
+	}
+}
+
+func TestZipSizeLimit(t *testing.T) {
+	// Compresses to about 1 KB
+	bomb := map[string]string{"a.txt": strings.Repeat("a", 1<<20), "b.txt": "b"}
+	if _, err := readZip(newZip(t, bomb), 1<<20); err == nil {
+		t.Error("readZip() accepted an archive expanding past its limit")
+	}
+	if _, err := extractZip(newZip(t, bomb), t.TempDir(), 1<<20); err == nil {
+		t.Error("extractZip() accepted an archive expanding past its limit")
+	}
+	if _, err := readZip(newZip(t, bomb), 1<<20+1); err != nil {
+		t.Errorf("readZip() refused an archive within its limit: %v", err)
+	}
+}
@@ -2,24 +2,126 @@ package github
 
 import (
 	"context"
+	"net/url"
+	"strings"
+	"time"
 
 	gh "github.com/google/go-github/v69/github"
 )
 
+// API is the plain data interface to GitHub.
+// It has no dependency on any frontend so it can be faked in tests
+// or reused by alternative frontends.
+type API interface {
+	GetUser(ctx context.Context) (*User, error)
+	ListOrgs(ctx context.Context) ([]Owner, error)
+	ListRepos(ctx context.Context, owner string, isUser bool) ([]RepoInfo, error)
+	GetRepoDetails(ctx context.Context, owner, repoName string) (*RepoDetails, error)
+	GetRepoStatus(ctx context.Context, owner, repoName string) (*RepoStatus, error)
+	ListWorkflows(ctx context.Context, owner, repoName string) ([]WorkflowInfo, error)
+	ListWorkflowRuns(ctx context.Context, owner, repoName string, workflowID int64, filter RunFilter) ([]RunInfo, error)
+	ListRepoRuns(ctx context.Context, owner, repoName string, filter RunFilter) ([]RunInfo, error)
+	ListBranches(ctx context.Context, owner, repoName string) ([]BranchInfo, bool, error)
+	GetBranchStatus(ctx context.Context, owner, repoName, branch, base string) (*BranchStatus, error)
+	CreateBranch(ctx context.Context, owner, repoName, name, fromRef string) error
+	DeleteBranch(ctx context.Context, owner, repoName, name string) error
+	CompareRefs(ctx context.Context, owner, repoName, base, head string) (*Comparison, error)
+	ListCommits(ctx context.Context, owner, repoName, branch string) ([]CommitInfo, error)
+	GetCommit(ctx context.Context, owner, repoName, sha string) (*CommitDetail, error)
+	GetCommitChecks(ctx context.Context, owner, repoName, ref string) (status, conclusion string, err error)
+	ListCommitRuns(ctx context.Context, owner, repoName, sha string) ([]RunInfo, error)
+	ListEnvironments(ctx context.Context, owner, repoName string) ([]EnvironmentInfo, error)
+	LatestDeployment(ctx context.Context, owner, repoName, environment string) (*DeploymentInfo, error)
+	GetPendingDeployments(ctx context.Context, owner, repoName string, runID int64) ([]PendingDeploymentInfo, error)
+	ReviewPendingDeployments(ctx context.Context, owner, repoName string, runID int64, environmentIDs []int64, approve bool, comment string) error
+	ListVariables(ctx context.Context, scope VariableScope) ([]VariableInfo, error)
+	SetVariable(ctx context.Context, scope VariableScope, name, value string, create bool) error
+	DeleteVariable(ctx context.Context, scope VariableScope, name string) error
+	ListSecrets(ctx context.Context, scope VariableScope) ([]SecretInfo, error)
+	SetSecret(ctx context.Context, scope VariableScope, name, value string) error
+	DeleteSecret(ctx context.Context, scope VariableScope, name string) error
+	ListProjects(ctx context.Context, owner, repoName string) ([]ProjectInfo, error)
+	GetProject(ctx context.Context, projectID string) (*ProjectDetail, error)
+	SetProjectField(ctx context.Context, projectID, itemID string, field ProjectField, value string) error
+	AddProjectItem(ctx context.Context, projectID, owner, repoName string, number int) error
+	ListReleases(ctx context.Context, owner, repoName string) ([]ReleaseInfo, error)
+	ListTags(ctx context.Context, owner, repoName string) ([]TagInfo, error)
+	CreateRelease(ctx context.Context, owner, repoName string, req ReleaseRequest) (*ReleaseInfo, error)
+	UploadReleaseAsset(ctx context.Context, owner, repoName string, releaseID int64, path string) (*AssetInfo, error)
+	PublishRelease(ctx context.Context, owner, repoName string, releaseID int64) error
+	ListRunArtifacts(ctx context.Context, owner, repoName string, runID int64) ([]ArtifactInfo, error)
+	DownloadArtifact(ctx context.Context, owner, repoName string, artifactID int64, dir string, progress func(done, total int64)) ([]string, error)
+	PreviewArtifact(ctx context.Context, owner, repoName string, artifactID, limit int64) ([]ArtifactFile, error)
+	DeleteArtifact(ctx context.Context, owner, repoName string, artifactID int64) error
+	ListCaches(ctx context.Context, owner, repoName string) ([]CacheInfo, error)
+	GetCacheUsage(ctx context.Context, owner, repoName string) (*CacheUsage, error)
+	DeleteCache(ctx context.Context, owner, repoName string, cacheID int64) error
+	ListRunners(ctx context.Context, owner, repoName string) ([]RunnerInfo, error)
+	ListRunnerGroups(ctx context.Context, org string) ([]RunnerGroupInfo, error)
+	ListRunnerJobs(ctx context.Context, owner, repoName string) ([]RunnerJob, error)
+	RemoveRunner(ctx context.Context, owner, repoName string, runnerID int64) error
+	CreateRegistrationToken(ctx context.Context, owner, repoName string) (*RegistrationToken, error)
+	ListIssues(ctx context.Context, owner, repoName string) ([]IssueInfo, error)
+	GetIssue(ctx context.Context, owner, repoName string, number int) (*IssueInfo, error)
+	ListNotifications(ctx context.Context, all bool) ([]NotificationInfo, error)
+	MarkNotificationRead(ctx context.Context, threadID string) error
+	MarkNotificationDone(ctx context.Context, threadID string) error
+	Unsubscribe(ctx context.Context, threadID string) error
+	GetRun(ctx context.Context, owner, repoName string, runID int64) (*RunDetailInfo, error)
+	ListRunJobs(ctx context.Context, owner, repoName string, runID int64) ([]JobInfo, error)
+	TriggerWorkflow(ctx context.Context, owner, repoName string, workflowID int64, ref string, inputs map[string]interface{}) error
+	RefExists(ctx context.Context, owner, repoName, ref string) (bool, error)
+	GetWorkflowFile(ctx context.Context, owner, repoName, workflowPath string) (string, error)
+	GetWorkflowInputs(ctx context.Context, owner, repoName, workflowPath string) ([]WorkflowInputDefinition, error)
+	SetWorkflowEnabled(ctx context.Context, owner, repoName string, workflowID int64, enabled bool) error
+	GetWorkflowUsage(ctx context.Context, owner, repoName string, workflowID int64) (*WorkflowUsage, error)
+	FindDispatchedRuns(ctx context.Context, owner, repoName string, workflowID int64, actor, ref string, since time.Time) ([]RunInfo, error)
+}
+
 // GitHubService centralizes all GitHub API interactions
 type GitHubService struct {
-	client *gh.Client
+	client    *gh.Client
+	pageSizes PageSizes
+}
+
+// PageSizes holds the number of items requested per API page
+type PageSizes struct {
+	Repos  int
+	Runs   int
+	Issues int
 }
 
+// DefaultPageSizes are the page sizes used unless SetPageSizes overrides them
+var DefaultPageSizes = PageSizes{Repos: 100, Runs: 30, Issues: 30}
+
+var _ API = (*GitHubService)(nil)
+
 // NewGitHubService creates a new GitHub service with the provided token
 func NewGitHubService(token string) *GitHubService {
 	return &GitHubService{
-		client: gh.NewClient(nil).WithAuthToken(token),
+		client:    gh.NewClient(nil).WithAuthToken(token),
+		pageSizes: DefaultPageSizes,
 	}
 }
 
-// Context returns a background context for API calls
-// Can be extended later to support cancellation
-func (s *GitHubService) Context() context.Context {
-	return context.Background()
+// NewGitHubServiceWithBaseURL creates a GitHub service talking to a custom API
+// endpoint, such as a local stand-in server used by tests
+func NewGitHubServiceWithBaseURL(token, baseURL string) (*GitHubService, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+
+	client := gh.NewClient(nil).WithAuthToken(token)
+	client.BaseURL = u
+	client.UploadURL = u
+	return &GitHubService{client: client, pageSizes: DefaultPageSizes}, nil
+}
+
+// SetPageSizes changes the number of items requested per API page
+func (s *GitHubService) SetPageSizes(p PageSizes) {
+	s.pageSizes = p
 }
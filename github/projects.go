@@ -0,0 +1,387 @@
+package github
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Projects (v2) are only exposed by the GraphQL API, the queries live
+// here rather than with the REST calls of service.go
+
+// graphQLRequest is the body of a GraphQL call
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// graphQL runs the named operation of query and decodes its data into out
+func (s *GitHubService) graphQL(ctx context.Context, operation, query string, variables map[string]any, out any) error {
+	req, err := s.client.NewRequest("POST", "graphql", graphQLRequest{Query: query, OperationName: operation, Variables: variables})
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if _, err := s.client.Do(ctx, req, &resp); err != nil {
+		return err
+	}
+	if len(resp.Errors) > 0 {
+		messages := make([]string, len(resp.Errors))
+		for i, e := range resp.Errors {
+			messages[i] = e.Message
+		}
+		return errors.New(strings.Join(messages, "; "))
+	}
+	if out == nil || len(resp.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resp.Data, out)
+}
+
+const projectSummaryFragment = `
+fragment projectSummary on ProjectV2 {
+  id
+  number
+  title
+  url
+  closed
+  updatedAt
+  items { totalCount }
+  owner {
+    ... on User { login }
+    ... on Organization { login }
+  }
+}`
+
+// projectSummary decodes projectSummaryFragment
+type projectSummary struct {
+	ID        string
+	Number    int
+	Title     string
+	URL       string
+	Closed    bool
+	UpdatedAt time.Time
+	Items     struct{ TotalCount int }
+	Owner     struct{ Login string }
+}
+
+func (p projectSummary) info() ProjectInfo {
+	return ProjectInfo{
+		ID:        p.ID,
+		Number:    p.Number,
+		Title:     p.Title,
+		Owner:     p.Owner.Login,
+		URL:       p.URL,
+		Closed:    p.Closed,
+		ItemCount: p.Items.TotalCount,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+const ownerProjectsQuery = `
+query OwnerProjects($owner: String!) {
+  repositoryOwner(login: $owner) {
+    ... on ProjectV2Owner {
+      projectsV2(first: 50, orderBy: {field: UPDATED_AT, direction: DESC}) {
+        nodes { ...projectSummary }
+      }
+    }
+  }
+}` + projectSummaryFragment
+
+const repoProjectsQuery = `
+query RepoProjects($owner: String!, $repo: String!) {
+  repository(owner: $owner, name: $repo) {
+    projectsV2(first: 50, orderBy: {field: UPDATED_AT, direction: DESC}) {
+      nodes { ...projectSummary }
+    }
+  }
+}` + projectSummaryFragment
+
+// ListProjects loads the projects of a user or organization, or the
+// projects linked to one of its repositories when repoName is set
+func (s *GitHubService) ListProjects(ctx context.Context, owner, repoName string) ([]ProjectInfo, error) {
+	type projects struct {
+		ProjectsV2 struct{ Nodes []projectSummary }
+	}
+	var data struct {
+		RepositoryOwner *projects
+		Repository      *projects
+	}
+
+	var err error
+	var found *projects
+	if repoName == "" {
+		err = s.graphQL(ctx, "OwnerProjects", ownerProjectsQuery, map[string]any{"owner": owner}, &data)
+		found = data.RepositoryOwner
+	} else {
+		err = s.graphQL(ctx, "RepoProjects", repoProjectsQuery, map[string]any{"owner": owner, "repo": repoName}, &data)
+		found = data.Repository
+	}
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, nil
+	}
+
+	infos := make([]ProjectInfo, len(found.ProjectsV2.Nodes))
+	for i, p := range found.ProjectsV2.Nodes {
+		infos[i] = p.info()
+	}
+	return infos, nil
+}
+
+const projectQuery = `
+query Project($id: ID!) {
+  node(id: $id) {
+    ... on ProjectV2 {
+      ...projectSummary
+      fields(first: 50) {
+        nodes {
+          ... on ProjectV2FieldCommon { id name dataType }
+          ... on ProjectV2SingleSelectField { options { id name } }
+          ... on ProjectV2IterationField { configuration { iterations { id title } } }
+        }
+      }
+      itemList: items(first: 100) {
+        nodes {
+          id
+          type
+          content {
+            ... on Issue { title number issueState: state repository { nameWithOwner } }
+            ... on PullRequest { title number prState: state repository { nameWithOwner } }
+            ... on DraftIssue { title }
+          }
+          fieldValues(first: 30) {
+            nodes {
+              ... on ProjectV2ItemFieldTextValue { text field { ... on ProjectV2FieldCommon { name } } }
+              ... on ProjectV2ItemFieldNumberValue { number field { ... on ProjectV2FieldCommon { name } } }
+              ... on ProjectV2ItemFieldDateValue { date field { ... on ProjectV2FieldCommon { name } } }
+              ... on ProjectV2ItemFieldSingleSelectValue { name field { ... on ProjectV2FieldCommon { name } } }
+              ... on ProjectV2ItemFieldIterationValue { title field { ... on ProjectV2FieldCommon { name } } }
+            }
+          }
+        }
+      }
+    }
+  }
+}` + projectSummaryFragment
+
+// editableFieldTypes are the field types updateProjectV2ItemFieldValue
+// accepts, the built-in fields (title, assignees, labels...) are not
+var editableFieldTypes = map[string]bool{
+	"TEXT":          true,
+	"NUMBER":        true,
+	"DATE":          true,
+	"SINGLE_SELECT": true,
+	"ITERATION":     true,
+}
+
+// GetProject loads the editable fields of a project and its first 100
+// items with their field values
+func (s *GitHubService) GetProject(ctx context.Context, projectID string) (*ProjectDetail, error) {
+	type option struct{ ID, Name, Title string }
+	type fieldValue struct {
+		Text   *string
+		Number *float64
+		Date   *string
+		Name   *string
+		Title  *string
+		Field  struct{ Name string }
+	}
+	var data struct {
+		Node *struct {
+			projectSummary
+			Fields struct {
+				Nodes []struct {
+					ID            string
+					Name          string
+					DataType      string
+					Options       []option
+					Configuration struct{ Iterations []option }
+				}
+			}
+			ItemList struct {
+				Nodes []struct {
+					ID      string
+					Type    string
+					Content struct {
+						Title  string
+						Number int
+						// Aliased, the two state enums conflict under one name
+						IssueState string
+						PRState    string
+						Repository struct{ NameWithOwner string }
+					}
+					FieldValues struct{ Nodes []fieldValue }
+				}
+			}
+		}
+	}
+	if err := s.graphQL(ctx, "Project", projectQuery, map[string]any{"id": projectID}, &data); err != nil {
+		return nil, err
+	}
+	if data.Node == nil {
+		return nil, fmt.Errorf("project %s not found", projectID)
+	}
+
+	project := &ProjectDetail{ProjectInfo: data.Node.info()}
+	for _, f := range data.Node.Fields.Nodes {
+		if !editableFieldTypes[f.DataType] {
+			continue
+		}
+		field := ProjectField{ID: f.ID, Name: f.Name, Type: f.DataType}
+		for _, o := range append(f.Options, f.Configuration.Iterations...) {
+			name := o.Name
+			if name == "" {
+				name = o.Title // iterations have a title
+			}
+			field.Options = append(field.Options, ProjectFieldOption{ID: o.ID, Name: name})
+		}
+		project.Fields = append(project.Fields, field)
+	}
+
+	for _, i := range data.Node.ItemList.Nodes {
+		item := ProjectItem{
+			ID:     i.ID,
+			Type:   i.Type,
+			Title:  i.Content.Title,
+			Number: i.Content.Number,
+			Repo:   i.Content.Repository.NameWithOwner,
+			State:  cmp.Or(i.Content.IssueState, i.Content.PRState),
+			Values: map[string]string{},
+		}
+		for _, v := range i.FieldValues.Nodes {
+			switch {
+			case v.Field.Name == "":
+				// a built-in field this query does not describe
+			case v.Text != nil:
+				item.Values[v.Field.Name] = *v.Text
+			case v.Number != nil:
+				item.Values[v.Field.Name] = strconv.FormatFloat(*v.Number, 'f', -1, 64)
+			case v.Date != nil:
+				item.Values[v.Field.Name] = *v.Date
+			case v.Name != nil:
+				item.Values[v.Field.Name] = *v.Name
+			case v.Title != nil:
+				item.Values[v.Field.Name] = *v.Title
+			}
+		}
+		project.Items = append(project.Items, item)
+	}
+	return project, nil
+}
+
+const setProjectFieldMutation = `
+mutation SetProjectField($project: ID!, $item: ID!, $field: ID!, $value: ProjectV2FieldValue!) {
+  updateProjectV2ItemFieldValue(input: {projectId: $project, itemId: $item, fieldId: $field, value: $value}) {
+    projectV2Item { id }
+  }
+}`
+
+const clearProjectFieldMutation = `
+mutation ClearProjectField($project: ID!, $item: ID!, $field: ID!) {
+  clearProjectV2ItemFieldValue(input: {projectId: $project, itemId: $item, fieldId: $field}) {
+    projectV2Item { id }
+  }
+}`
+
+// SetProjectField sets the value of a field for a project item, an empty
+// value clears it. Single select and iteration values are option names.
+func (s *GitHubService) SetProjectField(ctx context.Context, projectID, itemID string, field ProjectField, value string) error {
+	variables := map[string]any{"project": projectID, "item": itemID, "field": field.ID}
+	if value == "" {
+		return s.graphQL(ctx, "ClearProjectField", clearProjectFieldMutation, variables, nil)
+	}
+
+	fieldValue, err := projectFieldValue(field, value)
+	if err != nil {
+		return err
+	}
+	variables["value"] = fieldValue
+	return s.graphQL(ctx, "SetProjectField", setProjectFieldMutation, variables, nil)
+}
+
+// projectFieldValue converts a value typed by the user to the
+// ProjectV2FieldValue input of the field
+func projectFieldValue(field ProjectField, value string) (map[string]any, error) {
+	switch field.Type {
+	case "TEXT":
+		return map[string]any{"text": value}, nil
+	case "NUMBER":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s expects a number, got %q", field.Name, value)
+		}
+		return map[string]any{"number": n}, nil
+	case "DATE":
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return nil, fmt.Errorf("%s expects a date as YYYY-MM-DD, got %q", field.Name, value)
+		}
+		return map[string]any{"date": value}, nil
+	case "SINGLE_SELECT", "ITERATION":
+		names := make([]string, len(field.Options))
+		for i, o := range field.Options {
+			if strings.EqualFold(o.Name, value) {
+				if field.Type == "ITERATION" {
+					return map[string]any{"iterationId": o.ID}, nil
+				}
+				return map[string]any{"singleSelectOptionId": o.ID}, nil
+			}
+			names[i] = o.Name
+		}
+		return nil, fmt.Errorf("%s expects one of %s, got %q", field.Name, strings.Join(names, ", "), value)
+	}
+	return nil, fmt.Errorf("%s fields cannot be edited", strings.ToLower(field.Type))
+}
+
+const contentIDQuery = `
+query ContentID($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    issueOrPullRequest(number: $number) {
+      ... on Issue { id }
+      ... on PullRequest { id }
+    }
+  }
+}`
+
+const addProjectItemMutation = `
+mutation AddProjectItem($project: ID!, $content: ID!) {
+  addProjectV2ItemById(input: {projectId: $project, contentId: $content}) {
+    item { id }
+  }
+}`
+
+// AddProjectItem adds an issue or pull request to a project
+func (s *GitHubService) AddProjectItem(ctx context.Context, projectID, owner, repoName string, number int) error {
+	var data struct {
+		Repository *struct {
+			IssueOrPullRequest *struct{ ID string }
+		}
+	}
+	variables := map[string]any{"owner": owner, "repo": repoName, "number": number}
+	if err := s.graphQL(ctx, "ContentID", contentIDQuery, variables, &data); err != nil {
+		return err
+	}
+	if data.Repository == nil || data.Repository.IssueOrPullRequest == nil {
+		return fmt.Errorf("%s/%s#%d not found", owner, repoName, number)
+	}
+
+	return s.graphQL(ctx, "AddProjectItem", addProjectItemMutation, map[string]any{
+		"project": projectID,
+		"content": data.Repository.IssueOrPullRequest.ID,
+	}, nil)
+}
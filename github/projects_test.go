@@ -0,0 +1,51 @@
+package github
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProjectFieldValue(t *testing.T) {
+	status := ProjectField{Name: "Status", Type: "SINGLE_SELECT", Options: []ProjectFieldOption{{ID: "st_todo", Name: "Todo"}, {ID: "st_done", Name: "Done"}}}
+	sprint := ProjectField{Name: "Sprint", Type: "ITERATION", Options: []ProjectFieldOption{{ID: "it_1", Name: "Sprint 1"}}}
+
+	tests := []struct {
+		field ProjectField
+		value string
+		want  map[string]any
+	}{
+		{ProjectField{Type: "TEXT"}, "some text", map[string]any{"text": "some text"}},
+		{ProjectField{Type: "NUMBER"}, "2.5", map[string]any{"number": 2.5}},
+		{ProjectField{Type: "DATE"}, "2025-01-31", map[string]any{"date": "2025-01-31"}},
+		{status, "done", map[string]any{"singleSelectOptionId": "st_done"}},
+		{sprint, "Sprint 1", map[string]any{"iterationId": "it_1"}},
+	}
+	for _, tt := range tests {
+		got, err := projectFieldValue(tt.field, tt.value)
+		if err != nil {
+			t.Errorf("projectFieldValue(%s, %q): %v", tt.field.Type, tt.value, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("projectFieldValue(%s, %q) = %v, want %v", tt.field.Type, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestProjectFieldValueErrors(t *testing.T) {
+	status := ProjectField{Name: "Status", Type: "SINGLE_SELECT", Options: []ProjectFieldOption{{ID: "st_todo", Name: "Todo"}}}
+	tests := []struct {
+		field ProjectField
+		value string
+	}{
+		{ProjectField{Name: "Estimate", Type: "NUMBER"}, "three"},
+		{ProjectField{Name: "Due", Type: "DATE"}, "31/01/2025"},
+		{status, "Blocked"},
+		{ProjectField{Name: "Labels", Type: "LABELS"}, "bug"},
+	}
+	for _, tt := range tests {
+		if _, err := projectFieldValue(tt.field, tt.value); err == nil {
+			t.Errorf("projectFieldValue(%s, %q) succeeded, want an error", tt.field.Name, tt.value)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package github
+
+import (
+	"context"
+
+	gh "github.com/google/go-github/v69/github"
+)
+
+// The runner functions act on the runners of the organization owner when
+// repoName is empty, and on those of the repository otherwise.
+
+// ListRunners loads the self-hosted runners of a repository or of an
+// organization. Organization runners are given their runner group.
+func (s *GitHubService) ListRunners(ctx context.Context, owner, repoName string) ([]RunnerInfo, error) {
+	opts := &gh.ListRunnersOptions{ListOptions: gh.ListOptions{PerPage: 100}}
+	var runners []RunnerInfo
+	for {
+		var list *gh.Runners
+		var resp *gh.Response
+		var err error
+		if repoName == "" {
+			list, resp, err = s.client.Actions.ListOrganizationRunners(ctx, owner, opts)
+		} else {
+			list, resp, err = s.client.Actions.ListRunners(ctx, owner, repoName, opts)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, runner := range list.Runners {
+			runners = append(runners, toRunnerInfo(runner))
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	if repoName != "" || len(runners) == 0 {
+		return runners, nil
+	}
+	groups, err := s.ListRunnerGroups(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	groupOf := map[string]string{}
+	for _, group := range groups {
+		for _, name := range group.Runners {
+			groupOf[name] = group.Name
+		}
+	}
+	for i := range runners {
+		runners[i].Group = groupOf[runners[i].Name]
+	}
+	return runners, nil
+}
+
+func toRunnerInfo(runner *gh.Runner) RunnerInfo {
+	labels := make([]string, len(runner.Labels))
+	for i, label := range runner.Labels {
+		labels[i] = label.GetName()
+	}
+	return RunnerInfo{
+		ID:     runner.GetID(),
+		Name:   runner.GetName(),
+		OS:     runner.GetOS(),
+		Status: runner.GetStatus(),
+		Busy:   runner.GetBusy(),
+		Labels: labels,
+	}
+}
+
+// ListRunnerGroups loads the runner groups of an organization with the
+// names of their runners
+func (s *GitHubService) ListRunnerGroups(ctx context.Context, org string) ([]RunnerGroupInfo, error) {
+	list, _, err := s.client.Actions.ListOrganizationRunnerGroups(ctx, org, &gh.ListOrgRunnerGroupOptions{ListOptions: gh.ListOptions{PerPage: 100}})
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]RunnerGroupInfo, len(list.RunnerGroups))
+	for i, group := range list.RunnerGroups {
+		members, _, err := s.client.Actions.ListRunnerGroupRunners(ctx, org, group.GetID(), &gh.ListOptions{PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, len(members.Runners))
+		for j, runner := range members.Runners {
+			names[j] = runner.GetName()
+		}
+		groups[i] = RunnerGroupInfo{
+			ID:                    group.GetID(),
+			Name:                  group.GetName(),
+			Visibility:            group.GetVisibility(),
+			Default:               group.GetDefault(),
+			RestrictedToWorkflows: group.GetRestrictedToWorkflows(),
+			Runners:               names,
+		}
+	}
+	return groups, nil
+}
+
+// ListRunnerJobs loads the jobs of a repository currently running on a
+// self-hosted or hosted runner. The API has no way to ask a runner for
+// its job, so they are found from the in-progress runs.
+func (s *GitHubService) ListRunnerJobs(ctx context.Context, owner, repoName string) ([]RunnerJob, error) {
+	runs, _, err := s.client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repoName, &gh.ListWorkflowRunsOptions{
+		Status:      "in_progress",
+		ListOptions: gh.ListOptions{PerPage: 50},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []RunnerJob
+	for _, run := range runs.WorkflowRuns {
+		list, _, err := s.client.Actions.ListWorkflowJobs(ctx, owner, repoName, run.GetID(), &gh.ListWorkflowJobsOptions{Filter: "latest"})
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range list.Jobs {
+			if job.GetStatus() != "in_progress" || job.GetRunnerName() == "" {
+				continue
+			}
+			jobs = append(jobs, RunnerJob{
+				Runner:    job.GetRunnerName(),
+				Workflow:  run.GetName(),
+				RunNumber: run.GetRunNumber(),
+				Job:       job.GetName(),
+				RunID:     run.GetID(),
+			})
+		}
+	}
+	return jobs, nil
+}
+
+// RemoveRunner removes a self-hosted runner, which stops receiving jobs
+func (s *GitHubService) RemoveRunner(ctx context.Context, owner, repoName string, runnerID int64) error {
+	var err error
+	if repoName == "" {
+		_, err = s.client.Actions.RemoveOrganizationRunner(ctx, owner, runnerID)
+	} else {
+		_, err = s.client.Actions.RemoveRunner(ctx, owner, repoName, runnerID)
+	}
+	return err
+}
+
+// CreateRegistrationToken creates a token to configure a new self-hosted
+// runner, valid for an hour
+func (s *GitHubService) CreateRegistrationToken(ctx context.Context, owner, repoName string) (*RegistrationToken, error) {
+	var token *gh.RegistrationToken
+	var err error
+	if repoName == "" {
+		token, _, err = s.client.Actions.CreateOrganizationRegistrationToken(ctx, owner)
+	} else {
+		token, _, err = s.client.Actions.CreateRegistrationToken(ctx, owner, repoName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &RegistrationToken{Token: token.GetToken(), ExpiresAt: token.GetExpiresAt().Time}, nil
+}
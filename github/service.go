@@ -0,0 +1,1376 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	gh "github.com/google/go-github/v69/github"
+	"golang.org/x/crypto/nacl/box"
+	"gopkg.in/yaml.v3"
+)
+
+// GetUser loads the current user's information
+func (s *GitHubService) GetUser(ctx context.Context) (*User, error) {
+	slog.Debug("GetUser: Starting to fetch user info...")
+	user, _, err := s.client.Users.Get(ctx, "")
+	if err != nil {
+		slog.Debug("GetUser: Error fetching user", "error", err)
+		return nil, err
+	}
+
+	slog.Debug("GetUser: Successfully loaded user", "login", user.GetLogin())
+	return &User{
+		Login: user.GetLogin(),
+		Name:  user.GetName(),
+	}, nil
+}
+
+// ListOrgs loads the current user's organizations
+func (s *GitHubService) ListOrgs(ctx context.Context) ([]Owner, error) {
+	slog.Debug("ListOrgs: Starting to fetch organizations...")
+	orgs, _, err := s.client.Organizations.List(ctx, "", nil)
+	if err != nil {
+		slog.Debug("ListOrgs: Error fetching orgs", "error", err)
+		return nil, err
+	}
+
+	slog.Debug("ListOrgs: Successfully loaded organizations", "count", len(orgs))
+	owners := make([]Owner, len(orgs))
+	for i, org := range orgs {
+		desc := org.GetDescription()
+		if desc == "" {
+			desc = "Organization"
+		}
+		owners[i] = Owner{
+			Login:       org.GetLogin(),
+			Description: desc,
+			IsUser:      false,
+		}
+	}
+
+	return owners, nil
+}
+
+// ListRepos loads all repositories for an owner
+func (s *GitHubService) ListRepos(ctx context.Context, owner string, isUser bool) ([]RepoInfo, error) {
+	var allRepos []*gh.Repository
+	listOpt := &gh.ListOptions{PerPage: s.pageSizes.Repos}
+
+	for {
+		slog.Debug("ListRepos: Fetching page", "page", listOpt.Page)
+		var repos []*gh.Repository
+		var resp *gh.Response
+		var err error
+
+		if isUser {
+			opts := &gh.RepositoryListByUserOptions{
+				Type:        "owner",
+				ListOptions: *listOpt,
+			}
+			repos, resp, err = s.client.Repositories.ListByUser(ctx, owner, opts)
+		} else {
+			opts := &gh.RepositoryListByOrgOptions{ListOptions: *listOpt}
+			repos, resp, err = s.client.Repositories.ListByOrg(ctx, owner, opts)
+		}
+
+		if err != nil {
+			slog.Debug("ListRepos: Error fetching repos", "error", err)
+			return nil, err
+		}
+
+		slog.Debug("ListRepos: Fetched repos", "count", len(repos), "nextPage", resp.NextPage)
+		allRepos = append(allRepos, repos...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		listOpt.Page = resp.NextPage
+	}
+
+	repoInfos := make([]RepoInfo, len(allRepos))
+	for i, repo := range allRepos {
+		desc := repo.GetDescription()
+		if desc == "" {
+			desc = "N/A"
+		}
+		repoInfos[i] = RepoInfo{
+			Name:        repo.GetName(),
+			Description: desc,
+		}
+	}
+
+	return repoInfos, nil
+}
+
+// GetRepoDetails loads detailed repo information
+func (s *GitHubService) GetRepoDetails(ctx context.Context, owner, repoName string) (*RepoDetails, error) {
+	repo, _, err := s.client.Repositories.Get(ctx, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	languages, _, err := s.client.Repositories.ListLanguages(ctx, owner, repoName)
+	if err != nil {
+		languages = make(map[string]int)
+	}
+
+	return &RepoDetails{
+		Name:        repo.GetName(),
+		Description: repo.GetDescription(),
+		MainBranch:  repo.GetDefaultBranch(),
+		Languages:   languages,
+	}, nil
+}
+
+// GetRepoStatus loads the latest default branch run and the open pull
+// request and issue counts of a repository
+func (s *GitHubService) GetRepoStatus(ctx context.Context, owner, repoName string) (*RepoStatus, error) {
+	repo, _, err := s.client.Repositories.Get(ctx, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+	status := &RepoStatus{MainBranch: repo.GetDefaultBranch()}
+
+	runs, _, err := s.client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repoName, &gh.ListWorkflowRunsOptions{
+		Branch:      status.MainBranch,
+		ListOptions: gh.ListOptions{PerPage: 1},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if infos := toRunInfos(runs.WorkflowRuns); len(infos) > 0 {
+		status.LatestRun = &infos[0]
+	}
+
+	// With one item per page, the last page number is the item count
+	pulls, resp, err := s.client.PullRequests.List(ctx, owner, repoName, &gh.PullRequestListOptions{
+		State:       "open",
+		ListOptions: gh.ListOptions{PerPage: 1},
+	})
+	if err != nil {
+		return nil, err
+	}
+	status.OpenPRs = len(pulls)
+	if resp.LastPage > 0 {
+		status.OpenPRs = resp.LastPage
+	}
+
+	// The open issues count of a repository includes pull requests
+	status.OpenIssues = max(repo.GetOpenIssuesCount()-status.OpenPRs, 0)
+
+	slog.Debug("GetRepoStatus: loaded", "repo", owner+"/"+repoName, "prs", status.OpenPRs, "issues", status.OpenIssues)
+	return status, nil
+}
+
+// ListWorkflows loads workflows for a repository
+func (s *GitHubService) ListWorkflows(ctx context.Context, owner, repoName string) ([]WorkflowInfo, error) {
+	workflows, _, err := s.client.Actions.ListWorkflows(ctx, owner, repoName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]WorkflowInfo, len(workflows.Workflows))
+	for i, wf := range workflows.Workflows {
+		infos[i] = WorkflowInfo{
+			ID:    wf.GetID(),
+			Name:  wf.GetName(),
+			State: wf.GetState(),
+			Path:  wf.GetPath(),
+		}
+	}
+
+	return infos, nil
+}
+
+// SetWorkflowEnabled enables or disables a workflow. Disabled workflows
+// are not triggered by any event, dispatches included.
+func (s *GitHubService) SetWorkflowEnabled(ctx context.Context, owner, repoName string, workflowID int64, enabled bool) error {
+	var err error
+	if enabled {
+		_, err = s.client.Actions.EnableWorkflowByID(ctx, owner, repoName, workflowID)
+	} else {
+		_, err = s.client.Actions.DisableWorkflowByID(ctx, owner, repoName, workflowID)
+	}
+	return err
+}
+
+// GetWorkflowUsage loads the billable time of a workflow in the current
+// billing cycle
+func (s *GitHubService) GetWorkflowUsage(ctx context.Context, owner, repoName string, workflowID int64) (*WorkflowUsage, error) {
+	usage, _, err := s.client.Actions.GetWorkflowUsageByID(ctx, owner, repoName, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	billable := map[string]time.Duration{}
+	if usage.Billable != nil {
+		for os, bill := range *usage.Billable {
+			if ms := bill.GetTotalMS(); ms > 0 {
+				billable[os] = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+	return &WorkflowUsage{Billable: billable}, nil
+}
+
+// HasWorkflowDispatch reports whether a workflow file can be triggered
+// manually, that is whether workflow_dispatch is one of its events. The
+// events are either a single name, a list or a map of names.
+func HasWorkflowDispatch(content string) bool {
+	var wf struct {
+		On interface{} `yaml:"on"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &wf); err != nil {
+		return false
+	}
+	switch on := wf.On.(type) {
+	case string:
+		return on == "workflow_dispatch"
+	case []interface{}:
+		for _, event := range on {
+			if event == "workflow_dispatch" {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		_, ok := on["workflow_dispatch"]
+		return ok
+	}
+	return false
+}
+
+// ListWorkflowRuns loads runs for a specific workflow
+func (s *GitHubService) ListWorkflowRuns(ctx context.Context, owner, repoName string, workflowID int64, filter RunFilter) ([]RunInfo, error) {
+	runs, _, err := s.client.Actions.ListWorkflowRunsByID(ctx, owner, repoName, workflowID, filter.options(s.pageSizes.Runs))
+	if err != nil {
+		return nil, err
+	}
+
+	return toRunInfos(runs.WorkflowRuns), nil
+}
+
+// ListRepoRuns loads all workflow runs for a repo
+func (s *GitHubService) ListRepoRuns(ctx context.Context, owner, repoName string, filter RunFilter) ([]RunInfo, error) {
+	runs, _, err := s.client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repoName, filter.options(s.pageSizes.Runs))
+	if err != nil {
+		return nil, err
+	}
+
+	return toRunInfos(runs.WorkflowRuns), nil
+}
+
+func (f RunFilter) options(perPage int) *gh.ListWorkflowRunsOptions {
+	return &gh.ListWorkflowRunsOptions{
+		Branch:      f.Branch,
+		Actor:       f.Actor,
+		Event:       f.Event,
+		Status:      f.Status,
+		Created:     f.Created,
+		HeadSHA:     f.HeadSHA,
+		ListOptions: gh.ListOptions{PerPage: perPage},
+	}
+}
+
+func toRunInfos(runs []*gh.WorkflowRun) []RunInfo {
+	infos := make([]RunInfo, len(runs))
+	for i, run := range runs {
+		infos[i] = RunInfo{
+			ID:         run.GetID(),
+			RunNumber:  run.GetRunNumber(),
+			Status:     run.GetStatus(),
+			Conclusion: run.GetConclusion(),
+			Title:      run.GetName(),
+			Branch:     run.GetHeadBranch(),
+			Event:      run.GetEvent(),
+			Actor:      run.GetActor().GetLogin(),
+			WorkflowID: run.GetWorkflowID(),
+			CreatedAt:  run.GetCreatedAt().Time,
+			StartedAt:  run.GetRunStartedAt().Time,
+			UpdatedAt:  run.GetUpdatedAt().Time,
+		}
+	}
+	return infos
+}
+
+// ListIssues loads issues for a repository
+func (s *GitHubService) ListIssues(ctx context.Context, owner, repoName string) ([]IssueInfo, error) {
+	slog.Debug("ListIssues: Starting to load issues", "owner", owner, "repo", repoName)
+
+	issues, _, err := s.client.Issues.ListByRepo(ctx, owner, repoName, &gh.IssueListByRepoOptions{
+		State:       "all",
+		ListOptions: gh.ListOptions{PerPage: s.pageSizes.Issues},
+	})
+	if err != nil {
+		slog.Debug("ListIssues: Error loading issues", "error", err)
+		return nil, err
+	}
+
+	slog.Debug("ListIssues: Successfully loaded issues", "count", len(issues))
+
+	infos := []IssueInfo{}
+	for _, issue := range issues {
+		// Skip pull requests (they appear in issue list but have PullRequestLinks)
+		if issue.IsPullRequest() {
+			continue
+		}
+
+		infos = append(infos, toIssueInfo(issue))
+	}
+
+	return infos, nil
+}
+
+// MaxBranches is the number of branches ListBranches loads at most
+const MaxBranches = 300
+
+// ListBranches loads the first MaxBranches branches of a repository and
+// reports whether it has more
+func (s *GitHubService) ListBranches(ctx context.Context, owner, repoName string) ([]BranchInfo, bool, error) {
+	opts := &gh.BranchListOptions{ListOptions: gh.ListOptions{PerPage: 100}}
+	var infos []BranchInfo
+	for {
+		branches, resp, err := s.client.Repositories.ListBranches(ctx, owner, repoName, opts)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, branch := range branches {
+			infos = append(infos, BranchInfo{
+				Name:      branch.GetName(),
+				SHA:       branch.GetCommit().GetSHA(),
+				Protected: branch.GetProtected(),
+			})
+		}
+		if resp.NextPage == 0 {
+			return infos, false, nil
+		}
+		if len(infos) >= MaxBranches {
+			return infos, true, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// GetBranchStatus loads the last commit, the ahead/behind counts against
+// base, the protection and the checks of a branch
+func (s *GitHubService) GetBranchStatus(ctx context.Context, owner, repoName, branch, base string) (*BranchStatus, error) {
+	b, _, err := s.client.Repositories.GetBranch(ctx, owner, repoName, branch, 1)
+	if err != nil {
+		return nil, err
+	}
+	commit := b.GetCommit()
+	status := &BranchStatus{
+		Message: firstLine(commit.GetCommit().GetMessage()),
+		Author:  commitAuthor(commit),
+		Date:    commit.GetCommit().GetAuthor().GetDate().Time,
+	}
+
+	if branch != base {
+		comparison, _, err := s.client.Repositories.CompareCommits(ctx, owner, repoName, base, branch, &gh.ListOptions{PerPage: 1})
+		if err != nil {
+			return nil, err
+		}
+		status.AheadBy = comparison.GetAheadBy()
+		status.BehindBy = comparison.GetBehindBy()
+	}
+
+	if b.GetProtected() {
+		// The full rules need admin rights, else only the required checks
+		// returned with the branch are known
+		protection, _, err := s.client.Repositories.GetBranchProtection(ctx, owner, repoName, branch)
+		if err != nil {
+			slog.Debug("GetBranchStatus: protection details unavailable", "branch", branch, "error", err)
+			protection = b.GetProtection()
+		}
+		status.Protection = protectionSummary(protection)
+	}
+
+	status.CheckStatus, status.CheckConclusion, err = s.GetCommitChecks(ctx, owner, repoName, commit.GetSHA())
+	if err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// protectionSummary describes the protection rules of a branch in a few words
+func protectionSummary(p *gh.Protection) string {
+	var rules []string
+	if reviews := p.GetRequiredPullRequestReviews(); reviews != nil {
+		rules = append(rules, fmt.Sprintf("%d review(s)", reviews.RequiredApprovingReviewCount))
+	}
+	if checks := p.GetRequiredStatusChecks(); checks != nil {
+		count := len(checks.GetChecks())
+		if checks.Contexts != nil && len(*checks.Contexts) > count {
+			count = len(*checks.Contexts)
+		}
+		rules = append(rules, fmt.Sprintf("%d check(s)", count))
+	}
+	if p.GetRequireLinearHistory().Enabled {
+		rules = append(rules, "linear")
+	}
+	if len(rules) == 0 {
+		return "protected"
+	}
+	return strings.Join(rules, ", ")
+}
+
+// GetCommitChecks combines the check runs and the commit statuses of a
+// ref into the status and conclusion of a workflow run. Both are empty
+// when the ref has neither.
+func (s *GitHubService) GetCommitChecks(ctx context.Context, owner, repoName, ref string) (status, conclusion string, err error) {
+	checks, _, err := s.client.Checks.ListCheckRunsForRef(ctx, owner, repoName, ref, &gh.ListCheckRunsOptions{
+		ListOptions: gh.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return "", "", err
+	}
+	combined, _, err := s.client.Repositories.GetCombinedStatus(ctx, owner, repoName, ref, &gh.ListOptions{PerPage: 100})
+	if err != nil {
+		return "", "", err
+	}
+
+	status, conclusion = checksOutcome(checks.CheckRuns)
+	if combined.GetTotalCount() == 0 {
+		return status, conclusion, nil
+	}
+	status, conclusion = withCombinedStatus(status, conclusion, combined.GetState())
+	return status, conclusion, nil
+}
+
+// withCombinedStatus merges the combined state of the commit statuses,
+// pending, success, failure or error, into the outcome of the check runs.
+// A failure on either side wins over anything still pending.
+func withCombinedStatus(status, conclusion, state string) (string, string) {
+	switch {
+	case state == "failure" || state == "error":
+		return "completed", "failure"
+	case conclusion == "failure":
+		return status, conclusion
+	case state == "pending":
+		return "in_progress", ""
+	case state == "success" && status == "":
+		return "completed", "success"
+	}
+	return status, conclusion
+}
+
+// checksOutcome folds check runs into the status and conclusion of a
+// workflow run: failed as soon as any failed, else pending while any is
+// running
+func checksOutcome(runs []*gh.CheckRun) (status, conclusion string) {
+	if len(runs) == 0 {
+		return "", ""
+	}
+	status, conclusion = "completed", "success"
+	for _, run := range runs {
+		if run.GetStatus() != "completed" {
+			status = "in_progress"
+			continue
+		}
+		switch run.GetConclusion() {
+		case "failure", "timed_out", "action_required", "startup_failure":
+			return "completed", "failure"
+		case "cancelled":
+			conclusion = "cancelled"
+		}
+	}
+	if status == "in_progress" {
+		return status, ""
+	}
+	return status, conclusion
+}
+
+// CreateBranch creates a branch pointing to fromRef, a branch, tag or SHA
+func (s *GitHubService) CreateBranch(ctx context.Context, owner, repoName, name, fromRef string) error {
+	sha, _, err := s.client.Repositories.GetCommitSHA1(ctx, owner, repoName, fromRef, "")
+	if err != nil {
+		return fmt.Errorf("resolving %s: %w", fromRef, err)
+	}
+	_, _, err = s.client.Git.CreateRef(ctx, owner, repoName, &gh.Reference{
+		Ref:    gh.Ptr("refs/heads/" + name),
+		Object: &gh.GitObject{SHA: gh.Ptr(sha)},
+	})
+	return err
+}
+
+// DeleteBranch deletes a branch
+func (s *GitHubService) DeleteBranch(ctx context.Context, owner, repoName, name string) error {
+	_, err := s.client.Git.DeleteRef(ctx, owner, repoName, "heads/"+name)
+	return err
+}
+
+// CompareRefs loads the commits and files changed between base and head
+func (s *GitHubService) CompareRefs(ctx context.Context, owner, repoName, base, head string) (*Comparison, error) {
+	c, _, err := s.client.Repositories.CompareCommits(ctx, owner, repoName, base, head, &gh.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &Comparison{
+		Base:     base,
+		Head:     head,
+		Status:   c.GetStatus(),
+		AheadBy:  c.GetAheadBy(),
+		BehindBy: c.GetBehindBy(),
+	}
+	for _, commit := range c.Commits {
+		comparison.Commits = append(comparison.Commits, toCommitInfo(commit))
+	}
+	for _, file := range c.Files {
+		comparison.Files = append(comparison.Files, toFileChange(file))
+	}
+	return comparison, nil
+}
+
+// ListCommits loads the latest commits of a branch
+func (s *GitHubService) ListCommits(ctx context.Context, owner, repoName, branch string) ([]CommitInfo, error) {
+	commits, _, err := s.client.Repositories.ListCommits(ctx, owner, repoName, &gh.CommitsListOptions{
+		SHA:         branch,
+		ListOptions: gh.ListOptions{PerPage: s.pageSizes.Runs},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]CommitInfo, len(commits))
+	for i, commit := range commits {
+		infos[i] = toCommitInfo(commit)
+	}
+	return infos, nil
+}
+
+// GetCommit loads the full message, stats and changed files of a commit
+func (s *GitHubService) GetCommit(ctx context.Context, owner, repoName, sha string) (*CommitDetail, error) {
+	commit, _, err := s.client.Repositories.GetCommit(ctx, owner, repoName, sha, &gh.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &CommitDetail{
+		SHA:       commit.GetSHA(),
+		Message:   commit.GetCommit().GetMessage(),
+		Author:    commitAuthor(commit),
+		Date:      commit.GetCommit().GetAuthor().GetDate().Time,
+		Additions: commit.GetStats().GetAdditions(),
+		Deletions: commit.GetStats().GetDeletions(),
+	}
+	for _, file := range commit.Files {
+		detail.Files = append(detail.Files, toFileChange(file))
+	}
+	return detail, nil
+}
+
+// ListCommitRuns loads the workflow runs triggered for a commit
+func (s *GitHubService) ListCommitRuns(ctx context.Context, owner, repoName, sha string) ([]RunInfo, error) {
+	runs, _, err := s.client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repoName, &gh.ListWorkflowRunsOptions{
+		HeadSHA:     sha,
+		ListOptions: gh.ListOptions{PerPage: s.pageSizes.Runs},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toRunInfos(runs.WorkflowRuns), nil
+}
+
+func toFileChange(file *gh.CommitFile) FileChange {
+	return FileChange{
+		Filename:  file.GetFilename(),
+		Status:    file.GetStatus(),
+		Additions: file.GetAdditions(),
+		Deletions: file.GetDeletions(),
+	}
+}
+
+func toCommitInfo(commit *gh.RepositoryCommit) CommitInfo {
+	return CommitInfo{
+		SHA:     commit.GetSHA(),
+		Message: firstLine(commit.GetCommit().GetMessage()),
+		Author:  commitAuthor(commit),
+		Date:    commit.GetCommit().GetAuthor().GetDate().Time,
+	}
+}
+
+// commitAuthor prefers the GitHub login of the author over the git name
+func commitAuthor(commit *gh.RepositoryCommit) string {
+	if login := commit.GetAuthor().GetLogin(); login != "" {
+		return login
+	}
+	return commit.GetCommit().GetAuthor().GetName()
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+// ListEnvironments loads the deployment environments of a repository
+// with their protection rules, in one call. Their deployments are loaded
+// apart with LatestDeployment.
+func (s *GitHubService) ListEnvironments(ctx context.Context, owner, repoName string) ([]EnvironmentInfo, error) {
+	envs, _, err := s.client.Repositories.ListEnvironments(ctx, owner, repoName, &gh.EnvironmentListOptions{
+		ListOptions: gh.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]EnvironmentInfo, len(envs.Environments))
+	for i, env := range envs.Environments {
+		info := EnvironmentInfo{Name: env.GetName(), BranchPolicy: "all"}
+		for _, rule := range env.ProtectionRules {
+			switch rule.GetType() {
+			case "wait_timer":
+				info.WaitTimer = rule.GetWaitTimer()
+			case "required_reviewers":
+				info.Reviewers = reviewerNames(rule.Reviewers)
+				info.PreventSelfReview = rule.GetPreventSelfReview()
+			}
+		}
+		if policy := env.DeploymentBranchPolicy; policy != nil {
+			if policy.GetProtectedBranches() {
+				info.BranchPolicy = "protected"
+			} else if policy.GetCustomBranchPolicies() {
+				info.BranchPolicy = "custom"
+			}
+		}
+		infos[i] = info
+	}
+	return infos, nil
+}
+
+// LatestDeployment loads the latest deployment to an environment and its
+// state, or nil when there is none
+func (s *GitHubService) LatestDeployment(ctx context.Context, owner, repoName, environment string) (*DeploymentInfo, error) {
+	deployments, _, err := s.client.Repositories.ListDeployments(ctx, owner, repoName, &gh.DeploymentsListOptions{
+		Environment: environment,
+		ListOptions: gh.ListOptions{PerPage: 1},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(deployments) == 0 || deployments[0].GetEnvironment() != environment {
+		return nil, nil
+	}
+
+	d := deployments[0]
+	info := &DeploymentInfo{
+		ID:        d.GetID(),
+		Ref:       d.GetRef(),
+		SHA:       d.GetSHA(),
+		Creator:   d.GetCreator().GetLogin(),
+		State:     "pending",
+		CreatedAt: d.GetCreatedAt().Time,
+	}
+	statuses, _, err := s.client.Repositories.ListDeploymentStatuses(ctx, owner, repoName, d.GetID(), &gh.ListOptions{PerPage: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(statuses) > 0 {
+		info.State = statuses[0].GetState()
+	}
+	return info, nil
+}
+
+// reviewerNames returns the logins of the user reviewers and the slugs of
+// the team reviewers
+func reviewerNames(reviewers []*gh.RequiredReviewer) []string {
+	var names []string
+	for _, r := range reviewers {
+		switch reviewer := r.Reviewer.(type) {
+		case *gh.User:
+			names = append(names, reviewer.GetLogin())
+		case *gh.Team:
+			names = append(names, reviewer.GetSlug())
+		}
+	}
+	return names
+}
+
+// GetPendingDeployments loads the environments a waiting run needs to be
+// approved for
+func (s *GitHubService) GetPendingDeployments(ctx context.Context, owner, repoName string, runID int64) ([]PendingDeploymentInfo, error) {
+	pending, _, err := s.client.Actions.GetPendingDeployments(ctx, owner, repoName, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]PendingDeploymentInfo, len(pending))
+	for i, p := range pending {
+		infos[i] = PendingDeploymentInfo{
+			EnvironmentID:      p.GetEnvironment().GetID(),
+			Environment:        p.GetEnvironment().GetName(),
+			WaitTimer:          int(p.GetWaitTimer()),
+			WaitTimerStartedAt: p.GetWaitTimerStartedAt().Time,
+			CanApprove:         p.GetCurrentUserCanApprove(),
+			Reviewers:          reviewerNames(p.Reviewers),
+		}
+	}
+	return infos, nil
+}
+
+// ReviewPendingDeployments approves or rejects the deployments of a run
+// to the given environments
+func (s *GitHubService) ReviewPendingDeployments(ctx context.Context, owner, repoName string, runID int64, environmentIDs []int64, approve bool, comment string) error {
+	state := "rejected"
+	if approve {
+		state = "approved"
+	}
+	_, _, err := s.client.Actions.PendingDeployments(ctx, owner, repoName, runID, &gh.PendingDeploymentsRequest{
+		EnvironmentIDs: environmentIDs,
+		State:          state,
+		Comment:        comment,
+	})
+	return err
+}
+
+// ListVariables loads the Actions variables of a scope
+func (s *GitHubService) ListVariables(ctx context.Context, scope VariableScope) ([]VariableInfo, error) {
+	opts := &gh.ListOptions{PerPage: 100}
+	var vars *gh.ActionsVariables
+	var err error
+	switch {
+	case scope.Repo == "":
+		vars, _, err = s.client.Actions.ListOrgVariables(ctx, scope.Owner, opts)
+	case scope.Environment != "":
+		vars, _, err = s.client.Actions.ListEnvVariables(ctx, scope.Owner, scope.Repo, scope.Environment, opts)
+	default:
+		vars, _, err = s.client.Actions.ListRepoVariables(ctx, scope.Owner, scope.Repo, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]VariableInfo, len(vars.Variables))
+	for i, v := range vars.Variables {
+		infos[i] = VariableInfo{
+			Name:       v.Name,
+			Value:      v.Value,
+			UpdatedAt:  v.GetUpdatedAt().Time,
+			Visibility: v.GetVisibility(),
+		}
+	}
+	return infos, nil
+}
+
+// SetVariable creates a variable, or updates the value of an existing one.
+// New organization variables are only visible to private repositories.
+func (s *GitHubService) SetVariable(ctx context.Context, scope VariableScope, name, value string, create bool) error {
+	v := &gh.ActionsVariable{Name: name, Value: value}
+	var err error
+	switch {
+	case scope.Repo == "" && create:
+		v.Visibility = gh.Ptr("private")
+		_, err = s.client.Actions.CreateOrgVariable(ctx, scope.Owner, v)
+	case scope.Repo == "":
+		_, err = s.client.Actions.UpdateOrgVariable(ctx, scope.Owner, v)
+	case scope.Environment != "" && create:
+		_, err = s.client.Actions.CreateEnvVariable(ctx, scope.Owner, scope.Repo, scope.Environment, v)
+	case scope.Environment != "":
+		_, err = s.client.Actions.UpdateEnvVariable(ctx, scope.Owner, scope.Repo, scope.Environment, v)
+	case create:
+		_, err = s.client.Actions.CreateRepoVariable(ctx, scope.Owner, scope.Repo, v)
+	default:
+		_, err = s.client.Actions.UpdateRepoVariable(ctx, scope.Owner, scope.Repo, v)
+	}
+	return err
+}
+
+// DeleteVariable deletes a variable
+func (s *GitHubService) DeleteVariable(ctx context.Context, scope VariableScope, name string) error {
+	var err error
+	switch {
+	case scope.Repo == "":
+		_, err = s.client.Actions.DeleteOrgVariable(ctx, scope.Owner, name)
+	case scope.Environment != "":
+		_, err = s.client.Actions.DeleteEnvVariable(ctx, scope.Owner, scope.Repo, scope.Environment, name)
+	default:
+		_, err = s.client.Actions.DeleteRepoVariable(ctx, scope.Owner, scope.Repo, name)
+	}
+	return err
+}
+
+// ListSecrets loads the names and update dates of the Actions secrets of
+// a scope
+func (s *GitHubService) ListSecrets(ctx context.Context, scope VariableScope) ([]SecretInfo, error) {
+	opts := &gh.ListOptions{PerPage: 100}
+	var secrets *gh.Secrets
+	var err error
+	switch {
+	case scope.Repo == "":
+		secrets, _, err = s.client.Actions.ListOrgSecrets(ctx, scope.Owner, opts)
+	case scope.Environment != "":
+		var repoID int
+		if repoID, err = s.repoID(ctx, scope); err == nil {
+			secrets, _, err = s.client.Actions.ListEnvSecrets(ctx, repoID, scope.Environment, opts)
+		}
+	default:
+		secrets, _, err = s.client.Actions.ListRepoSecrets(ctx, scope.Owner, scope.Repo, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]SecretInfo, len(secrets.Secrets))
+	for i, secret := range secrets.Secrets {
+		infos[i] = SecretInfo{
+			Name:       secret.Name,
+			UpdatedAt:  secret.UpdatedAt.Time,
+			Visibility: secret.Visibility,
+		}
+	}
+	return infos, nil
+}
+
+// SetSecret creates or updates a secret. The value is sealed with the
+// public key of the scope, GitHub never receives it in clear. Organization
+// secrets keep their visibility, new ones are only visible to private
+// repositories.
+func (s *GitHubService) SetSecret(ctx context.Context, scope VariableScope, name, value string) error {
+	var key *gh.PublicKey
+	var repoID int
+	var err error
+	switch {
+	case scope.Repo == "":
+		key, _, err = s.client.Actions.GetOrgPublicKey(ctx, scope.Owner)
+	case scope.Environment != "":
+		if repoID, err = s.repoID(ctx, scope); err == nil {
+			key, _, err = s.client.Actions.GetEnvPublicKey(ctx, repoID, scope.Environment)
+		}
+	default:
+		key, _, err = s.client.Actions.GetRepoPublicKey(ctx, scope.Owner, scope.Repo)
+	}
+	if err != nil {
+		return fmt.Errorf("loading the public key: %w", err)
+	}
+
+	sealed, err := sealSecret(key.GetKey(), value)
+	if err != nil {
+		return err
+	}
+	secret := &gh.EncryptedSecret{Name: name, KeyID: key.GetKeyID(), EncryptedValue: sealed}
+
+	switch {
+	case scope.Repo == "":
+		secret.Visibility = "private"
+		existing, resp, err := s.client.Actions.GetOrgSecret(ctx, scope.Owner, name)
+		if err == nil {
+			secret.Visibility = existing.Visibility
+		} else if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return err
+		}
+		_, err = s.client.Actions.CreateOrUpdateOrgSecret(ctx, scope.Owner, secret)
+		return err
+	case scope.Environment != "":
+		_, err = s.client.Actions.CreateOrUpdateEnvSecret(ctx, repoID, scope.Environment, secret)
+	default:
+		_, err = s.client.Actions.CreateOrUpdateRepoSecret(ctx, scope.Owner, scope.Repo, secret)
+	}
+	return err
+}
+
+// DeleteSecret deletes a secret
+func (s *GitHubService) DeleteSecret(ctx context.Context, scope VariableScope, name string) error {
+	var err error
+	switch {
+	case scope.Repo == "":
+		_, err = s.client.Actions.DeleteOrgSecret(ctx, scope.Owner, name)
+	case scope.Environment != "":
+		var repoID int
+		if repoID, err = s.repoID(ctx, scope); err == nil {
+			_, err = s.client.Actions.DeleteEnvSecret(ctx, repoID, scope.Environment, name)
+		}
+	default:
+		_, err = s.client.Actions.DeleteRepoSecret(ctx, scope.Owner, scope.Repo, name)
+	}
+	return err
+}
+
+// repoID loads the numeric ID of the repository of a scope, the
+// environment secrets endpoints are addressed by it
+func (s *GitHubService) repoID(ctx context.Context, scope VariableScope) (int, error) {
+	repo, _, err := s.client.Repositories.Get(ctx, scope.Owner, scope.Repo)
+	if err != nil {
+		return 0, err
+	}
+	return int(repo.GetID()), nil
+}
+
+// sealSecret encrypts a secret value for GitHub: a libsodium sealed box
+// for the base64 encoded public key, itself encoded in base64
+func sealSecret(publicKey, value string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("decoding the public key: %w", err)
+	}
+	if len(raw) != 32 {
+		return "", fmt.Errorf("invalid public key: %d bytes instead of 32", len(raw))
+	}
+	var key [32]byte
+	copy(key[:], raw)
+
+	sealed, err := box.SealAnonymous(nil, []byte(value), &key, rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// ListReleases loads the latest releases of a repository with their assets
+func (s *GitHubService) ListReleases(ctx context.Context, owner, repoName string) ([]ReleaseInfo, error) {
+	releases, _, err := s.client.Repositories.ListReleases(ctx, owner, repoName, &gh.ListOptions{PerPage: 50})
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]ReleaseInfo, len(releases))
+	for i, release := range releases {
+		infos[i] = toReleaseInfo(release)
+	}
+	return infos, nil
+}
+
+func toReleaseInfo(release *gh.RepositoryRelease) ReleaseInfo {
+	info := ReleaseInfo{
+		ID:          release.GetID(),
+		Tag:         release.GetTagName(),
+		Name:        release.GetName(),
+		Draft:       release.GetDraft(),
+		Prerelease:  release.GetPrerelease(),
+		Author:      release.GetAuthor().GetLogin(),
+		CreatedAt:   release.GetCreatedAt().Time,
+		PublishedAt: release.GetPublishedAt().Time,
+		Body:        release.GetBody(),
+		URL:         release.GetHTMLURL(),
+	}
+	for _, asset := range release.Assets {
+		info.Assets = append(info.Assets, AssetInfo{
+			ID:        asset.GetID(),
+			Name:      asset.GetName(),
+			Size:      asset.GetSize(),
+			Downloads: asset.GetDownloadCount(),
+		})
+	}
+	return info
+}
+
+// ListTags loads the tags of a repository, the most recent first
+func (s *GitHubService) ListTags(ctx context.Context, owner, repoName string) ([]TagInfo, error) {
+	tags, _, err := s.client.Repositories.ListTags(ctx, owner, repoName, &gh.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]TagInfo, len(tags))
+	for i, tag := range tags {
+		infos[i] = TagInfo{Name: tag.GetName(), SHA: tag.GetCommit().GetSHA()}
+	}
+	return infos, nil
+}
+
+// CreateRelease creates a release, and its tag when it does not exist yet
+func (s *GitHubService) CreateRelease(ctx context.Context, owner, repoName string, req ReleaseRequest) (*ReleaseInfo, error) {
+	release := &gh.RepositoryRelease{
+		TagName:              gh.Ptr(req.Tag),
+		Name:                 gh.Ptr(req.Name),
+		Draft:                gh.Ptr(req.Draft),
+		Prerelease:           gh.Ptr(req.Prerelease),
+		GenerateReleaseNotes: gh.Ptr(req.GenerateNotes),
+	}
+	if req.Target != "" {
+		release.TargetCommitish = gh.Ptr(req.Target)
+	}
+	created, _, err := s.client.Repositories.CreateRelease(ctx, owner, repoName, release)
+	if err != nil {
+		return nil, err
+	}
+	info := toReleaseInfo(created)
+	return &info, nil
+}
+
+// UploadReleaseAsset attaches a local file to a release, under its base name
+func (s *GitHubService) UploadReleaseAsset(ctx context.Context, owner, repoName string, releaseID int64, path string) (*AssetInfo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	asset, _, err := s.client.Repositories.UploadReleaseAsset(ctx, owner, repoName, releaseID, &gh.UploadOptions{Name: filepath.Base(path)}, file)
+	if err != nil {
+		return nil, err
+	}
+	return &AssetInfo{ID: asset.GetID(), Name: asset.GetName(), Size: asset.GetSize()}, nil
+}
+
+// PublishRelease publishes a draft release
+func (s *GitHubService) PublishRelease(ctx context.Context, owner, repoName string, releaseID int64) error {
+	_, _, err := s.client.Repositories.EditRelease(ctx, owner, repoName, releaseID, &gh.RepositoryRelease{Draft: gh.Ptr(false)})
+	return err
+}
+
+// ListCaches loads every Actions cache of a repository, the most recently
+// used first
+func (s *GitHubService) ListCaches(ctx context.Context, owner, repoName string) ([]CacheInfo, error) {
+	opts := &gh.ActionsCacheListOptions{ListOptions: gh.ListOptions{PerPage: 100}}
+	var caches []CacheInfo
+	for {
+		list, resp, err := s.client.Actions.ListCaches(ctx, owner, repoName, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, cache := range list.ActionsCaches {
+			caches = append(caches, CacheInfo{
+				ID:             cache.GetID(),
+				Key:            cache.GetKey(),
+				Ref:            cache.GetRef(),
+				Size:           cache.GetSizeInBytes(),
+				CreatedAt:      cache.GetCreatedAt().Time,
+				LastAccessedAt: cache.GetLastAccessedAt().Time,
+			})
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return caches, nil
+}
+
+// GetCacheUsage loads the storage used by the Actions caches of a repository
+func (s *GitHubService) GetCacheUsage(ctx context.Context, owner, repoName string) (*CacheUsage, error) {
+	usage, _, err := s.client.Actions.GetCacheUsageForRepo(ctx, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+	return &CacheUsage{Count: usage.ActiveCachesCount, Size: usage.ActiveCachesSizeInBytes}, nil
+}
+
+// DeleteCache deletes an Actions cache of a repository
+func (s *GitHubService) DeleteCache(ctx context.Context, owner, repoName string, cacheID int64) error {
+	_, err := s.client.Actions.DeleteCachesByID(ctx, owner, repoName, cacheID)
+	return err
+}
+
+// GetIssue loads a single issue or pull request
+func (s *GitHubService) GetIssue(ctx context.Context, owner, repoName string, number int) (*IssueInfo, error) {
+	issue, _, err := s.client.Issues.Get(ctx, owner, repoName, number)
+	if err != nil {
+		return nil, err
+	}
+	info := toIssueInfo(issue)
+	return &info, nil
+}
+
+func toIssueInfo(issue *gh.Issue) IssueInfo {
+	labels := make([]string, len(issue.Labels))
+	for j, label := range issue.Labels {
+		labels[j] = label.GetName()
+	}
+
+	author := ""
+	if issue.User != nil {
+		author = issue.User.GetLogin()
+	}
+
+	return IssueInfo{
+		Number:    issue.GetNumber(),
+		Title:     issue.GetTitle(),
+		State:     issue.GetState(),
+		Labels:    labels,
+		Author:    author,
+		Comments:  issue.GetComments(),
+		CreatedAt: issue.GetCreatedAt().Time,
+		UpdatedAt: issue.GetUpdatedAt().Time,
+		Body:      issue.GetBody(),
+	}
+}
+
+// ListNotifications loads the notifications of the user, only the unread
+// ones unless all is set
+func (s *GitHubService) ListNotifications(ctx context.Context, all bool) ([]NotificationInfo, error) {
+	notifications, _, err := s.client.Activity.ListNotifications(ctx, &gh.NotificationListOptions{
+		All:         all,
+		ListOptions: gh.ListOptions{PerPage: 50},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]NotificationInfo, len(notifications))
+	for i, n := range notifications {
+		subject := n.GetSubject()
+		infos[i] = NotificationInfo{
+			ID:        n.GetID(),
+			Reason:    n.GetReason(),
+			Title:     subject.GetTitle(),
+			Type:      subject.GetType(),
+			Repo:      n.GetRepository().GetFullName(),
+			Unread:    n.GetUnread(),
+			UpdatedAt: n.GetUpdatedAt().Time,
+		}
+		// Issues and pull requests are addressed by the number ending their URL
+		if url := subject.GetURL(); url != "" {
+			if number, err := strconv.Atoi(path.Base(url)); err == nil {
+				infos[i].Number = number
+			}
+		}
+	}
+	slog.Debug("ListNotifications: loaded", "count", len(infos), "all", all)
+	return infos, nil
+}
+
+// MarkNotificationRead marks a notification thread as read
+func (s *GitHubService) MarkNotificationRead(ctx context.Context, threadID string) error {
+	_, err := s.client.Activity.MarkThreadRead(ctx, threadID)
+	return err
+}
+
+// MarkNotificationDone marks a notification thread as done, removing it
+// from the inbox
+func (s *GitHubService) MarkNotificationDone(ctx context.Context, threadID string) error {
+	id, err := strconv.ParseInt(threadID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid thread id %q", threadID)
+	}
+	_, err = s.client.Activity.MarkThreadDone(ctx, id)
+	return err
+}
+
+// Unsubscribe mutes a notification thread until the user is mentioned or
+// comments again
+func (s *GitHubService) Unsubscribe(ctx context.Context, threadID string) error {
+	_, err := s.client.Activity.DeleteThreadSubscription(ctx, threadID)
+	return err
+}
+
+// GetRun loads detailed information for a workflow run
+func (s *GitHubService) GetRun(ctx context.Context, owner, repoName string, runID int64) (*RunDetailInfo, error) {
+	slog.Debug("GetRun: Starting to load run detail", "runID", runID)
+
+	run, _, err := s.client.Actions.GetWorkflowRunByID(ctx, owner, repoName, runID)
+	if err != nil {
+		slog.Debug("GetRun: Error loading run detail", "error", err)
+		return nil, err
+	}
+
+	slog.Debug("GetRun: Successfully loaded run detail")
+
+	actor := ""
+	if run.Actor != nil {
+		actor = run.Actor.GetLogin()
+	}
+
+	// The path may end with the ref of the workflow file, as in
+	// ".github/workflows/ci.yaml@refs/heads/main"
+	path, _, _ := strings.Cut(run.GetPath(), "@")
+
+	return &RunDetailInfo{
+		ID:           run.GetID(),
+		Name:         run.GetName(),
+		WorkflowID:   run.GetWorkflowID(),
+		WorkflowPath: path,
+		Status:       run.GetStatus(),
+		Conclusion:   run.GetConclusion(),
+		Branch:       run.GetHeadBranch(),
+		Event:        run.GetEvent(),
+		CreatedAt:    run.GetCreatedAt().Time,
+		UpdatedAt:    run.GetUpdatedAt().Time,
+		RunNumber:    run.GetRunNumber(),
+		RunAttempt:   run.GetRunAttempt(),
+		HeadSHA:      run.GetHeadSHA(),
+		Actor:        actor,
+		HTMLURL:      run.GetHTMLURL(),
+		JobsURL:      run.GetJobsURL(),
+		LogsURL:      run.GetLogsURL(),
+	}, nil
+}
+
+// ListRunJobs loads jobs for a workflow run
+func (s *GitHubService) ListRunJobs(ctx context.Context, owner, repoName string, runID int64) ([]JobInfo, error) {
+	slog.Debug("ListRunJobs: Starting to load jobs", "runID", runID)
+
+	jobs, _, err := s.client.Actions.ListWorkflowJobs(ctx, owner, repoName, runID, nil)
+	if err != nil {
+		slog.Debug("ListRunJobs: Error loading jobs", "error", err)
+		return nil, err
+	}
+
+	slog.Debug("ListRunJobs: Successfully loaded jobs", "count", len(jobs.Jobs))
+
+	jobInfos := make([]JobInfo, len(jobs.Jobs))
+	for i, job := range jobs.Jobs {
+		steps := make([]StepInfo, len(job.Steps))
+		for j, step := range job.Steps {
+			steps[j] = StepInfo{
+				Name:        step.GetName(),
+				Status:      step.GetStatus(),
+				Conclusion:  step.GetConclusion(),
+				Number:      int(step.GetNumber()),
+				StartedAt:   step.GetStartedAt().Time,
+				CompletedAt: step.GetCompletedAt().Time,
+			}
+		}
+
+		jobInfos[i] = JobInfo{
+			ID:          job.GetID(),
+			Name:        job.GetName(),
+			Status:      job.GetStatus(),
+			Conclusion:  job.GetConclusion(),
+			CreatedAt:   job.GetCreatedAt().Time,
+			StartedAt:   job.GetStartedAt().Time,
+			CompletedAt: job.GetCompletedAt().Time,
+			Steps:       steps,
+		}
+	}
+
+	return jobInfos, nil
+}
+
+// RefExists reports whether ref names a branch or a tag of the repository.
+// A ref without the refs/ prefix may be either.
+func (s *GitHubService) RefExists(ctx context.Context, owner, repoName, ref string) (bool, error) {
+	candidates := []string{"heads/" + ref, "tags/" + ref}
+	if name, ok := strings.CutPrefix(ref, "refs/"); ok {
+		candidates = []string{name}
+	}
+	for _, candidate := range candidates {
+		_, resp, err := s.client.Git.GetRef(ctx, owner, repoName, candidate)
+		if err == nil {
+			return true, nil
+		}
+		if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// TriggerWorkflow creates a workflow dispatch event
+func (s *GitHubService) TriggerWorkflow(ctx context.Context, owner, repoName string, workflowID int64, ref string, inputs map[string]interface{}) error {
+	slog.Debug("TriggerWorkflow: Triggering workflow", "workflowID", workflowID, "ref", ref)
+
+	event := gh.CreateWorkflowDispatchEventRequest{
+		Ref:    ref,
+		Inputs: inputs,
+	}
+
+	_, err := s.client.Actions.CreateWorkflowDispatchEventByID(ctx, owner, repoName, workflowID, event)
+	if err != nil {
+		slog.Debug("TriggerWorkflow: Error triggering workflow", "error", err)
+		return err
+	}
+
+	slog.Debug("TriggerWorkflow: Successfully triggered workflow")
+	return nil
+}
+
+// GetWorkflowFile loads the YAML source of a workflow file from the
+// default branch
+func (s *GitHubService) GetWorkflowFile(ctx context.Context, owner, repoName, workflowPath string) (string, error) {
+	fileContent, _, _, err := s.client.Repositories.GetContents(ctx, owner, repoName, workflowPath, nil)
+	if err != nil {
+		return "", err
+	}
+	if fileContent == nil {
+		return "", fmt.Errorf("%s is a directory", workflowPath)
+	}
+	return fileContent.GetContent()
+}
+
+// GetWorkflowInputs loads the workflow_dispatch inputs of a workflow file
+func (s *GitHubService) GetWorkflowInputs(ctx context.Context, owner, repoName, workflowPath string) ([]WorkflowInputDefinition, error) {
+	slog.Debug("GetWorkflowInputs: Loading inputs", "path", workflowPath)
+
+	content, err := s.GetWorkflowFile(ctx, owner, repoName, workflowPath)
+	if err != nil {
+		slog.Debug("GetWorkflowInputs: Error fetching file content", "error", err)
+		return nil, err
+	}
+
+	return parseWorkflowInputs(content), nil
+}
+
+// parseWorkflowInputs reads the workflow_dispatch inputs of a workflow
+// file in the order they are written in
+func parseWorkflowInputs(content string) []WorkflowInputDefinition {
+	var wf struct {
+		On struct {
+			WorkflowDispatch struct {
+				Inputs yaml.Node `yaml:"inputs"` // a mapping, decoded by hand to keep its order
+			} `yaml:"workflow_dispatch"`
+		} `yaml:"on"`
+	}
+
+	if err := yaml.Unmarshal([]byte(content), &wf); err != nil {
+		// If unmarshal fails, it might be because 'on' is not a map.
+		// In that case, there are no inputs for workflow_dispatch (or it's not enabled).
+		slog.Debug("GetWorkflowInputs: YAML unmarshal failed (likely no inputs)", "error", err)
+		return []WorkflowInputDefinition{}
+	}
+
+	inputs := []WorkflowInputDefinition{}
+	mapping := wf.On.WorkflowDispatch.Inputs
+	if mapping.Kind != yaml.MappingNode {
+		return inputs
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		var input struct {
+			Description string      `yaml:"description"`
+			Required    bool        `yaml:"required"`
+			Default     interface{} `yaml:"default"` // Default can be string or boolean
+			Type        string      `yaml:"type"`
+			Options     []string    `yaml:"options"`
+		}
+		if err := mapping.Content[i+1].Decode(&input); err != nil {
+			slog.Debug("GetWorkflowInputs: skipping malformed input", "input", mapping.Content[i].Value, "error", err)
+			continue
+		}
+
+		defVal := ""
+		if input.Default != nil {
+			defVal = fmt.Sprintf("%v", input.Default)
+		}
+
+		inputs = append(inputs, WorkflowInputDefinition{
+			Name:        mapping.Content[i].Value,
+			Description: input.Description,
+			Required:    input.Required,
+			Default:     defVal,
+			Type:        input.Type,
+			Options:     input.Options,
+		})
+	}
+
+	return inputs
+}
+
+// FindDispatchedRuns returns the runs of a workflow dispatched by actor on
+// ref since a time, most recent first. They are the candidates for the run
+// created by a dispatch, which the API does not return.
+func (s *GitHubService) FindDispatchedRuns(ctx context.Context, owner, repoName string, workflowID int64, actor, ref string, since time.Time) ([]RunInfo, error) {
+	// Runs are filtered on their branch name, without the refs/ prefix
+	branch := strings.TrimPrefix(strings.TrimPrefix(ref, "refs/heads/"), "refs/tags/")
+	runs, _, err := s.client.Actions.ListWorkflowRunsByID(ctx, owner, repoName, workflowID, &gh.ListWorkflowRunsOptions{
+		Actor:       actor,
+		Branch:      branch,
+		Event:       "workflow_dispatch",
+		Created:     ">=" + since.UTC().Format(time.RFC3339),
+		ListOptions: gh.ListOptions{PerPage: 10},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toRunInfos(runs.WorkflowRuns), nil
+}
@@ -0,0 +1,227 @@
+package github
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	gh "github.com/google/go-github/v69/github"
+	"golang.org/x/crypto/nacl/box"
+)
+
+func TestSealSecret(t *testing.T) {
+	pub, priv, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sealed, err := sealSecret(base64.StdEncoding.EncodeToString(pub[:]), "s3cr3t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		t.Fatalf("sealed value is not base64: %v", err)
+	}
+	opened, ok := box.OpenAnonymous(nil, raw, pub, priv)
+	if !ok {
+		t.Fatal("sealed value does not open with the private key")
+	}
+	if string(opened) != "s3cr3t" {
+		t.Errorf("opened %q, want %q", opened, "s3cr3t")
+	}
+}
+
+func TestSealSecretInvalidKey(t *testing.T) {
+	for _, key := range []string{"not base64!", base64.StdEncoding.EncodeToString([]byte("short"))} {
+		if _, err := sealSecret(key, "value"); err == nil {
+			t.Errorf("sealSecret(%q) succeeded, want an error", key)
+		}
+	}
+}
+
+func TestSetSecret(t *testing.T) {
+	// Each scope has its own key pair
+	type keyPair struct{ pub, priv *[32]byte }
+	keys := map[string]keyPair{}
+	for _, scope := range []string{"repos/acme/api", "orgs/acme"} {
+		pub, priv, err := box.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[scope] = keyPair{pub, priv}
+	}
+	sent := map[string]gh.EncryptedSecret{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.Trim(r.URL.Path, "/")
+		scope, _, _ := strings.Cut(path, "/actions/")
+		switch {
+		case strings.HasSuffix(path, "/public-key"):
+			json.NewEncoder(w).Encode(gh.PublicKey{KeyID: gh.Ptr(scope + "-key"), Key: gh.Ptr(base64.StdEncoding.EncodeToString(keys[scope].pub[:]))})
+		case r.Method == http.MethodPut:
+			var secret gh.EncryptedSecret
+			json.NewDecoder(r.Body).Decode(&secret)
+			sent[scope] = secret
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	s, err := NewGitHubServiceWithBaseURL("token", srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for scope, vs := range map[string]VariableScope{
+		"repos/acme/api": {Owner: "acme", Repo: "api"},
+		"orgs/acme":      {Owner: "acme"},
+	} {
+		if err := s.SetSecret(t.Context(), vs, "TOKEN", "s3cr3t-"+scope); err != nil {
+			t.Fatalf("SetSecret(%s): %v", scope, err)
+		}
+		secret := sent[scope]
+		if secret.KeyID != scope+"-key" {
+			t.Errorf("%s: key_id = %q, want %q", scope, secret.KeyID, scope+"-key")
+		}
+		raw, err := base64.StdEncoding.DecodeString(secret.EncryptedValue)
+		if err != nil {
+			t.Fatalf("%s: encrypted_value is not base64: %v", scope, err)
+		}
+		opened, ok := box.OpenAnonymous(nil, raw, keys[scope].pub, keys[scope].priv)
+		if !ok || string(opened) != "s3cr3t-"+scope {
+			t.Errorf("%s: encrypted_value opens to %q, %v with the key of the scope", scope, opened, ok)
+		}
+	}
+}
+
+func TestListBranches(t *testing.T) {
+	for _, tc := range []struct {
+		pages     int
+		wantCalls int
+		wantMore  bool
+	}{
+		{pages: 2, wantCalls: 2},
+		{pages: 10, wantCalls: MaxBranches / 100, wantMore: true},
+	} {
+		calls := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			page = max(page, 1)
+			if page < tc.pages {
+				w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=%d>; rel="next"`, "http://"+r.Host, r.URL.Path, page+1))
+			}
+			branches := make([]*gh.Branch, 100)
+			for i := range branches {
+				branches[i] = &gh.Branch{Name: gh.Ptr(fmt.Sprintf("branch-%d-%d", page, i))}
+			}
+			json.NewEncoder(w).Encode(branches)
+		}))
+		s, err := NewGitHubServiceWithBaseURL("token", srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		branches, more, err := s.ListBranches(t.Context(), "acme", "api")
+		srv.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if calls != tc.wantCalls || len(branches) != 100*tc.wantCalls || more != tc.wantMore {
+			t.Errorf("%d pages: %d calls, %d branches, more %v; want %d calls, %d branches, more %v",
+				tc.pages, calls, len(branches), more, tc.wantCalls, 100*tc.wantCalls, tc.wantMore)
+		}
+	}
+}
+
+func TestHasWorkflowDispatch(t *testing.T) {
+	for content, want := range map[string]bool{
+		"on: workflow_dispatch\n":                              true,
+		"on: [push, workflow_dispatch]\n":                      true,
+		"on:\n  push:\n  workflow_dispatch:\n    inputs: {}\n": true,
+		"on:\n  workflow_dispatch:\n":                          true,
+		"on: push\n":                                           false,
+		"on:\n  push:\n    branches: [main]\n":                 false,
+		"name: CI\n":                                           false,
+		"on: [workflow_dispatch\n":                             false,
+	} {
+		if got := HasWorkflowDispatch(content); got != want {
+			t.Errorf("HasWorkflowDispatch(%q) = %v, want %v", content, got, want)
+		}
+	}
+}
+
+func TestParseWorkflowInputs(t *testing.T) {
+	content := `
+on:
+  workflow_dispatch:
+    inputs:
+      version:
+        required: true
+      dry_run:
+        type: boolean
+        default: false
+      level:
+        type: choice
+        options: [info, debug]
+        default: info
+      apply:
+        type: boolean
+        default: true
+`
+	inputs := parseWorkflowInputs(content)
+	var names []string
+	for _, input := range inputs {
+		names = append(names, input.Name)
+	}
+	if got := strings.Join(names, ","); got != "version,dry_run,level,apply" {
+		t.Fatalf("inputs in order %s, want the source order", got)
+	}
+	if !inputs[0].Required || inputs[1].Default != "false" || inputs[2].Type != "choice" || len(inputs[2].Options) != 2 {
+		t.Errorf("inputs not decoded: %+v", inputs)
+	}
+
+	if inputs := parseWorkflowInputs("on: [push]\n"); len(inputs) != 0 {
+		t.Errorf("inputs of a workflow without dispatch: %+v", inputs)
+	}
+}
+
+func TestRunFilterOptions(t *testing.T) {
+	opts := RunFilter{Branch: "main", Actor: "octo", Status: "failure", Created: ">=2025-01-01"}.options(30)
+	if opts.Branch != "main" || opts.Actor != "octo" || opts.Status != "failure" || opts.Created != ">=2025-01-01" || opts.Event != "" || opts.PerPage != 30 {
+		t.Errorf("unexpected options %+v", opts)
+	}
+}
+
+func TestCommitChecksOutcome(t *testing.T) {
+	run := func(status, conclusion string) *gh.CheckRun {
+		return &gh.CheckRun{Status: gh.Ptr(status), Conclusion: gh.Ptr(conclusion)}
+	}
+	status, conclusion := checksOutcome([]*gh.CheckRun{run("in_progress", ""), run("completed", "failure")})
+	if status != "completed" || conclusion != "failure" {
+		t.Errorf("running and failed checks = %s/%s, want completed/failure", status, conclusion)
+	}
+
+	for _, tc := range []struct {
+		status, conclusion, state string
+		want                      string
+	}{
+		{"completed", "failure", "pending", "completed/failure"},
+		{"completed", "success", "pending", "in_progress/"},
+		{"in_progress", "", "failure", "completed/failure"},
+		{"", "", "success", "completed/success"},
+		{"completed", "cancelled", "success", "completed/cancelled"},
+	} {
+		status, conclusion := withCombinedStatus(tc.status, tc.conclusion, tc.state)
+		if got := status + "/" + conclusion; got != tc.want {
+			t.Errorf("checks %s/%s with status %s = %s, want %s", tc.status, tc.conclusion, tc.state, got, tc.want)
+		}
+	}
+}
@@ -2,6 +2,12 @@ package github
 
 import "time"
 
+// User represents the authenticated GitHub user
+type User struct {
+	Login string
+	Name  string
+}
+
 // Owner represents a GitHub user or organization
 type Owner struct {
 	Login       string
@@ -23,6 +29,14 @@ type RepoDetails struct {
 	Languages   map[string]int
 }
 
+// RepoStatus summarizes a repository for the dashboard badges
+type RepoStatus struct {
+	MainBranch string
+	LatestRun  *RunInfo // latest run on the default branch, nil if none
+	OpenPRs    int
+	OpenIssues int
+}
+
 // WorkflowInfo represents a GitHub Actions workflow
 type WorkflowInfo struct {
 	ID    int64
@@ -31,34 +45,58 @@ type WorkflowInfo struct {
 	Path  string
 }
 
+// WorkflowUsage is the billable time of a workflow in the current billing
+// cycle. Runs on public repositories and self-hosted runners are free.
+type WorkflowUsage struct {
+	Billable map[string]time.Duration // by runner OS: UBUNTU, MACOS or WINDOWS
+}
+
 // RunInfo represents a workflow run
 type RunInfo struct {
 	ID         int64
+	RunNumber  int
 	Status     string
 	Conclusion string
 	Title      string
 	Branch     string
 	Event      string
+	Actor      string
+	WorkflowID int64
 	CreatedAt  time.Time
+	StartedAt  time.Time // start of the latest attempt
+	UpdatedAt  time.Time
+}
+
+// RunFilter narrows the runs listed to those matching every non-empty
+// field, as the parameters of the API
+type RunFilter struct {
+	Branch  string
+	Actor   string
+	Event   string
+	Status  string // a status or a conclusion, such as in_progress or failure
+	Created string // a date or a range, such as >=2025-01-01 or 2025-01-01..2025-01-31
+	HeadSHA string
 }
 
 // RunDetailInfo represents detailed workflow run information
 type RunDetailInfo struct {
-	ID         int64
-	Name       string
-	Status     string
-	Conclusion string
-	Branch     string
-	Event      string
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
-	RunNumber  int
-	RunAttempt int
-	HeadSHA    string
-	Actor      string
-	HTMLURL    string
-	JobsURL    string
-	LogsURL    string
+	ID           int64
+	Name         string
+	WorkflowID   int64
+	WorkflowPath string
+	Status       string
+	Conclusion   string
+	Branch       string
+	Event        string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	RunNumber    int
+	RunAttempt   int
+	HeadSHA      string
+	Actor        string
+	HTMLURL      string
+	JobsURL      string
+	LogsURL      string
 }
 
 // WorkflowDispatchInputs represents the inputs for a workflow dispatch
@@ -83,6 +121,7 @@ type JobInfo struct {
 	Name        string
 	Status      string
 	Conclusion  string
+	CreatedAt   time.Time // queued at
 	StartedAt   time.Time
 	CompletedAt time.Time
 	Steps       []StepInfo
@@ -101,6 +140,144 @@ type IssueInfo struct {
 	Body      string
 }
 
+// BranchInfo represents a branch of a repository
+type BranchInfo struct {
+	Name      string
+	SHA       string
+	Protected bool
+}
+
+// BranchStatus details a branch: its last commit, how far it is from the
+// default branch, its protection and the checks of its head
+type BranchStatus struct {
+	Message    string // first line of the last commit message
+	Author     string
+	Date       time.Time
+	AheadBy    int
+	BehindBy   int
+	Protection string // summary of the protection rules, empty when unprotected
+	// Check status and conclusion of the head commit, with the values of
+	// a workflow run; both are empty without checks
+	CheckStatus     string
+	CheckConclusion string
+}
+
+// CommitInfo represents a commit
+type CommitInfo struct {
+	SHA     string
+	Message string
+	Author  string
+	Date    time.Time
+}
+
+// CommitDetail represents a commit with its full message and changes
+type CommitDetail struct {
+	SHA       string
+	Message   string // full commit message
+	Author    string
+	Date      time.Time
+	Additions int
+	Deletions int
+	Files     []FileChange
+}
+
+// FileChange represents a file changed by a commit or between two refs
+type FileChange struct {
+	Filename  string
+	Status    string // added, modified, removed, renamed...
+	Additions int
+	Deletions int
+}
+
+// Comparison represents the difference between two refs
+type Comparison struct {
+	Base     string
+	Head     string
+	Status   string // ahead, behind, diverged or identical
+	AheadBy  int
+	BehindBy int
+	Commits  []CommitInfo
+	Files    []FileChange
+}
+
+// EnvironmentInfo represents a deployment environment and its protection rules
+type EnvironmentInfo struct {
+	Name              string
+	WaitTimer         int      // minutes to wait before deploying
+	Reviewers         []string // users and teams, one of which must approve
+	PreventSelfReview bool
+	BranchPolicy      string // "all", "protected" or "custom" branches
+}
+
+// DeploymentInfo represents a deployment with its latest status
+type DeploymentInfo struct {
+	ID        int64
+	Ref       string
+	SHA       string
+	Creator   string
+	State     string // success, failure, in_progress, queued, pending, inactive...
+	CreatedAt time.Time
+}
+
+// PendingDeploymentInfo represents an environment a workflow run waits
+// for before deploying
+type PendingDeploymentInfo struct {
+	EnvironmentID      int64
+	Environment        string
+	WaitTimer          int // minutes
+	WaitTimerStartedAt time.Time
+	CanApprove         bool
+	Reviewers          []string
+}
+
+// VariableScope selects where Actions variables and secrets are stored:
+// an organization when Repo is empty, an environment of the repository
+// when Environment is set, the repository otherwise
+type VariableScope struct {
+	Owner       string
+	Repo        string
+	Environment string
+}
+
+// String describes the scope for display
+func (s VariableScope) String() string {
+	switch {
+	case s.Repo == "":
+		return "organization " + s.Owner
+	case s.Environment != "":
+		return "environment " + s.Environment
+	default:
+		return "repository " + s.Owner + "/" + s.Repo
+	}
+}
+
+// VariableInfo represents an Actions variable
+type VariableInfo struct {
+	Name       string
+	Value      string
+	UpdatedAt  time.Time
+	Visibility string // all, private or selected, organization variables only
+}
+
+// SecretInfo represents an Actions secret, whose value cannot be read back
+type SecretInfo struct {
+	Name       string
+	UpdatedAt  time.Time
+	Visibility string // all, private or selected, organization secrets only
+}
+
+// NotificationInfo represents a notification thread of the user
+type NotificationInfo struct {
+	ID        string
+	Reason    string // review_requested, mention, ci_activity...
+	Title     string
+	Type      string // Issue, PullRequest, CheckSuite, Release...
+	Repo      string // "owner/repo"
+	Number    int    // issue or pull request number, 0 for other subjects
+	Unread    bool
+	UpdatedAt time.Time
+}
+
 // WorkflowInputDefinition represents a single input parameter for a workflow
 type WorkflowInputDefinition struct {
 	Name        string
@@ -110,3 +287,157 @@ type WorkflowInputDefinition struct {
 	Type        string
 	Options     []string
 }
+
+// ProjectInfo represents a GitHub project (v2)
+type ProjectInfo struct {
+	ID        string // GraphQL node ID
+	Number    int
+	Title     string
+	Owner     string
+	URL       string
+	Closed    bool
+	ItemCount int
+	UpdatedAt time.Time
+}
+
+// ProjectDetail is a project with its editable fields and its items
+type ProjectDetail struct {
+	ProjectInfo
+	Fields []ProjectField
+	Items  []ProjectItem
+}
+
+// ProjectField represents an editable custom field of a project
+type ProjectField struct {
+	ID      string
+	Name    string
+	Type    string               // TEXT, NUMBER, DATE, SINGLE_SELECT or ITERATION
+	Options []ProjectFieldOption // single select options or iterations
+}
+
+// ProjectFieldOption represents an option of a single select field or an
+// iteration of an iteration field
+type ProjectFieldOption struct {
+	ID   string
+	Name string
+}
+
+// ProjectItem represents an issue, pull request or draft issue of a project
+type ProjectItem struct {
+	ID     string
+	Type   string // ISSUE, PULL_REQUEST or DRAFT_ISSUE
+	Title  string
+	Number int               // 0 for draft issues
+	Repo   string            // "owner/repo", empty for draft issues
+	State  string            // OPEN, CLOSED or MERGED
+	Values map[string]string // field name to displayed value
+}
+
+// ReleaseInfo represents a release of a repository
+type ReleaseInfo struct {
+	ID          int64
+	Tag         string
+	Name        string
+	Draft       bool
+	Prerelease  bool
+	Author      string
+	CreatedAt   time.Time
+	PublishedAt time.Time // zero for drafts
+	Body        string    // release notes, in markdown
+	URL         string
+	Assets      []AssetInfo
+}
+
+// AssetInfo represents a file attached to a release
+type AssetInfo struct {
+	ID        int64
+	Name      string
+	Size      int
+	Downloads int
+}
+
+// TagInfo represents a tag of a repository
+type TagInfo struct {
+	Name string
+	SHA  string
+}
+
+// ReleaseRequest describes a release to create
+type ReleaseRequest struct {
+	Tag           string
+	Target        string // branch or commit the tag is created from when it does not exist
+	Name          string
+	Draft         bool
+	Prerelease    bool
+	GenerateNotes bool // let GitHub write the notes from the merged pull requests
+}
+
+// ArtifactInfo represents an artifact uploaded by a workflow run
+type ArtifactInfo struct {
+	ID        int64
+	Name      string
+	Size      int64 // size of the zip archive, in bytes
+	Expired   bool
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// ArtifactFile is a file read from an artifact for preview
+type ArtifactFile struct {
+	Name    string
+	Content string // empty when Binary
+	Binary  bool
+}
+
+// CacheInfo represents an Actions cache entry of a repository
+type CacheInfo struct {
+	ID             int64
+	Key            string
+	Ref            string // e.g. refs/heads/main or refs/pull/12/merge
+	Size           int64
+	CreatedAt      time.Time
+	LastAccessedAt time.Time
+}
+
+// CacheUsage is the storage used by the active caches of a repository
+type CacheUsage struct {
+	Count int
+	Size  int64
+}
+
+// RunnerInfo represents a self-hosted runner of a repository or of an
+// organization
+type RunnerInfo struct {
+	ID     int64
+	Name   string
+	OS     string
+	Status string // online or offline
+	Busy   bool
+	Labels []string
+	Group  string // runner group, for organization runners
+}
+
+// RunnerGroupInfo represents a runner group of an organization
+type RunnerGroupInfo struct {
+	ID                    int64
+	Name                  string
+	Visibility            string // all, selected or private repositories
+	Default               bool
+	RestrictedToWorkflows bool
+	Runners               []string // names of the runners of the group
+}
+
+// RunnerJob is a job running on a self-hosted runner
+type RunnerJob struct {
+	Runner    string
+	Workflow  string
+	RunNumber int
+	Job       string
+	RunID     int64
+}
+
+// RegistrationToken registers a new self-hosted runner
+type RegistrationToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
@@ -2,14 +2,18 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"log"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/jjournet/tgr/config"
 	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/notify"
+	"github.com/jjournet/tgr/state"
 	"github.com/jjournet/tgr/tui"
 )
 
@@ -21,12 +25,21 @@ func main() {
 	// Load config
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		// Fallback to default if loading fails, but try to log it later
-		cfg = &config.Config{LogLevel: "INFO"}
+		fmt.Fprintf(os.Stderr, "tgr: invalid configuration: %v\n", err)
+		os.Exit(1)
+	}
+	// Earlier versions read config.json from the working directory
+	used, _ := config.Path()
+	if legacy := config.LegacyPath(used); legacy != "" {
+		fmt.Fprintf(os.Stderr, "tgr: ignoring %s, the configuration is now read from %s; move it there to keep its settings\n", legacy, config.Dir())
 	}
 
 	// Setup logging
-	f, err := os.OpenFile("log.txt", os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
+	logPath := config.LogPath()
+	if err := os.MkdirAll(filepath.Dir(logPath), 0700); err != nil {
+		log.Fatalf("Error creating log directory: %v", err)
+	}
+	f, err := os.OpenFile(logPath, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
 	if err != nil {
 		log.Fatalf("Error opening log file: %v", err)
 	}
@@ -57,6 +70,15 @@ func main() {
 
 	slog.Debug("Starting tgr")
 
+	if err := tui.LoadKeyMap(cfg.Keys); err != nil {
+		fmt.Fprintf(os.Stderr, "tgr: invalid configuration: %v\n", err)
+		os.Exit(1)
+	}
+	if err := tui.LoadTheme(cfg.Theme, cfg.Icons); err != nil {
+		fmt.Fprintf(os.Stderr, "tgr: invalid configuration: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Initialize Auth Service
 	authService, err := github.NewAuthService()
 	if err != nil {
@@ -102,14 +124,38 @@ func main() {
 
 	// Create centralized GitHub service
 	ghService := github.NewGitHubService(token)
+	ghService.SetPageSizes(github.PageSizes{
+		Repos:  cfg.PageSize.Repos,
+		Runs:   cfg.PageSize.Runs,
+		Issues: cfg.PageSize.Issues,
+	})
+
+	// Load pinned and recent repositories
+	st, err := state.Load(state.Path())
+	if err != nil {
+		// Start afresh on the same file, keeping the unreadable one aside
+		reset, backup, resetErr := state.Reset(state.Path())
+		if resetErr != nil {
+			slog.Warn("Ignoring unreadable state file", "path", state.Path(), "error", err, "reset_error", resetErr)
+			fmt.Fprintf(os.Stderr, "tgr: unreadable state file %s: %v; pins, presets and filters will not be saved\n", state.Path(), err)
+			reset = &state.State{}
+		} else {
+			slog.Warn("Reset unreadable state file", "path", state.Path(), "backup", backup, "error", err)
+			fmt.Fprintf(os.Stderr, "tgr: unreadable state file %s: %v; moved to %s\n", state.Path(), err, backup)
+		}
+		st = reset
+	}
 
 	// Create initial model
-	initialModel := tui.NewApp(ghService)
+	// Run end notifications are written to the terminal between frames
+	out := notify.NewTerminal(os.Stdout)
+	initialModel := tui.NewApp(ghService, cfg, st, out)
 
 	// Start the program
 	p := tea.NewProgram(
 		initialModel,
 		tea.WithAltScreen(),
+		tea.WithOutput(out),
 	)
 
 	if _, err := p.Run(); err != nil {
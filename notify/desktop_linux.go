@@ -0,0 +1,38 @@
+package notify
+
+import (
+	"os/exec"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// sendDesktop shows a notification through the freedesktop notification
+// service on the session bus, falling back to notify-send
+func sendDesktop(msg Message) error {
+	urgency := byte(1)
+	if msg.Urgent {
+		urgency = 2
+	}
+
+	conn, err := dbus.SessionBus()
+	if err == nil {
+		obj := conn.Object("org.freedesktop.Notifications", "/org/freedesktop/Notifications")
+		call := obj.Call("org.freedesktop.Notifications.Notify", 0,
+			"tgr", uint32(0), "", msg.Title, msg.Body, []string{},
+			map[string]dbus.Variant{"urgency": dbus.MakeVariant(urgency)}, int32(-1))
+		if call.Err == nil {
+			return nil
+		}
+		err = call.Err
+	}
+
+	path, lookErr := exec.LookPath("notify-send")
+	if lookErr != nil {
+		return err
+	}
+	level := "normal"
+	if msg.Urgent {
+		level = "critical"
+	}
+	return exec.Command(path, "--app-name=tgr", "--urgency="+level, msg.Title, msg.Body).Run()
+}
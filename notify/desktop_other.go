@@ -0,0 +1,10 @@
+//go:build !linux
+
+package notify
+
+import "errors"
+
+// sendDesktop is only implemented on Linux
+func sendDesktop(msg Message) error {
+	return errors.New("desktop notifications are only supported on Linux")
+}
@@ -0,0 +1,91 @@
+// Package notify tells the user that a run has finished, with the
+// terminal bell, OSC 9 or OSC 777 escape sequences, or a desktop
+// notification.
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jjournet/tgr/config"
+)
+
+// Message is a notification
+type Message struct {
+	Title string
+	Body  string
+	// Urgent asks the desktop to keep the notification until dismissed
+	Urgent bool
+}
+
+// Notifier sends notifications with the methods configured for a conclusion
+type Notifier struct {
+	cfg config.NotificationsConfig
+	// out is the terminal receiving the bell and escape sequences
+	out io.Writer
+	// desktop sends desktop notifications, replaced in tests
+	desktop func(Message) error
+}
+
+// New creates a notifier writing terminal notifications to out
+func New(cfg config.NotificationsConfig, out io.Writer) *Notifier {
+	return &Notifier{cfg: cfg, out: out, desktop: sendDesktop}
+}
+
+// methods returns the notification methods configured for a run conclusion
+func (n *Notifier) methods(conclusion string) []string {
+	switch conclusion {
+	case "success":
+		return n.cfg.Success
+	case "failure":
+		return n.cfg.Failure
+	case "cancelled":
+		return n.cfg.Cancelled
+	default:
+		return n.cfg.Other
+	}
+}
+
+// Notify sends msg with every method configured for the conclusion and
+// reports the methods that failed
+func (n *Notifier) Notify(conclusion string, msg Message) error {
+	var errs []error
+	for _, method := range n.methods(conclusion) {
+		var err error
+		switch method {
+		case "bell":
+			err = n.write("\a")
+		case "osc9":
+			// Each sequence is written at once, so a Terminal can keep it
+			// whole between two frames of the TUI
+			err = n.write(fmt.Sprintf("\x1b]9;%s: %s\x07", clean(msg.Title), clean(msg.Body)))
+		case "osc777":
+			err = n.write(fmt.Sprintf("\x1b]777;notify;%s;%s\x07", strings.ReplaceAll(clean(msg.Title), ";", ","), clean(msg.Body)))
+		case "desktop":
+			err = n.desktop(msg)
+		default:
+			err = fmt.Errorf("unknown method")
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", method, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (n *Notifier) write(s string) error {
+	_, err := io.WriteString(n.out, s)
+	return err
+}
+
+// clean removes the control characters which would end an escape sequence
+func clean(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return ' '
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,92 @@
+package notify
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jjournet/tgr/config"
+)
+
+func TestNotify(t *testing.T) {
+	var out bytes.Buffer
+	n := New(config.NotificationsConfig{
+		Success: []string{"bell", "osc9"},
+		Failure: []string{"osc777", "desktop"},
+	}, &out)
+
+	var desktop []Message
+	n.desktop = func(msg Message) error {
+		desktop = append(desktop, msg)
+		return errors.New("no notification service")
+	}
+
+	msg := Message{Title: "CI success", Body: "acme/api on main\n"}
+	if err := n.Notify("success", msg); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if got, want := out.String(), "\a\x1b]9;CI success: acme/api on main \x07"; got != want {
+		t.Errorf("success wrote %q, want %q", got, want)
+	}
+
+	out.Reset()
+	msg = Message{Title: "CI; failure", Body: "acme/api", Urgent: true}
+	if err := n.Notify("failure", msg); err == nil {
+		t.Error("expected the desktop error to be reported")
+	}
+	if got, want := out.String(), "\x1b]777;notify;CI, failure;acme/api\x07"; got != want {
+		t.Errorf("failure wrote %q, want %q", got, want)
+	}
+	if len(desktop) != 1 || !desktop[0].Urgent {
+		t.Errorf("unexpected desktop notifications %+v", desktop)
+	}
+
+	out.Reset()
+	if err := n.Notify("cancelled", msg); err != nil || out.Len() != 0 {
+		t.Errorf("cancelled is not configured, got %q, %v", out.String(), err)
+	}
+}
+
+func TestTerminal(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "tty"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	term := NewTerminal(f)
+
+	// Frames written alongside notifications are never cut by them
+	frame := strings.Repeat("x", 4096)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			io.WriteString(term, "\x1b]9;done\x07")
+		}()
+		go func() {
+			defer wg.Done()
+			term.Write([]byte(frame))
+		}()
+	}
+	wg.Wait()
+
+	data, err := os.ReadFile(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rest := string(data)
+	for rest != "" {
+		var ok bool
+		if rest, ok = strings.CutPrefix(rest, frame); !ok {
+			if rest, ok = strings.CutPrefix(rest, "\x1b]9;done\x07"); !ok {
+				t.Fatalf("interleaved writes: %q", rest[:min(len(rest), 40)])
+			}
+		}
+	}
+}
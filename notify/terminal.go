@@ -0,0 +1,32 @@
+package notify
+
+import (
+	"os"
+	"sync"
+)
+
+// Terminal is the terminal shared by the TUI, which renders to it, and the
+// notifier, which writes the bell and escape sequences to it from another
+// goroutine. Writes are serialized so a sequence is never split by a frame.
+type Terminal struct {
+	*os.File
+	mu sync.Mutex
+}
+
+// NewTerminal wraps f, usually os.Stdout, to be given both to the program
+// with tea.WithOutput and to the notifier
+func NewTerminal(f *os.File) *Terminal {
+	return &Terminal{File: f}
+}
+
+func (t *Terminal) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.File.Write(p)
+}
+
+// WriteString is overridden too, io.WriteString would otherwise reach the
+// file unlocked
+func (t *Terminal) WriteString(s string) (int, error) {
+	return t.Write([]byte(s))
+}
@@ -0,0 +1,262 @@
+// Package state persists what tgr remembers between sessions: pinned and
+// recently visited repositories, the inputs workflows were dispatched
+// with and the filters of their runs. Unlike the configuration it is written
+// by the application itself.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jjournet/tgr/config"
+)
+
+// MaxRecent is the number of recently visited repositories kept
+const MaxRecent = 10
+
+// MaxDispatchedRuns is the number of dispatched runs whose inputs are kept
+// per workflow
+const MaxDispatchedRuns = 20
+
+type State struct {
+	// Pinned lists the repositories pinned from the TUI, as "owner/repo"
+	Pinned []string `json:"pinned,omitempty"`
+	// Recent lists the last visited repositories, most recent first
+	Recent []string `json:"recent,omitempty"`
+	// Workflows holds the dispatches of each workflow, by WorkflowKey
+	Workflows map[string]*WorkflowDispatches `json:"workflows,omitempty"`
+	// RunFilters holds the filter of the run list of each workflow, by
+	// RunFilterKey
+	RunFilters map[string]RunFilter `json:"run_filters,omitempty"`
+
+	path string
+}
+
+// DispatchInputs are the ref and the input values a workflow is dispatched
+// with
+type DispatchInputs struct {
+	Ref    string            `json:"ref"`
+	Inputs map[string]string `json:"inputs,omitempty"`
+}
+
+// Preset is a named set of dispatch inputs saved by the user
+type Preset struct {
+	Name string `json:"name"`
+	DispatchInputs
+}
+
+// DispatchedRun records the inputs of a run dispatched from tgr, which the
+// API does not return
+type DispatchedRun struct {
+	RunID int64 `json:"run_id"`
+	DispatchInputs
+}
+
+// WorkflowDispatches is what is remembered of the dispatches of a workflow
+type WorkflowDispatches struct {
+	// Last holds the inputs of the latest dispatch
+	Last *DispatchInputs `json:"last,omitempty"`
+	// Presets are sorted by name
+	Presets []Preset `json:"presets,omitempty"`
+	// Runs lists the latest dispatched runs, most recent first
+	Runs []DispatchedRun `json:"runs,omitempty"`
+}
+
+// RunFilter narrows the runs listed for a workflow, as github.RunFilter
+type RunFilter struct {
+	Branch  string `json:"branch,omitempty"`
+	Actor   string `json:"actor,omitempty"`
+	Event   string `json:"event,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Created string `json:"created,omitempty"`
+	HeadSHA string `json:"head_sha,omitempty"`
+}
+
+// RunFilterKey identifies the run list of a workflow in the state, e.g.
+// "acme/api/101". The run list only knows the ID of its workflow.
+func RunFilterKey(owner, repoName string, workflowID int64) string {
+	return fmt.Sprintf("%s/%s/%d", owner, repoName, workflowID)
+}
+
+// WorkflowKey identifies a workflow of a repository in the state, e.g.
+// "acme/api/.github/workflows/deploy.yaml"
+func WorkflowKey(owner, repoName, path string) string {
+	return owner + "/" + repoName + "/" + path
+}
+
+// Path returns the state file location, e.g. ~/.config/tgr/state.json
+func Path() string {
+	return filepath.Join(config.Dir(), "state.json")
+}
+
+// Load reads the state file. A missing file gives an empty state which is
+// created on the first Save.
+func Load(path string) (*State, error) {
+	s := &State{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reset moves an unreadable state file aside, to <path>.bak, and returns
+// an empty state saved to path along with the name of the backup
+func Reset(path string) (*State, string, error) {
+	backup := path + ".bak"
+	if err := os.Rename(path, backup); err != nil {
+		return nil, "", err
+	}
+	return &State{path: path}, backup, nil
+}
+
+// Save writes the state file. A state without a file, such as the zero
+// value, is only kept in memory.
+func (s *State) Save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+
+	// Write then rename so an interrupted save keeps the previous state
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// IsPinned reports whether a repository is pinned
+func (s *State) IsPinned(repo string) bool {
+	return slices.Contains(s.Pinned, repo)
+}
+
+// TogglePin pins or unpins a repository and returns whether it is now pinned
+func (s *State) TogglePin(repo string) bool {
+	if i := slices.Index(s.Pinned, repo); i >= 0 {
+		s.Pinned = slices.Delete(s.Pinned, i, i+1)
+		return false
+	}
+	s.Pinned = append(s.Pinned, repo)
+	return true
+}
+
+// Visit moves a repository to the top of the recent list
+func (s *State) Visit(repo string) {
+	if i := slices.Index(s.Recent, repo); i >= 0 {
+		s.Recent = slices.Delete(s.Recent, i, i+1)
+	}
+	s.Recent = slices.Insert(s.Recent, 0, repo)
+	if len(s.Recent) > MaxRecent {
+		s.Recent = s.Recent[:MaxRecent]
+	}
+}
+
+func (s *State) workflow(key string) *WorkflowDispatches {
+	if s.Workflows == nil {
+		s.Workflows = map[string]*WorkflowDispatches{}
+	}
+	if s.Workflows[key] == nil {
+		s.Workflows[key] = &WorkflowDispatches{}
+	}
+	return s.Workflows[key]
+}
+
+// LastDispatch returns the inputs of the latest dispatch of a workflow
+func (s *State) LastDispatch(key string) (DispatchInputs, bool) {
+	if w := s.Workflows[key]; w != nil && w.Last != nil {
+		return *w.Last, true
+	}
+	return DispatchInputs{}, false
+}
+
+// RememberDispatch records the inputs of a dispatch as the last used ones
+func (s *State) RememberDispatch(key string, inputs DispatchInputs) {
+	s.workflow(key).Last = &inputs
+}
+
+// Presets returns the presets saved for a workflow
+func (s *State) Presets(key string) []Preset {
+	if w := s.Workflows[key]; w != nil {
+		return w.Presets
+	}
+	return nil
+}
+
+// SavePreset adds a preset to a workflow, replacing the one of the same name
+func (s *State) SavePreset(key string, preset Preset) {
+	w := s.workflow(key)
+	i, found := slices.BinarySearchFunc(w.Presets, preset.Name, func(p Preset, name string) int {
+		return strings.Compare(p.Name, name)
+	})
+	if found {
+		w.Presets[i] = preset
+		return
+	}
+	w.Presets = slices.Insert(w.Presets, i, preset)
+}
+
+// DeletePreset removes a preset of a workflow
+func (s *State) DeletePreset(key, name string) {
+	if w := s.Workflows[key]; w != nil {
+		w.Presets = slices.DeleteFunc(w.Presets, func(p Preset) bool { return p.Name == name })
+	}
+}
+
+// RecordRun keeps the inputs a run was dispatched with
+func (s *State) RecordRun(key string, runID int64, inputs DispatchInputs) {
+	w := s.workflow(key)
+	w.Runs = slices.DeleteFunc(w.Runs, func(r DispatchedRun) bool { return r.RunID == runID })
+	w.Runs = slices.Insert(w.Runs, 0, DispatchedRun{RunID: runID, DispatchInputs: inputs})
+	if len(w.Runs) > MaxDispatchedRuns {
+		w.Runs = w.Runs[:MaxDispatchedRuns]
+	}
+}
+
+// RunInputs returns the inputs a run was dispatched with, when it was
+// dispatched from tgr
+func (s *State) RunInputs(key string, runID int64) (DispatchInputs, bool) {
+	if w := s.Workflows[key]; w != nil {
+		for _, r := range w.Runs {
+			if r.RunID == runID {
+				return r.DispatchInputs, true
+			}
+		}
+	}
+	return DispatchInputs{}, false
+}
+
+// RunFilter returns the filter of the run list of a workflow
+func (s *State) RunFilter(key string) RunFilter {
+	return s.RunFilters[key]
+}
+
+// SetRunFilter keeps the filter of the run list of a workflow, an empty
+// filter being forgotten
+func (s *State) SetRunFilter(key string, filter RunFilter) {
+	if filter == (RunFilter{}) {
+		delete(s.RunFilters, key)
+		return
+	}
+	if s.RunFilters == nil {
+		s.RunFilters = map[string]RunFilter{}
+	}
+	s.RunFilters[key] = filter
+}
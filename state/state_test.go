@@ -0,0 +1,134 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tgr", "state.json")
+	s, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load missing file: %v", err)
+	}
+
+	if !s.TogglePin("acme/api") || s.TogglePin("acme/api") || !s.TogglePin("acme/web") {
+		t.Errorf("unexpected TogglePin results, pinned %v", s.Pinned)
+	}
+	for i := range MaxRecent + 2 {
+		s.Visit(fmt.Sprintf("acme/repo%d", i))
+	}
+	s.Visit("acme/repo5")
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !slices.Equal(loaded.Pinned, []string{"acme/web"}) {
+		t.Errorf("pinned = %v, want [acme/web]", loaded.Pinned)
+	}
+	if len(loaded.Recent) != MaxRecent || loaded.Recent[0] != "acme/repo5" || loaded.Recent[1] != "acme/repo11" {
+		t.Errorf("unexpected recent list %v", loaded.Recent)
+	}
+}
+
+func TestDispatches(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	s, _ := Load(path)
+	key := WorkflowKey("acme", "api", ".github/workflows/deploy.yaml")
+
+	if _, ok := s.LastDispatch(key); ok {
+		t.Error("unexpected last dispatch in an empty state")
+	}
+	s.RememberDispatch(key, DispatchInputs{Ref: "main", Inputs: map[string]string{"version": "1.4.0"}})
+	s.SavePreset(key, Preset{Name: "staging", DispatchInputs: DispatchInputs{Ref: "main"}})
+	s.SavePreset(key, Preset{Name: "prod", DispatchInputs: DispatchInputs{Ref: "v1"}})
+	s.SavePreset(key, Preset{Name: "staging", DispatchInputs: DispatchInputs{Ref: "develop"}})
+	s.SavePreset(key, Preset{Name: "old", DispatchInputs: DispatchInputs{Ref: "main"}})
+	s.DeletePreset(key, "old")
+	for i := range MaxDispatchedRuns + 2 {
+		s.RecordRun(key, int64(i), DispatchInputs{Ref: fmt.Sprint(i)})
+	}
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if last, ok := loaded.LastDispatch(key); !ok || last.Ref != "main" || last.Inputs["version"] != "1.4.0" {
+		t.Errorf("last dispatch = %+v, %v", last, ok)
+	}
+	presets := loaded.Presets(key)
+	if len(presets) != 2 || presets[0].Name != "prod" || presets[1].Name != "staging" || presets[1].Ref != "develop" {
+		t.Errorf("unexpected presets %+v", presets)
+	}
+	if inputs, ok := loaded.RunInputs(key, MaxDispatchedRuns+1); !ok || inputs.Ref != fmt.Sprint(MaxDispatchedRuns+1) {
+		t.Errorf("run inputs = %+v, %v", inputs, ok)
+	}
+	if _, ok := loaded.RunInputs(key, 0); ok {
+		t.Error("the oldest run should have been dropped")
+	}
+}
+
+func TestRunFilters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	s, _ := Load(path)
+	ci, deploy := RunFilterKey("acme", "api", 101), RunFilterKey("acme", "api", 102)
+
+	s.SetRunFilter(ci, RunFilter{Branch: "main", Status: "failure"})
+	s.SetRunFilter(deploy, RunFilter{Actor: "octo"})
+	s.SetRunFilter(deploy, RunFilter{})
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := loaded.RunFilter(ci); got != (RunFilter{Branch: "main", Status: "failure"}) {
+		t.Errorf("filter of %s = %+v", ci, got)
+	}
+	if _, ok := loaded.RunFilters[deploy]; ok {
+		t.Errorf("empty filter of %s kept", deploy)
+	}
+}
+
+func TestReset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(path, []byte(`{"pinned": [`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatal("Load succeeded on a truncated file")
+	}
+
+	s, backup, err := Reset(path)
+	if err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	if data, err := os.ReadFile(backup); err != nil || string(data) != `{"pinned": [` {
+		t.Errorf("backup %s = %q, %v, want the unreadable file", backup, data, err)
+	}
+
+	// The new state is still saved to the file
+	s.TogglePin("acme/api")
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !slices.Equal(loaded.Pinned, []string{"acme/api"}) {
+		t.Errorf("pinned = %v, want [acme/api]", loaded.Pinned)
+	}
+}
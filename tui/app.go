@@ -2,30 +2,68 @@ package tui
 
 import (
 	"fmt"
+	"io"
 	"log/slog"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jjournet/tgr/config"
 	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/notify"
+	"github.com/jjournet/tgr/state"
 )
 
+// session is what the views of an App share: the GitHub API, the user
+// configuration, the pinned and recent repositories and the workflow
+// dispatches, and the notifier announcing the end of runs
+type session struct {
+	github.API
+	settings *config.Config
+	state    *state.State
+	notifier *notify.Notifier
+	// notifiedRuns remembers the runs already notified, so a run followed
+	// by both the monitor and the watch view is only notified once
+	notifiedRuns map[int64]bool
+}
+
 // App is the root model that manages the application
 type App struct {
-	ghService   *github.GitHubService
+	sess        *session
 	currentView tea.Model
 	err         error
 	initCmd     tea.Cmd // Store initial command to run in Init()
+	keys        KeyMap
+	showHelp    bool
 }
 
-// NewApp creates the root application model
-func NewApp(ghService *github.GitHubService) *App {
-	// Start with profile selection
-	profileView, initCmd := NewProfileSelection(ghService)
+// NewApp creates the root application model. Run end notifications are
+// written to out, the terminal of the program, which must serialize them
+// with the rendering (see notify.Terminal).
+func NewApp(ghService github.API, cfg *config.Config, st *state.State, out io.Writer) *App {
+	sess := &session{
+		API:          ghService,
+		settings:     cfg,
+		state:        st,
+		notifier:     notify.New(cfg.Notifications, out),
+		notifiedRuns: map[int64]bool{},
+	}
+
+	// Start with the dashboard when there is something to show, else with
+	// profile selection, jumping to the default owner if any
+	var firstView tea.Model
+	var initCmd tea.Cmd
+	if cfg.DefaultOwner == "" && len(sess.dashboardRepos()) > 0 {
+		firstView, initCmd = NewDashboard(sess)
+	} else {
+		firstView, initCmd = newProfileSelection(sess, cfg.DefaultOwner)
+	}
 
 	// Store the initial command to be returned from Init()
 	return &App{
-		ghService:   ghService,
-		currentView: profileView,
+		sess:        sess,
+		currentView: firstView,
 		initCmd:     initCmd,
+		keys:        keyMapFor(""),
 	}
 }
 
@@ -48,10 +86,22 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// Handle global keys
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
-		switch keyMsg.String() {
-		case "ctrl+c":
+		if key.Matches(keyMsg, forceQuitKey) {
 			return a, tea.Quit
 		}
+
+		// Any key closes the help overlay
+		if a.showHelp {
+			a.showHelp = false
+			return a, nil
+		}
+
+		if key.Matches(keyMsg, a.keys.Help) && !a.capturingInput() {
+			if _, ok := a.currentView.(helpProvider); ok {
+				a.showHelp = true
+				return a, nil
+			}
+		}
 	}
 
 	// Forward to current view
@@ -65,5 +115,18 @@ func (a *App) View() string {
 	if a.err != nil {
 		return a.err.Error()
 	}
+	if a.showHelp {
+		if hp, ok := a.currentView.(helpProvider); ok {
+			return renderHelp(append(hp.helpBindings(), a.keys.Help, forceQuitKey))
+		}
+	}
 	return a.currentView.View()
 }
+
+// capturingInput reports whether the current view is typing text
+func (a *App) capturingInput() bool {
+	if ic, ok := a.currentView.(inputCapturer); ok {
+		return ic.capturingInput()
+	}
+	return false
+}
@@ -0,0 +1,817 @@
+package tui
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jjournet/tgr/config"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/notify"
+	"github.com/jjournet/tgr/state"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/nacl/box"
+)
+
+var terminalSizes = []struct{ width, height int }{
+	{80, 24},
+	{120, 40},
+}
+
+func TestNavigation(t *testing.T) {
+	for _, size := range terminalSizes {
+		t.Run(fmt.Sprintf("%dx%d", size.width, size.height), func(t *testing.T) {
+			suffix := fmt.Sprintf("_%dx%d", size.width, size.height)
+			h := newHarness(t, size.width, size.height)
+			h.snapshot("profile_selection" + suffix)
+
+			h.keys("enter")
+			h.snapshot("repo_selection" + suffix)
+
+			h.keys("enter")
+			h.snapshot("repo_summary" + suffix)
+
+			h.keys("down", "down", "enter")
+			h.snapshot("workflow_list" + suffix)
+
+			h.keys("enter")
+			h.snapshot("run_list" + suffix)
+
+			h.keys("?")
+			h.snapshot("help_overlay" + suffix)
+			h.keys("?")
+
+			h.keys("enter")
+			h.snapshot("run_detail" + suffix)
+
+			h.keys("backspace", "w")
+			h.snapshot("run_watch" + suffix)
+
+			h.keys("backspace", "backspace", "backspace", "down", "down", "down", "enter")
+			h.snapshot("issue_list" + suffix)
+
+			h.keys("enter")
+			h.snapshot("issue_detail" + suffix)
+		})
+	}
+}
+
+func TestASCIIIcons(t *testing.T) {
+	if err := LoadTheme("dark", "ascii"); err != nil {
+		t.Fatalf("LoadTheme: %v", err)
+	}
+	t.Cleanup(func() { LoadTheme("dark", "nerd") })
+
+	h := newHarness(t, 100, 30)
+	h.keys("enter", "enter", "down", "down", "enter", "enter")
+	h.snapshot("run_list_ascii")
+	h.keys("w")
+	h.snapshot("run_watch_ascii")
+}
+
+func TestDashboard(t *testing.T) {
+	h := newHarness(t, 100, 30)
+	h.keys("enter", "p")
+	h.snapshot("repo_selection_pinned")
+
+	h.keys("backspace", "backspace")
+	h.snapshot("dashboard")
+
+	h.keys("p")
+	h.snapshot("dashboard_empty")
+}
+
+func TestRepoFilterWithoutMatch(t *testing.T) {
+	h := newHarness(t, 100, 30)
+	h.keys("enter", "/", "nomatch", "enter", "p", "enter")
+	if view := h.model.View(); strings.Contains(view, "Pinned") || !strings.Contains(view, "Repository") {
+		t.Errorf("pin or select acted on an empty list:\n%s", view)
+	}
+}
+
+func TestRunMonitor(t *testing.T) {
+	h := newHarness(t, 120, 30)
+	h.keys("enter", "p", "down", "p", "backspace", "backspace", "m")
+	h.snapshot("run_monitor")
+
+	// Drill down into the second run and come back
+	h.keys("down", "enter")
+	h.snapshot("run_monitor_watch")
+	h.keys("backspace")
+	h.snapshot("run_monitor_back")
+}
+
+func TestRunMonitorRefresh(t *testing.T) {
+	h := newHarness(t, 120, 30)
+	h.sess.settings.Monitor.Repos = []string{"acme/api"}
+	h.open(NewRunMonitor)
+	m := h.model.(*runMonitor)
+
+	// Results of the poll replaced by the refresh are dropped when they
+	// come back after it
+	stale := m.gen
+	h.keys("r")
+	h.send(monitorRunsLoadedMsg{Repo: "acme/api", Gen: stale, Runs: []github.RunInfo{{ID: 1, Title: "Stale", Status: "in_progress"}}})
+	if strings.Contains(h.model.View(), "Stale") {
+		t.Errorf("results of a replaced poll are shown:\n%s", h.model.View())
+	}
+	if m.loading || m.pending != 0 {
+		t.Errorf("loading %v with %d pending after the refresh, want done", m.loading, m.pending)
+	}
+
+	// The elapsed time of an active run follows the clock between polls
+	h.send(monitorRunsLoadedMsg{Repo: "acme/api", Gen: m.gen, Runs: []github.RunInfo{{
+		ID: 2, Title: "Active", Status: "in_progress", CreatedAt: time.Now().Add(-time.Hour - 300*time.Millisecond),
+	}}})
+	if !strings.Contains(h.model.View(), "1h0m0s") {
+		t.Fatalf("active run elapsed time missing:\n%s", h.model.View())
+	}
+	time.Sleep(time.Second)
+	h.send(monitorClockMsg{Gen: m.gen})
+	if !strings.Contains(h.model.View(), "1h0m1s") {
+		t.Errorf("elapsed time not refreshed by the clock:\n%s", h.model.View())
+	}
+}
+
+func TestInbox(t *testing.T) {
+	h := newHarness(t, 120, 24)
+	h.keys("backspace", "i")
+	h.snapshot("inbox")
+
+	h.keys("f", "f")
+	h.snapshot("inbox_reason")
+
+	// Open the mentioning issue and come back
+	h.keys("enter")
+	h.snapshot("inbox_issue")
+	h.keys("backspace", "f", "f", "e")
+	h.snapshot("inbox_done")
+}
+
+func TestBranches(t *testing.T) {
+	h := newHarness(t, 120, 24)
+	h.keys("enter", "enter", "down", "down", "down", "down", "enter")
+	h.snapshot("branch_list")
+
+	h.keys("down", "enter")
+	h.snapshot("compare")
+
+	// Compare two branches picked in the list
+	h.keys("backspace", "c", "down", "c")
+	h.snapshot("compare_branches")
+
+	h.keys("backspace", "D")
+	h.snapshot("branch_delete_confirm")
+}
+
+func TestCreateBranch(t *testing.T) {
+	h := newHarness(t, 120, 24)
+	h.open(func(sess *session) (tea.Model, tea.Cmd) {
+		return NewBranchList(sess, "acme", "api", "main")
+	})
+	// Created from the highlighted branch by default
+	h.keys("down", "n", "hotfix", "enter", "enter")
+	if view := h.model.View(); !strings.Contains(view, "Created hotfix") {
+		t.Errorf("creation not reported:\n%s", view)
+	}
+	if got, want := h.request(http.MethodPost, "/repos/acme/api/git/refs").json(), `{"ref":"refs/heads/hotfix","sha":"bbb2222"}`; got != want {
+		t.Errorf("created %s, want %s", got, want)
+	}
+}
+
+func TestCommits(t *testing.T) {
+	h := newHarness(t, 120, 30)
+	h.keys("enter", "enter", "down", "down", "down", "down", "enter", "down", "enter")
+	h.snapshot("commit_list")
+
+	h.keys("enter")
+	h.snapshot("commit_detail")
+
+	h.keys("enter")
+	h.snapshot("commit_run_watch")
+	h.keys("backspace", "backspace")
+	h.snapshot("commit_list_back")
+}
+
+func TestEnvironments(t *testing.T) {
+	h := newHarness(t, 120, 24)
+	h.keys("enter", "enter", "down", "down", "down", "down", "down", "enter")
+	h.snapshot("environment_list")
+
+	// A failed deployment lookup only affects its environment
+	h.send(latestDeploymentLoadedMsg{Environment: "production", Err: errors.New("403 Resource not accessible")})
+	view := h.model.View()
+	for _, want := range []string{"Environments (2)", "Never deployed", "403 Resource not accessible"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("environment list does not show %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestVariables(t *testing.T) {
+	h := newHarness(t, 120, 24)
+	h.keys("enter", "enter", "down", "down", "down", "down", "down", "down", "enter")
+	h.snapshot("variable_list")
+	h.keys("S")
+	h.snapshot("secret_list")
+	h.keys("s", "s")
+	h.snapshot("secret_list_environment")
+	h.keys("S")
+	h.snapshot("variable_list_environment")
+	h.keys("s", "S")
+	// orgs/acme/actions/secrets has no fixture, the error embeds the
+	// address of the fixture server
+	if view := h.model.View(); !strings.Contains(view, "Could not load the secrets of organization acme") {
+		t.Errorf("organization error not shown:\n%s", view)
+	}
+	h.keys("S")
+	h.snapshot("variable_list_organization")
+}
+
+func TestSetSecret(t *testing.T) {
+	h := newHarness(t, 120, 24)
+	h.open(func(sess *session) (tea.Model, tea.Cmd) {
+		return NewVariableList(sess, "acme", "api")
+	})
+	h.keys("S", "enter", "hunter2")
+	view := h.model.View()
+	if !strings.Contains(view, "Value of DEPLOY_KEY: ") || strings.Contains(view, "hunter2") {
+		t.Errorf("secret value input not masked:\n%s", view)
+	}
+	h.keys("enter")
+	if view := h.model.View(); !strings.Contains(view, "Saved secret DEPLOY_KEY") {
+		t.Errorf("secret update not reported:\n%s", view)
+	}
+	// The public key of the fixture is the one of this private key
+	var priv, pub [32]byte
+	for i := range priv {
+		priv[i] = byte(i + 1)
+	}
+	curve25519.ScalarBaseMult(&pub, &priv)
+	body := h.request(http.MethodPut, "/repos/acme/api/actions/secrets/DEPLOY_KEY").Body
+	sealed, err := base64.StdEncoding.DecodeString(fmt.Sprint(body["encrypted_value"]))
+	if err != nil {
+		t.Fatalf("encrypted_value: %v", err)
+	}
+	if value, ok := box.OpenAnonymous(nil, sealed, &pub, &priv); !ok || string(value) != "hunter2" || body["key_id"] != "568250167242549743" {
+		t.Errorf("secret sent as %q with key %v", value, body["key_id"])
+	}
+
+	h.keys("S", "D")
+	if view := h.model.View(); !strings.Contains(view, "Delete variable API_URL?") {
+		t.Errorf("deletion not confirmed first:\n%s", view)
+	}
+	h.keys("enter")
+	if view := h.model.View(); !strings.Contains(view, "Deleted variable API_URL") {
+		t.Errorf("deletion not reported:\n%s", view)
+	}
+	h.request(http.MethodDelete, "/repos/acme/api/actions/variables/API_URL")
+}
+
+func TestProjects(t *testing.T) {
+	h := newHarness(t, 120, 24)
+	h.keys("enter", "enter", "enter")
+	h.snapshot("project_list")
+	h.keys("s")
+	h.snapshot("project_list_owner")
+	h.keys("enter")
+	h.snapshot("project_board")
+	h.keys("v")
+	h.snapshot("project_table")
+	h.keys("v", "g")
+	h.snapshot("project_board_priority")
+}
+
+func TestEditProjectItem(t *testing.T) {
+	h := newHarness(t, 120, 24)
+	h.open(func(sess *session) (tea.Model, tea.Cmd) {
+		return NewProjectBoard(sess, "acme", "api", github.ProjectInfo{ID: "PVT_roadmap", Number: 3, Title: "API roadmap"}, nil)
+	})
+	// The draft item without status comes first
+	h.keys("l", ">")
+	h.snapshot("project_board_moved")
+
+	h.keys("e")
+	if view := h.model.View(); !strings.Contains(view, "Status [Todo|In Progress|Done]: In Progress") {
+		t.Errorf("field input not shown:\n%s", view)
+	}
+	// The input starts with the current value, High
+	h.keys("tab", "backspace", "backspace", "backspace", "backspace", "low", "enter")
+	if view := h.model.View(); !strings.Contains(view, "Set Priority of #7 to Low") {
+		t.Errorf("field update not reported:\n%s", view)
+	}
+
+	h.keys("n", "web#4", "enter")
+	if view := h.model.View(); !strings.Contains(view, "Added acme/web#4") {
+		t.Errorf("added item not reported:\n%s", view)
+	}
+
+	if got, want := h.graphql("SetProjectField"), []string{
+		`{"field":"PVTSSF_status","item":"PVTI_1","project":"PVT_roadmap","value":{"singleSelectOptionId":"st_progress"}}`,
+		`{"field":"PVTSSF_priority","item":"PVTI_1","project":"PVT_roadmap","value":{"singleSelectOptionId":"pr_low"}}`,
+	}; !slices.Equal(got, want) {
+		t.Errorf("field updates %q, want %q", got, want)
+	}
+	if got, want := h.graphql("AddProjectItem"), []string{`{"content":"I_web4","project":"PVT_roadmap"}`}; !slices.Equal(got, want) {
+		t.Errorf("added items %q, want %q", got, want)
+	}
+}
+
+func TestReleases(t *testing.T) {
+	h := newHarness(t, 120, 24)
+	h.keys("enter", "enter", "down", "down", "down", "down", "down", "down", "down", "enter")
+	h.snapshot("release_list")
+	h.keys("down", "enter")
+	h.snapshot("release_notes")
+	h.keys("backspace", "s")
+	h.snapshot("release_tags")
+	// v1.3.0 has no release yet
+	h.keys("up", "enter")
+	h.snapshot("release_form")
+}
+
+func TestCreateRelease(t *testing.T) {
+	asset := filepath.Join(t.TempDir(), "api.tar.gz")
+	if err := os.WriteFile(asset, []byte("binary"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	h := newHarness(t, 120, 24)
+	h.open(func(sess *session) (tea.Model, tea.Cmd) {
+		return NewReleaseList(sess, "acme", "api", "main")
+	})
+	h.keys("n", "enter")
+	if view := h.model.View(); !strings.Contains(view, "a tag is required") {
+		t.Errorf("missing tag not reported:\n%s", view)
+	}
+	h.keys("v2.0.0", "tab", "tab", "tab", "missing.zip", "enter")
+	if view := h.model.View(); !strings.Contains(view, "missing.zip: no such file") {
+		t.Errorf("missing asset not reported:\n%s", view)
+	}
+	for range "missing.zip" {
+		h.keys("backspace")
+	}
+	h.keys(asset, "tab", " ", "enter")
+	if view := h.model.View(); !strings.Contains(view, "Created release v2.0.0") {
+		t.Errorf("creation not reported:\n%s", view)
+	}
+	if got, want := h.request(http.MethodPost, "/repos/acme/api/releases").json(),
+		`{"draft":true,"generate_release_notes":true,"name":"","prerelease":false,"tag_name":"v2.0.0","target_commitish":"main"}`; got != want {
+		t.Errorf("created %s, want %s", got, want)
+	}
+	if upload := h.request(http.MethodPost, "/repos/acme/api/releases/9004/assets"); upload.Query.Get("name") != "api.tar.gz" || string(upload.Raw) != "binary" {
+		t.Errorf("asset uploaded as %q: %q", upload.Query.Get("name"), upload.Raw)
+	}
+
+	h.keys("P")
+	if view := h.model.View(); !strings.Contains(view, "Publish v1.3.0-rc.1?") {
+		t.Errorf("publication not confirmed first:\n%s", view)
+	}
+	h.keys("enter")
+	if view := h.model.View(); !strings.Contains(view, "Published v1.3.0-rc.1") {
+		t.Errorf("publication not reported:\n%s", view)
+	}
+	if got := h.request(http.MethodPatch, "/repos/acme/api/releases/9003").json(); got != `{"draft":false}` {
+		t.Errorf("published with %s", got)
+	}
+
+	h.keys("u", asset, "enter")
+	if view := h.model.View(); !strings.Contains(view, "Uploaded asset to v1.3.0-rc.1") {
+		t.Errorf("upload not reported:\n%s", view)
+	}
+	if upload := h.request(http.MethodPost, "/repos/acme/api/releases/9003/assets"); upload.Query.Get("name") != "api.tar.gz" || string(upload.Raw) != "binary" {
+		t.Errorf("asset uploaded as %q: %q", upload.Query.Get("name"), upload.Raw)
+	}
+}
+
+func TestArtifacts(t *testing.T) {
+	h := newHarness(t, 120, 24)
+	h.open(func(sess *session) (tea.Model, tea.Cmd) {
+		return NewWorkflowRunDetail(sess, "acme", "api", 101, 5001)
+	})
+	h.keys("a")
+	h.snapshot("artifact_list")
+	h.keys("enter")
+	h.snapshot("artifact_preview")
+	h.keys("backspace", "down", "enter")
+	if view := h.model.View(); !strings.Contains(view, "api-linux-amd64 is too large to preview") {
+		t.Errorf("large artifact previewed:\n%s", view)
+	}
+}
+
+func TestDownloadArtifact(t *testing.T) {
+	dir := t.TempDir()
+	h := newHarness(t, 120, 24)
+	h.open(func(sess *session) (tea.Model, tea.Cmd) {
+		return NewArtifactList(sess, "acme", "api", 5001, 42, nil)
+	})
+	h.keys("d", "backspace", dir, "enter")
+	if view := h.model.View(); !strings.Contains(view, "Extracted 3 file(s) of test-report") {
+		t.Errorf("download not reported:\n%s", view)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "test-report", "summary.txt"))
+	if err != nil || !strings.HasPrefix(string(data), "Tests: 128 passed") {
+		t.Errorf("summary.txt = %q, %v", data, err)
+	}
+
+	h.keys("D", "enter")
+	if view := h.model.View(); !strings.Contains(view, "Deleted test-report") {
+		t.Errorf("deletion not reported:\n%s", view)
+	}
+	h.request(http.MethodDelete, "/repos/acme/api/actions/artifacts/7001")
+}
+
+func TestCaches(t *testing.T) {
+	h := newHarness(t, 120, 24)
+	h.keys("enter", "enter", "down", "down", "down", "down", "down", "down", "down", "down", "enter")
+	h.snapshot("cache_list")
+	h.keys("o")
+	h.snapshot("cache_list_size")
+	h.keys("/", "feature/retry", "enter")
+	h.snapshot("cache_list_filtered")
+}
+
+func TestDeleteCaches(t *testing.T) {
+	h := newHarness(t, 120, 24)
+	h.open(func(sess *session) (tea.Model, tea.Cmd) {
+		return NewCacheList(sess, "acme", "api")
+	})
+	h.keys("x", "x", "D")
+	if view := h.model.View(); !strings.Contains(view, "Delete 2 cache(s) (2.2 GiB)?") {
+		t.Errorf("deletion not confirmed first:\n%s", view)
+	}
+	h.keys("enter")
+	if view := h.model.View(); !strings.Contains(view, "Deleted 2 cache(s)") {
+		t.Errorf("deletion not reported:\n%s", view)
+	}
+	for _, id := range []string{"801", "802"} {
+		h.request(http.MethodDelete, "/repos/acme/api/actions/caches/"+id)
+	}
+
+	// The highlight moved to the feature/retry build while selecting
+	h.keys("X")
+	if view := h.model.View(); !strings.Contains(view, "Delete all 2 cache(s) of feature/retry") {
+		t.Errorf("purge not confirmed first:\n%s", view)
+	}
+	h.keys("esc")
+	if view := h.model.View(); strings.Contains(view, "Delete all 2") {
+		t.Errorf("purge not cancelled:\n%s", view)
+	}
+	for _, r := range h.rec.requests {
+		if r.Method == http.MethodDelete && !slices.Contains([]string{"/repos/acme/api/actions/caches/801", "/repos/acme/api/actions/caches/802"}, r.Path) {
+			t.Errorf("cancelled purge sent DELETE %s", r.Path)
+		}
+	}
+}
+
+func TestRunners(t *testing.T) {
+	h := newHarness(t, 120, 24)
+	h.keys("enter", "enter", "down", "down", "down", "down", "down", "down", "down", "down", "down", "enter")
+	h.snapshot("runner_list")
+	h.keys("s")
+	h.snapshot("runner_list_org")
+	h.keys("g")
+	h.snapshot("runner_groups")
+}
+
+func TestRunnerActions(t *testing.T) {
+	h := newHarness(t, 120, 24)
+	h.open(func(sess *session) (tea.Model, tea.Cmd) {
+		return NewRunnerList(sess, "acme", "api")
+	})
+	h.keys("n")
+	if view := h.model.View(); !strings.Contains(view, "./config.sh --url https://github.com/acme/api --token AABF3JGZDX3P5PMEXLND6TS6FCWO6") {
+		t.Errorf("registration token not shown:\n%s", view)
+	}
+
+	h.keys("down", "D")
+	if view := h.model.View(); !strings.Contains(view, "Remove runner api-builder-2 from repository acme/api?") {
+		t.Errorf("removal not confirmed first:\n%s", view)
+	}
+	h.keys("enter")
+	if view := h.model.View(); !strings.Contains(view, "Removed runner api-builder-2") {
+		t.Errorf("removal not reported:\n%s", view)
+	}
+}
+
+func TestWorkflowActions(t *testing.T) {
+	h := newHarness(t, 120, 24)
+	h.open(func(sess *session) (tea.Model, tea.Cmd) {
+		return NewWorkflowList(sess, "acme", "api")
+	})
+	h.keys("y")
+	h.snapshot("workflow_source")
+
+	h.keys("backspace", "t")
+	if view := h.model.View(); !strings.Contains(view, "CI has no workflow_dispatch trigger") {
+		t.Errorf("trigger of a workflow without dispatch not refused:\n%s", view)
+	}
+	h.keys("E")
+	if view := h.model.View(); !strings.Contains(view, "Disabled CI") || !strings.Contains(view, "disabled_manually") {
+		t.Errorf("disabling not reported:\n%s", view)
+	}
+	h.keys("down", "E")
+	if view := h.model.View(); !strings.Contains(view, "Enabled Deploy") {
+		t.Errorf("enabling not reported:\n%s", view)
+	}
+}
+
+func TestWorkflowSummaryFailure(t *testing.T) {
+	h := newHarness(t, 120, 24)
+	h.open(func(sess *session) (tea.Model, tea.Cmd) {
+		return NewWorkflowList(sess, "acme", "api")
+	})
+	// The fixtures answer every call, fail one as a slow API would
+	h.send(workflowSummaryLoadedMsg{WorkflowID: 102, Err: errors.New("loading the usage: context deadline exceeded")})
+	view := h.model.View()
+	for _, want := range []string{"Summary incomplete for 1 workflow(s)", "loading the usage: context"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("workflow list does not show %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestWorkflowAnalytics(t *testing.T) {
+	dir := t.TempDir()
+	h := newHarness(t, 120, 30)
+	h.open(func(sess *session) (tea.Model, tea.Cmd) {
+		return NewWorkflowList(sess, "acme", "api")
+	})
+	h.keys("A")
+	h.snapshot("workflow_analytics")
+
+	// The file is named after the workflow, in the working directory
+	h.keys("d")
+	if view := h.model.View(); !strings.Contains(view, "Export CSV to: ci-analytics.csv") {
+		t.Errorf("export file not suggested:\n%s", view)
+	}
+	file := filepath.Join(dir, "ci.csv")
+	for range len("ci-analytics.csv") {
+		h.keys("backspace")
+	}
+	h.keys(file, "enter")
+	if view := h.model.View(); !strings.Contains(view, "Exported to "+file) {
+		t.Errorf("export not reported:\n%s", view)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil || !strings.Contains(string(data), "\ntest,go test ./...,2,2,0.500,") {
+		t.Errorf("ci.csv = %q, %v", data, err)
+	}
+}
+
+func TestWorkflowInputForm(t *testing.T) {
+	h := newHarness(t, 120, 32)
+	h.open(func(sess *session) (tea.Model, tea.Cmd) {
+		return NewWorkflowInputForm(sess, "acme", "api", 102, ".github/workflows/deploy.yaml", nil)
+	})
+	h.snapshot("workflow_input_form")
+	// The environment picker only needs the names
+	if n := len(h.requests(http.MethodGet, "/repos/acme/api/deployments")); n != 0 {
+		t.Errorf("form loaded deployments %d time(s)", n)
+	}
+
+	h.keys("enter")
+	if view := h.model.View(); !strings.Contains(view, "version*  required") {
+		t.Errorf("missing required input not reported:\n%s", view)
+	}
+	// The focus moved to version
+	h.keys("1.4.0", "tab", "backspace", "two", "tab", "right", "tab", " ", "enter")
+	if view := h.model.View(); !strings.Contains(view, "must be a number") {
+		t.Errorf("invalid number not reported:\n%s", view)
+	}
+	h.keys("backspace", "backspace", "backspace", "3")
+	h.snapshot("workflow_input_form_filled")
+	h.keys("enter")
+	if view := h.model.View(); !strings.Contains(view, "Workflow has been queued") {
+		t.Errorf("dispatch not reported:\n%s", view)
+	}
+	if got, want := h.request(http.MethodPost, "/repos/acme/api/actions/workflows/102/dispatches").json(),
+		`{"inputs":{"dry_run":"true","environment":"staging","log_level":"debug","replicas":"3","version":"1.4.0"},"ref":"main"}`; got != want {
+		t.Errorf("dispatched %s, want %s", got, want)
+	}
+}
+
+func TestWorkflowInputPresets(t *testing.T) {
+	h := newHarness(t, 120, 36)
+	open := func() {
+		h.open(func(sess *session) (tea.Model, tea.Cmd) {
+			return NewWorkflowInputForm(sess, "acme", "api", 102, ".github/workflows/deploy.yaml", nil)
+		})
+	}
+	open()
+	h.keys("tab", "tab", "1.4.0", "tab", "tab", "right", "ctrl+s", "nightly", "enter")
+	if view := h.model.View(); !strings.Contains(view, "Saved preset nightly") {
+		t.Errorf("preset not saved:\n%s", view)
+	}
+	h.keys("enter")
+	if got, want := h.request(http.MethodPost, "/repos/acme/api/actions/workflows/102/dispatches").json(),
+		`{"inputs":{"dry_run":"false","environment":"staging","log_level":"debug","replicas":"2","version":"1.4.0"},"ref":"main"}`; got != want {
+		t.Errorf("dispatched %s, want %s", got, want)
+	}
+	last, ok := h.sess.state.LastDispatch(state.WorkflowKey("acme", "api", ".github/workflows/deploy.yaml"))
+	if !ok || last.Ref != "main" || last.Inputs["version"] != "1.4.0" || last.Inputs["log_level"] != "debug" {
+		t.Errorf("last dispatch = %+v, %v", last, ok)
+	}
+
+	// The next form starts from the last values
+	open()
+	h.snapshot("workflow_input_form_last")
+	h.keys("ctrl+o")
+	h.snapshot("workflow_input_presets")
+	h.keys("D")
+	if view := h.model.View(); !strings.Contains(view, "Deleted preset nightly") || strings.Contains(view, "Presets:") {
+		t.Errorf("preset not deleted:\n%s", view)
+	}
+	h.keys("ctrl+o")
+	if view := h.model.View(); !strings.Contains(view, "No preset saved for this workflow") {
+		t.Errorf("missing presets not reported:\n%s", view)
+	}
+}
+
+func TestRunFilters(t *testing.T) {
+	h := newHarness(t, 120, 30)
+	open := func() {
+		h.open(func(sess *session) (tea.Model, tea.Cmd) {
+			return NewWorkflowRunList(sess, "acme", "api", 101)
+		})
+	}
+	open()
+	h.keys("f", "m")
+	if view := h.model.View(); !strings.Contains(view, "actor:octo status:failure") {
+		t.Errorf("toggles not applied:\n%s", view)
+	}
+
+	// Fields are edited at the cursor
+	h.keys("/", "man", "left", "i", "tab", "tab", "tab")
+	h.keys("backspace", "backspace", "backspace", "backspace", "backspace", "backspace", "backspace", "done", "enter")
+	if view := h.model.View(); !strings.Contains(view, `unknown status "done"`) {
+		t.Errorf("invalid status not refused:\n%s", view)
+	}
+	h.keys("backspace", "backspace", "backspace", "backspace", "success")
+	h.snapshot("run_filter_form")
+	h.keys("enter")
+
+	// The filter is kept for the next visit
+	open()
+	h.snapshot("run_list_filtered")
+	if got := h.sess.state.RunFilter(state.RunFilterKey("acme", "api", 101)); got != (state.RunFilter{Branch: "main", Actor: "octo", Status: "success"}) {
+		t.Errorf("saved filter = %+v", got)
+	}
+	h.keys("m", "f")
+	if view := h.model.View(); !strings.Contains(view, "branch:main status:failure") {
+		t.Errorf("toggles not reverted:\n%s", view)
+	}
+}
+
+func TestRunFilterWithoutMatch(t *testing.T) {
+	h := newHarness(t, 120, 30)
+	h.open(func(sess *session) (tea.Model, tea.Cmd) {
+		return NewWorkflowRunList(sess, "acme", "api", 101)
+	})
+	h.keys("f")
+	// The fixtures ignore the filter, answer as GitHub would
+	h.send(workflowRunsLoadedMsg{WorkflowID: 101})
+	h.keys("enter", "w")
+	if view := h.model.View(); !strings.Contains(view, "0 runs") || !strings.Contains(view, "status:failure") {
+		t.Errorf("empty filtered list not shown:\n%s", view)
+	}
+}
+
+func TestRefPicker(t *testing.T) {
+	h := newHarness(t, 120, 36)
+	h.open(func(sess *session) (tea.Model, tea.Cmd) {
+		return NewWorkflowInputForm(sess, "acme", "api", 102, ".github/workflows/deploy.yaml", nil)
+	})
+	h.keys("backspace", "backspace", "backspace", "backspace", "v1")
+	h.snapshot("workflow_input_refs")
+	h.keys("down", "enter")
+	if view := h.model.View(); !strings.Contains(view, "v1.2.0") || strings.Contains(view, "v1.3.0") {
+		t.Errorf("suggestion not picked:\n%s", view)
+	}
+
+	// Refs missing from the lists are looked up before dispatching
+	h.keys("tab", "tab", "1.4.0", "shift+tab", "shift+tab")
+	h.keys("backspace", "backspace", "backspace", "backspace", "backspace", "backspace", "nope", "enter")
+	if view := h.model.View(); !strings.Contains(view, "no such branch or tag") {
+		t.Errorf("unknown ref not refused:\n%s", view)
+	}
+	if n := len(h.requests(http.MethodPost, "/repos/acme/api/actions/workflows/102/dispatches")); n != 0 {
+		t.Errorf("dispatched %d time(s) on an unknown ref", n)
+	}
+	h.keys("backspace", "backspace", "backspace", "backspace", "release", "enter")
+	if view := h.model.View(); !strings.Contains(view, "Workflow has been queued") {
+		t.Errorf("dispatch on an existing ref not sent:\n%s", view)
+	}
+	if got, want := h.request(http.MethodPost, "/repos/acme/api/actions/workflows/102/dispatches").json(),
+		`{"inputs":{"dry_run":"false","environment":"staging","log_level":"info","replicas":"2","version":"1.4.0"},"ref":"release"}`; got != want {
+		t.Errorf("dispatched %s, want %s", got, want)
+	}
+}
+
+func TestRefPickerDefaultBranch(t *testing.T) {
+	h := newHarness(t, 120, 36)
+	h.open(func(sess *session) (tea.Model, tea.Cmd) {
+		return NewWorkflowInputForm(sess, "acme", "billing", 301, ".github/workflows/release.yaml", nil)
+	})
+	if view := h.model.View(); !strings.Contains(view, "develop") || strings.Contains(view, "main") {
+		t.Errorf("the default branch is not the initial ref:\n%s", view)
+	}
+}
+
+func TestLinkDispatchedRun(t *testing.T) {
+	h := newHarness(t, 120, 36)
+	h.open(func(sess *session) (tea.Model, tea.Cmd) {
+		return NewWorkflowInputForm(sess, "acme", "api", 102, ".github/workflows/deploy.yaml", nil)
+	})
+	// Two runs of octo on main match the dispatch
+	h.keys("tab", "tab", "1.4.0", "enter")
+	h.snapshot("workflow_run_chooser")
+	if got, want := h.request(http.MethodPost, "/repos/acme/api/actions/workflows/102/dispatches").json(),
+		`{"inputs":{"dry_run":"false","environment":"staging","log_level":"info","replicas":"2","version":"1.4.0"},"ref":"main"}`; got != want {
+		t.Errorf("dispatched %s, want %s", got, want)
+	}
+
+	h.keys("down", "enter")
+	if view := h.model.View(); !strings.Contains(view, "Deploy") || !strings.Contains(view, "#12") {
+		t.Errorf("watch view of the chosen run not shown:\n%s", view)
+	}
+	inputs, ok := h.sess.state.RunInputs(state.WorkflowKey("acme", "api", ".github/workflows/deploy.yaml"), 5004)
+	if !ok || inputs.Inputs["version"] != "1.4.0" {
+		t.Errorf("inputs of the chosen run = %+v, %v", inputs, ok)
+	}
+}
+
+func TestRedispatch(t *testing.T) {
+	h := newHarness(t, 120, 36)
+	h.sess.state.RecordRun(state.WorkflowKey("acme", "api", ".github/workflows/deploy.yaml"), 5004, state.DispatchInputs{
+		Ref:    "release",
+		Inputs: map[string]string{"environment": "production", "version": "1.2.0", "log_level": "warning"},
+	})
+	h.open(func(sess *session) (tea.Model, tea.Cmd) {
+		return NewWorkflowRunDetail(sess, "acme", "api", 102, 5004)
+	})
+	h.keys("t")
+	h.snapshot("workflow_redispatch")
+
+	// Runs not dispatched from tgr are dispatched again with the defaults
+	h.open(func(sess *session) (tea.Model, tea.Cmd) {
+		return NewWorkflowRunDetail(sess, "acme", "api", 101, 5001)
+	})
+	h.keys("t")
+	if view := h.model.View(); !strings.Contains(view, "The inputs of run #42 are unknown") {
+		t.Errorf("unknown inputs not reported:\n%s", view)
+	}
+}
+
+func TestNotifyRunEnd(t *testing.T) {
+	h := newHarness(t, 120, 24)
+	h.sess.notifier = notify.New(config.NotificationsConfig{Success: []string{"bell", "osc9"}}, &h.out)
+	h.open(func(sess *session) (tea.Model, tea.Cmd) {
+		return NewWorkflowRunWatch(sess, "acme", "api", 102, 5004, nil)
+	})
+	if h.out.Len() != 0 {
+		t.Fatalf("running run notified: %q", h.out.String())
+	}
+
+	run := &github.RunDetailInfo{ID: 5004, Name: "Deploy", Branch: "main", Status: "completed", Conclusion: "success"}
+	h.send(runDetailLoadedMsg{Run: run})
+	h.send(runDetailLoadedMsg{Run: run})
+	if got, want := h.out.String(), "\a\x1b]9;Deploy success: acme/api on main\x07"; got != want {
+		t.Errorf("terminal got %q, want %q", got, want)
+	}
+}
+
+func TestReviewPendingDeployment(t *testing.T) {
+	h := newHarness(t, 120, 24)
+	h.open(func(sess *session) (tea.Model, tea.Cmd) {
+		return NewWorkflowRunWatch(sess, "acme", "api", 102, 5004, nil)
+	})
+	// The elapsed time of a waiting run changes, so no snapshot here
+	view := h.model.View()
+	for _, want := range []string{"Waiting for review", "production  reviewers: octo, release-managers", "(a) Approve", "(x) Reject"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("watch view does not show %q:\n%s", want, view)
+		}
+	}
+
+	h.keys("a", "ship it")
+	if view := h.model.View(); !strings.Contains(view, "Approve production, comment: ship it") {
+		t.Errorf("comment input not shown:\n%s", view)
+	}
+	h.keys("enter")
+	if view := h.model.View(); !strings.Contains(view, "Approved production") {
+		t.Errorf("approval not reported:\n%s", view)
+	}
+	if got, want := h.request(http.MethodPost, "/repos/acme/api/actions/runs/5004/pending_deployments").json(),
+		`{"comment":"ship it","environment_ids":[301],"state":"approved"}`; got != want {
+		t.Errorf("reviewed with %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,23 @@
+package tui
+
+import (
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+// LoadTheme installs the named theme and icon set used by every view
+func LoadTheme(name, icons string) error {
+	t, err := theme.Load(name, theme.Dir())
+	if err != nil {
+		return err
+	}
+	set, err := theme.LoadIcons(icons)
+	if err != nil {
+		return err
+	}
+
+	theme.Current = t
+	theme.Icons = set
+	constants.ApplyTheme()
+	return nil
+}
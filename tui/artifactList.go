@@ -0,0 +1,389 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+// artifactPreviewLimit is the largest artifact read in memory for preview
+const artifactPreviewLimit = 1 << 20
+
+type artifactListView struct {
+	commonElements
+
+	// Service
+	sess *session
+
+	// Context
+	owner     string
+	repoName  string
+	runID     int64
+	runNumber int
+
+	// State
+	artifacts []github.ArtifactInfo
+	loading   bool
+	listErr   error  // shown instead of the list
+	status    string // result of the last action
+
+	// Actions
+	confirmDelete   *github.ArtifactInfo // artifact waiting for the deletion to be confirmed
+	downloadFrom    *github.ArtifactInfo // artifact downloaded to the typed directory
+	downloading     *github.ArtifactInfo
+	received        float64 // share of the download received, from 0 to 1
+	waitForProgress tea.Cmd // waits for the next progress update of the download
+
+	// UI
+	EltList        table.Model
+	progress       progress.Model
+	visibleCommand bool
+	keys           KeyMap
+
+	// Navigation
+	parentView tea.Model
+}
+
+func (m *artifactListView) resizeMain(w int, h int) {
+	headerHeight := lipgloss.Height(m.RenderTopFields())
+	footerHeight := lipgloss.Height(m.RenderBottomFields())
+	cmdHeight := 0
+	if m.visibleCommand || m.downloading != nil {
+		cmdHeight = 3
+	}
+	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2 - cmdHeight)
+	m.EltList = m.EltList.WithPageSize(h - headerHeight - footerHeight - 3 - cmdHeight)
+	constants.CommandStyle = constants.CommandStyle.Width(w - 2).Height(1)
+	m.progress.Width = max(w-40, 10)
+}
+
+// NewArtifactList creates a view listing the artifacts of a run, to
+// preview, download or delete them. Back returns to parentView.
+func NewArtifactList(sess *session, owner, repoName string, runID int64, runNumber int, parentView tea.Model) (tea.Model, tea.Cmd) {
+	m := &artifactListView{
+		sess:       sess,
+		owner:      owner,
+		repoName:   repoName,
+		runID:      runID,
+		runNumber:  runNumber,
+		loading:    true,
+		parentView: parentView,
+		keys:       keyMapFor(viewArtifactList),
+	}
+	m.keys.Select = withDesc(m.keys.Select, "Preview")
+
+	m.InitTop(owner, repoName, fmt.Sprintf("Artifacts of run #%d", runNumber))
+	m.TopFields = []string{owner, repoName, fmt.Sprintf("Artifacts of run #%d", runNumber)}
+	m.InitBottom()
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Select, m.keys.Download, m.keys.Delete, m.keys.Refresh, m.keys.Back, m.keys.Help)
+	m.CommandInput = textinput.New()
+	m.progress = progress.New(
+		progress.WithSolidFill(string(theme.Current.Primary)),
+		progress.WithColorProfile(lipgloss.ColorProfile()),
+	)
+
+	return m, loadArtifactsCmd(sess, owner, repoName, runID)
+}
+
+func (m *artifactListView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *artifactListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case artifactsLoadedMsg:
+		m.loading = false
+		m.artifacts, m.listErr = msg.Artifacts, msg.Err
+		m.rebuild()
+		return m, nil
+
+	case artifactProgressMsg:
+		if m.downloading == nil {
+			return m, nil // the download ended meanwhile
+		}
+		total := msg.Total
+		if total <= 0 {
+			total = m.downloading.Size
+		}
+		if total > 0 {
+			m.received = min(float64(msg.Done)/float64(total), 1)
+		}
+		return m, m.waitForProgress
+
+	case artifactDownloadedMsg:
+		m.downloading = nil
+		m.waitForProgress = nil
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Could not download %s: %v", msg.Name, msg.Err)
+		} else {
+			m.status = fmt.Sprintf("Extracted %d file(s) of %s to %s", len(msg.Files), msg.Name, msg.Dir)
+		}
+		m.rebuild()
+		return m, nil
+
+	case artifactDeletedMsg:
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Could not delete %s: %v", msg.Name, msg.Err)
+			m.rebuild()
+			return m, nil
+		}
+		m.status = "Deleted " + msg.Name
+		m.loading = true
+		return m, loadArtifactsCmd(m.sess, m.owner, m.repoName, m.runID)
+
+	case tea.WindowSizeMsg:
+		constants.WindowSize = msg
+		m.resizeMain(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading {
+			if isQuit(msg, m.keys) {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		if m.visibleCommand {
+			return m.handleDownloadInput(msg)
+		}
+
+		if m.confirmDelete != nil {
+			artifact := *m.confirmDelete
+			m.confirmDelete = nil
+			if key.Matches(msg, m.keys.Select) {
+				m.status = "Deleting " + artifact.Name + "..."
+				m.rebuild()
+				return m, deleteArtifactCmd(m.sess, m.owner, m.repoName, artifact)
+			}
+			m.status = ""
+			m.rebuild()
+			return m, nil
+		}
+
+		switch {
+		case isQuit(msg, m.keys):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return m.parentView, m.parentView.Init()
+		case key.Matches(msg, m.keys.Refresh):
+			m.status = ""
+			m.loading = true
+			return m, loadArtifactsCmd(m.sess, m.owner, m.repoName, m.runID)
+		}
+
+		artifact, ok := m.highlighted()
+		if !ok {
+			break
+		}
+		switch {
+		case key.Matches(msg, m.keys.Select):
+			switch {
+			case artifact.Expired:
+				m.status = artifact.Name + " has expired"
+			case artifact.Size > artifactPreviewLimit:
+				m.status = fmt.Sprintf("%s is too large to preview, download it with %s", artifact.Name, m.keys.Download.Help().Key)
+			default:
+				return NewArtifactPreview(m.sess, m.owner, m.repoName, artifact, m)
+			}
+			m.rebuild()
+			return m, nil
+		case key.Matches(msg, m.keys.Download):
+			if artifact.Expired {
+				m.status = artifact.Name + " has expired"
+				m.rebuild()
+				return m, nil
+			}
+			if m.downloading != nil {
+				m.status = "Wait for the download of " + m.downloading.Name
+				m.rebuild()
+				return m, nil
+			}
+			m.downloadFrom = &artifact
+			m.CommandInput.Prompt = fmt.Sprintf("Download %s to: ", artifact.Name)
+			m.CommandInput.SetValue(".")
+			m.CommandInput.CursorEnd()
+			m.CommandInput.Focus()
+			m.visibleCommand = true
+			m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+			return m, nil
+		case key.Matches(msg, m.keys.Delete):
+			m.confirmDelete = &artifact
+			m.status = fmt.Sprintf("Delete artifact %s? (%s) Confirm, any other key cancels", artifact.Name, m.keys.Select.Keys()[0])
+			m.rebuild()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.EltList, cmd = m.EltList.Update(msg)
+	return m, cmd
+}
+
+// handleDownloadInput reads the directory the artifact is extracted to,
+// in a folder named after it
+func (m *artifactListView) handleDownloadInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeDownloadInput()
+		return m, nil
+	case "enter":
+		dir := strings.TrimSpace(m.CommandInput.Value())
+		if dir == "" {
+			return m, nil
+		}
+		artifact := *m.downloadFrom
+		m.closeDownloadInput()
+		m.downloading = &artifact
+		m.received = 0
+		m.status = ""
+		m.rebuild()
+
+		progress := make(chan artifactProgressMsg, 1)
+		m.waitForProgress = waitForArtifactProgressCmd(progress)
+		return m, tea.Batch(
+			downloadArtifactCmd(m.sess, m.owner, m.repoName, artifact, filepath.Join(dir, artifact.Name), progress),
+			m.waitForProgress,
+		)
+	}
+
+	var cmd tea.Cmd
+	m.CommandInput, cmd = m.CommandInput.Update(msg)
+	return m, cmd
+}
+
+func (m *artifactListView) closeDownloadInput() {
+	m.downloadFrom = nil
+	m.visibleCommand = false
+	m.CommandInput.Blur()
+	m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+}
+
+func (m *artifactListView) highlighted() (github.ArtifactInfo, bool) {
+	if m.listErr != nil || len(m.EltList.GetVisibleRows()) == 0 {
+		return github.ArtifactInfo{}, false
+	}
+	artifact, ok := m.EltList.HighlightedRow().Data["artifact"].(github.ArtifactInfo)
+	return artifact, ok
+}
+
+// rebuild refreshes the header and the table after the list or the
+// status changed
+func (m *artifactListView) rebuild() {
+	var total int64
+	for _, artifact := range m.artifacts {
+		total += artifact.Size
+	}
+	m.TopFields = []string{m.owner, m.repoName, fmt.Sprintf("Artifacts of run #%d (%d, %s)", m.runNumber, len(m.artifacts), formatSize(total))}
+	if m.listErr != nil {
+		m.TopFields[2] = fmt.Sprintf("Artifacts of run #%d", m.runNumber)
+	}
+	if m.status != "" {
+		m.TopFields = append(m.TopFields, m.status)
+	}
+
+	m.EltList = m.buildArtifactTable(m.EltList.GetHighlightedRowIndex())
+	if constants.WindowSize.Height != 0 {
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	}
+}
+
+func (m *artifactListView) View() string {
+	if m.loading {
+		return m.RenderTopFields() + "\n\nLoading artifacts..."
+	}
+
+	var main string
+	switch {
+	case m.listErr != nil:
+		main = constants.ErrorStyle.Render(fmt.Sprintf("Could not load the artifacts: %v", m.listErr))
+	case len(m.EltList.GetVisibleRows()) == 0:
+		main = "This run uploaded no artifacts."
+	default:
+		for i, row := range m.EltList.GetVisibleRows() {
+			row.Data["arrow"] = ""
+			if i == m.EltList.GetHighlightedRowIndex() {
+				row.Data["arrow"] = theme.Icons.Arrow
+			}
+		}
+		main = m.EltList.View()
+	}
+
+	var command string
+	switch {
+	case m.visibleCommand:
+		command = m.CommandInput.View()
+	case m.downloading != nil:
+		command = fmt.Sprintf("Downloading %s  %s", m.downloading.Name, m.progress.ViewAs(m.received))
+	}
+	if command != "" {
+		return fmt.Sprintf(
+			"%s\n%s\n%s\n%s",
+			m.RenderTopFields(),
+			constants.CommandStyle.BorderForeground(theme.Current.Accent).Render(command),
+			constants.MainStyle.Render(main),
+			m.RenderBottomFields(),
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s",
+		m.RenderTopFields(),
+		constants.MainStyle.Render(main),
+		m.RenderBottomFields(),
+	)
+}
+
+func (m *artifactListView) buildArtifactTable(highlighted int) table.Model {
+	columns := []table.Column{
+		table.NewColumn("arrow", " ", 3),
+		table.NewColumn("name", "Artifact", 44),
+		table.NewColumn("size", "Size", 11),
+		table.NewColumn("created", "Created", 17),
+		table.NewColumn("expires", "Expires", 17),
+	}
+
+	rows := []table.Row{}
+	for _, artifact := range m.artifacts {
+		expires := artifact.ExpiresAt.Format("2006-01-02 15:04")
+		if artifact.Expired {
+			expires = "expired"
+		}
+		rows = append(rows, table.NewRow(table.RowData{
+			"arrow":    "",
+			"name":     artifact.Name,
+			"size":     formatSize(artifact.Size),
+			"created":  artifact.CreatedAt.Format("2006-01-02 15:04"),
+			"expires":  expires,
+			"artifact": artifact,
+		}))
+	}
+
+	return table.New(columns).WithRows(rows).
+		WithKeyMap(m.keys.tableKeyMap()).
+		Focused(true).
+		Border(table.Border{}).
+		WithBaseStyle(constants.BaseTableStyle).
+		HighlightStyle(constants.HighlightedLineStyle).
+		WithFooterVisibility(false).
+		WithHighlightedRow(min(highlighted, max(len(rows)-1, 0)))
+}
+
+func (m *artifactListView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Select, m.keys.Download, m.keys.Delete, m.keys.Refresh, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
+
+func (m *artifactListView) capturingInput() bool {
+	return m.visibleCommand
+}
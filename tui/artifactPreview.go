@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+type artifactPreviewView struct {
+	commonElements
+
+	// Service
+	sess *session
+
+	// Context
+	owner    string
+	repoName string
+	artifact github.ArtifactInfo
+
+	// State
+	files   []github.ArtifactFile
+	loading bool
+	err     error
+
+	// UI
+	viewport viewport.Model
+	keys     KeyMap
+
+	// Navigation
+	parentView tea.Model
+}
+
+func (m *artifactPreviewView) resizeMain(w int, h int) {
+	headerHeight := lipgloss.Height(m.RenderTopFields())
+	footerHeight := lipgloss.Height(m.RenderBottomFields())
+	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2)
+	m.viewport.Width = w - 2
+	m.viewport.Height = max(h-headerHeight-footerHeight-2, 1)
+}
+
+// NewArtifactPreview creates a view showing the text files of a small
+// artifact, such as test reports. Back returns to parentView.
+func NewArtifactPreview(sess *session, owner, repoName string, artifact github.ArtifactInfo, parentView tea.Model) (tea.Model, tea.Cmd) {
+	m := &artifactPreviewView{
+		sess:       sess,
+		owner:      owner,
+		repoName:   repoName,
+		artifact:   artifact,
+		loading:    true,
+		parentView: parentView,
+		keys:       keyMapFor(viewArtifactPreview),
+	}
+
+	m.InitTop(owner, repoName, "Artifact "+artifact.Name)
+	m.TopFields = []string{owner, repoName, "Artifact " + artifact.Name}
+	m.InitBottom()
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Back, m.keys.Help)
+
+	m.viewport = viewport.New(0, 0)
+	m.viewport.KeyMap = viewport.KeyMap{Up: m.keys.Up, Down: m.keys.Down, PageUp: m.keys.PageUp, PageDown: m.keys.PageDown}
+	if constants.WindowSize.Height != 0 {
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	}
+
+	return m, previewArtifactCmd(sess, owner, repoName, artifact.ID)
+}
+
+func (m *artifactPreviewView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *artifactPreviewView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case artifactPreviewLoadedMsg:
+		m.loading = false
+		m.files, m.err = msg.Files, msg.Err
+		m.viewport.SetContent(m.content())
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		constants.WindowSize = msg
+		m.resizeMain(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case isQuit(msg, m.keys):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return m.parentView, m.parentView.Init()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// content renders every file of the artifact under a header, binary
+// files by their name only
+func (m *artifactPreviewView) content() string {
+	emphasis := lipgloss.NewStyle().Bold(true).Foreground(theme.Current.Emphasis)
+	muted := lipgloss.NewStyle().Foreground(theme.Current.Muted)
+
+	var lines []string
+	for _, file := range m.files {
+		lines = append(lines, emphasis.Render(file.Name))
+		if file.Binary {
+			lines = append(lines, muted.Render("Binary file, download it to open it"), "")
+			continue
+		}
+		lines = append(lines, strings.TrimRight(file.Content, "\n"), "")
+	}
+	if len(m.files) == 0 {
+		lines = append(lines, muted.Render("The artifact is empty."))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *artifactPreviewView) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit or 'backspace' to go back", m.err)
+	}
+
+	if m.loading {
+		return m.RenderTopFields() + "\n\nLoading artifact..."
+	}
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s",
+		m.RenderTopFields(),
+		constants.MainStyle.Render(m.viewport.View()),
+		m.RenderBottomFields(),
+	)
+}
+
+func (m *artifactPreviewView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
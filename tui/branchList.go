@@ -0,0 +1,432 @@
+package tui
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+// Steps of the inline form creating a branch
+const (
+	createNone = iota
+	createName
+	createFrom
+)
+
+type branchListView struct {
+	commonElements
+
+	// Service
+	sess *session
+
+	// Context
+	owner      string
+	repoName   string
+	mainBranch string
+
+	// State
+	branches  []github.BranchInfo
+	more      bool // only the first github.MaxBranches branches are listed
+	statuses  map[string]*github.BranchStatus
+	errs      map[string]error
+	requested map[string]bool // branches whose status is loading or loaded
+	loading   bool
+	err       error
+	status    string // result of the last action
+
+	// Actions
+	compareBase   string // branch marked as base by a first compare
+	confirmDelete string // branch waiting for the deletion to be confirmed
+	createStep    int
+	newBranch     string
+
+	// UI
+	EltList        table.Model
+	visibleCommand bool
+	keys           KeyMap
+}
+
+func (m *branchListView) resizeMain(w int, h int) {
+	headerHeight := lipgloss.Height(m.RenderTopFields())
+	footerHeight := lipgloss.Height(m.RenderBottomFields())
+	cmdHeight := 0
+	if m.visibleCommand {
+		cmdHeight = 3
+	}
+	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2 - cmdHeight)
+	m.EltList = m.EltList.WithPageSize(h - headerHeight - footerHeight - 3 - cmdHeight)
+	constants.CommandStyle = constants.CommandStyle.Width(w - 2).Height(1)
+}
+
+// NewBranchList creates a view listing the branches of a repository,
+// compared to its default branch
+func NewBranchList(sess *session, owner, repoName, mainBranch string) (tea.Model, tea.Cmd) {
+	m := &branchListView{
+		sess:       sess,
+		owner:      owner,
+		repoName:   repoName,
+		mainBranch: mainBranch,
+		statuses:   map[string]*github.BranchStatus{},
+		errs:       map[string]error{},
+		requested:  map[string]bool{},
+		loading:    true,
+		keys:       keyMapFor(viewBranchList),
+	}
+	m.keys.Select = withDesc(m.keys.Select, "History")
+	m.keys.Create = withDesc(m.keys.Create, "New branch")
+
+	m.InitTop(owner, repoName, "Branches")
+	m.TopFields = []string{owner, repoName, "Branches"}
+	m.InitBottom()
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Select, m.keys.Create, m.keys.Delete, m.keys.Compare, m.keys.Refresh, m.keys.Back, m.keys.Help)
+	m.CommandInput = textinput.New()
+
+	return m, loadBranchesCmd(sess, owner, repoName)
+}
+
+func (m *branchListView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *branchListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case branchesLoadedMsg:
+		m.loading = false
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		m.branches = msg.Branches
+		m.more = msg.More
+		// The default branch first, the others keep the API order
+		if i := slices.IndexFunc(m.branches, func(b github.BranchInfo) bool { return b.Name == m.mainBranch }); i > 0 {
+			main := m.branches[i]
+			m.branches = slices.Insert(slices.Delete(m.branches, i, i+1), 0, main)
+		}
+		m.rebuild()
+		return m, m.loadVisibleStatuses()
+
+	case branchStatusLoadedMsg:
+		if msg.Err != nil {
+			slog.Debug("Loading branch status failed", "branch", msg.Branch, "error", msg.Err)
+			m.errs[msg.Branch] = msg.Err
+		} else {
+			m.statuses[msg.Branch] = msg.Status
+			delete(m.errs, msg.Branch)
+		}
+		m.rebuild()
+		return m, nil
+
+	case branchActionMsg:
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Could not %s %s: %v", msg.Action, msg.Branch, msg.Err)
+			m.rebuild()
+			return m, nil
+		}
+		if msg.Action == "create" {
+			m.status = "Created " + msg.Branch
+		} else {
+			m.status = "Deleted " + msg.Branch
+		}
+		delete(m.requested, msg.Branch)
+		delete(m.statuses, msg.Branch)
+		m.loading = true
+		return m, loadBranchesCmd(m.sess, m.owner, m.repoName)
+
+	case tea.WindowSizeMsg:
+		constants.WindowSize = msg
+		m.resizeMain(msg.Width, msg.Height)
+		return m, m.loadVisibleStatuses()
+
+	case tea.KeyMsg:
+		if m.loading {
+			if isQuit(msg, m.keys) {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		if m.visibleCommand {
+			return m.handleCreateInput(msg)
+		}
+
+		if m.confirmDelete != "" {
+			branch := m.confirmDelete
+			m.confirmDelete = ""
+			if key.Matches(msg, m.keys.Select) {
+				m.status = "Deleting " + branch + "..."
+				m.rebuild()
+				return m, deleteBranchCmd(m.sess, m.owner, m.repoName, branch)
+			}
+			m.status = ""
+			m.rebuild()
+			return m, nil
+		}
+
+		switch {
+		case isQuit(msg, m.keys):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return NewRepoView(m.sess, m.owner, m.repoName)
+		case key.Matches(msg, m.keys.Refresh):
+			m.requested = map[string]bool{}
+			m.status = ""
+			m.loading = true
+			return m, loadBranchesCmd(m.sess, m.owner, m.repoName)
+		case key.Matches(msg, m.keys.Create):
+			m.createStep = createName
+			m.CommandInput.Prompt = "New branch: "
+			m.CommandInput.SetValue("")
+			m.CommandInput.Focus()
+			m.visibleCommand = true
+			m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+			return m, nil
+		}
+
+		branch, ok := m.highlighted()
+		if !ok {
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, m.keys.Select):
+			return NewCommitList(m.sess, m.owner, m.repoName, branch.Name, m)
+		case key.Matches(msg, m.keys.Compare):
+			if m.compareBase == "" || m.compareBase == branch.Name {
+				m.compareBase = branch.Name
+				m.status = fmt.Sprintf("Base: %s, press %s on the branch to compare", branch.Name, m.keys.Compare.Help().Key)
+				m.rebuild()
+				return m, nil
+			}
+			base := m.compareBase
+			m.compareBase = ""
+			m.status = ""
+			m.rebuild()
+			return NewCompare(m.sess, m.owner, m.repoName, base, branch.Name, m)
+		case key.Matches(msg, m.keys.Delete):
+			if branch.Name == m.mainBranch {
+				m.status = "The default branch cannot be deleted"
+			} else {
+				m.confirmDelete = branch.Name
+				m.status = fmt.Sprintf("Delete %s? (%s) Confirm, any other key cancels", branch.Name, m.keys.Select.Keys()[0])
+			}
+			m.rebuild()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.EltList, cmd = m.EltList.Update(msg)
+	return m, tea.Batch(cmd, m.loadVisibleStatuses())
+}
+
+// handleCreateInput reads the name of the new branch, then the ref it
+// starts from, which defaults to the highlighted branch
+func (m *branchListView) handleCreateInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeCreateInput()
+		return m, nil
+	case "enter":
+		value := m.CommandInput.Value()
+		if value == "" {
+			return m, nil
+		}
+		if m.createStep == createName {
+			m.newBranch = value
+			m.createStep = createFrom
+			from := m.mainBranch
+			if branch, ok := m.highlighted(); ok {
+				from = branch.Name
+			}
+			m.CommandInput.Prompt = fmt.Sprintf("Create %s from: ", value)
+			m.CommandInput.SetValue(from)
+			m.CommandInput.CursorEnd()
+			return m, nil
+		}
+		m.closeCreateInput()
+		m.status = fmt.Sprintf("Creating %s from %s...", m.newBranch, value)
+		m.rebuild()
+		return m, createBranchCmd(m.sess, m.owner, m.repoName, m.newBranch, value)
+	}
+
+	var cmd tea.Cmd
+	m.CommandInput, cmd = m.CommandInput.Update(msg)
+	return m, cmd
+}
+
+func (m *branchListView) closeCreateInput() {
+	m.createStep = createNone
+	m.visibleCommand = false
+	m.CommandInput.Blur()
+	m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+}
+
+// loadVisibleStatuses loads the details of the branches on the current
+// page which were not requested yet, as each one costs several calls
+func (m *branchListView) loadVisibleStatuses() tea.Cmd {
+	if m.loading || len(m.branches) == 0 {
+		return nil
+	}
+	rows := m.EltList.GetVisibleRows()
+	start, end := m.EltList.VisibleIndices()
+	var cmds []tea.Cmd
+	for i := start; i <= end && i < len(rows); i++ {
+		branch, ok := rows[i].Data["branch"].(github.BranchInfo)
+		if !ok || m.requested[branch.Name] {
+			continue
+		}
+		m.requested[branch.Name] = true
+		cmds = append(cmds, loadBranchStatusCmd(m.sess, m.owner, m.repoName, branch.Name, m.mainBranch))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *branchListView) highlighted() (github.BranchInfo, bool) {
+	if len(m.EltList.GetVisibleRows()) == 0 {
+		return github.BranchInfo{}, false
+	}
+	branch, ok := m.EltList.HighlightedRow().Data["branch"].(github.BranchInfo)
+	return branch, ok
+}
+
+// rebuild refreshes the header and the table after the branches, their
+// details or the status changed
+func (m *branchListView) rebuild() {
+	m.TopFields = []string{m.owner, m.repoName, fmt.Sprintf("Branches (%d)", len(m.branches))}
+	if m.more {
+		m.TopFields[2] = fmt.Sprintf("Branches (first %d)", len(m.branches))
+	}
+	if m.status != "" {
+		m.TopFields = append(m.TopFields, m.status)
+	}
+
+	m.EltList = m.buildBranchTable(m.EltList.GetHighlightedRowIndex())
+	if constants.WindowSize.Height != 0 {
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	}
+}
+
+func (m *branchListView) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit or 'backspace' to go back", m.err)
+	}
+
+	if m.loading {
+		return m.RenderTopFields() + "\n\nLoading branches..."
+	}
+
+	for i, row := range m.EltList.GetVisibleRows() {
+		row.Data["arrow"] = ""
+		if i == m.EltList.GetHighlightedRowIndex() {
+			row.Data["arrow"] = theme.Icons.Arrow
+		}
+	}
+
+	if m.visibleCommand {
+		return fmt.Sprintf(
+			"%s\n%s\n%s\n%s",
+			m.RenderTopFields(),
+			constants.CommandStyle.BorderForeground(theme.Current.Accent).Render(m.CommandInput.View()),
+			constants.MainStyle.Render(m.EltList.View()),
+			m.RenderBottomFields(),
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s",
+		m.RenderTopFields(),
+		constants.MainStyle.Render(m.EltList.View()),
+		m.RenderBottomFields(),
+	)
+}
+
+func (m *branchListView) buildBranchTable(highlighted int) table.Model {
+	columns := []table.Column{
+		table.NewColumn("arrow", " ", 3),
+		table.NewColumn("protected", " ", 3),
+		table.NewColumn("name", "Branch", 22),
+		table.NewColumn("indicator", " ", 3),
+		table.NewColumn("commit", "Last Commit", 26),
+		table.NewColumn("author", "Author", 12),
+		table.NewColumn("date", "Date", 11),
+		table.NewColumn("diff", "Ahead/Behind", 13),
+		table.NewColumn("protection", "Protection", 24),
+	}
+
+	rows := []table.Row{}
+	for _, branch := range m.branches {
+		rows = append(rows, m.makeBranchRow(branch))
+	}
+
+	return table.New(columns).WithRows(rows).
+		WithKeyMap(m.keys.tableKeyMap()).
+		Focused(true).
+		Border(table.Border{}).
+		WithBaseStyle(constants.BaseTableStyle).
+		HighlightStyle(constants.HighlightedLineStyle).
+		WithFooterVisibility(false).
+		WithHighlightedRow(min(highlighted, max(len(rows)-1, 0)))
+}
+
+func (m *branchListView) makeBranchRow(branch github.BranchInfo) table.Row {
+	protected := ""
+	if branch.Protected {
+		protected = theme.Icons.Protected
+	}
+	name := branch.Name
+	if name == m.compareBase {
+		name += " (base)"
+	}
+
+	data := table.RowData{
+		"arrow":      "",
+		"protected":  protected,
+		"name":       name,
+		"indicator":  "",
+		"commit":     "Loading...",
+		"author":     "",
+		"date":       "",
+		"diff":       "",
+		"protection": "",
+		"branch":     branch,
+	}
+
+	if err, ok := m.errs[branch.Name]; ok {
+		data["commit"] = table.NewStyledCell(err.Error(), constants.ErrorStyle)
+	} else if status, ok := m.statuses[branch.Name]; ok {
+		if status.CheckStatus != "" {
+			indicator, color := theme.RunStatus(status.CheckStatus, status.CheckConclusion)
+			data["indicator"] = table.NewStyledCell(indicator, lipgloss.NewStyle().Foreground(color))
+		}
+		data["commit"] = status.Message
+		data["author"] = status.Author
+		data["date"] = status.Date.Format("2006-01-02")
+		data["diff"] = fmt.Sprintf("+%d -%d", status.AheadBy, status.BehindBy)
+		if branch.Name == m.mainBranch {
+			data["diff"] = "default"
+		}
+		data["protection"] = status.Protection
+	} else if !m.requested[branch.Name] {
+		data["commit"] = ""
+	}
+
+	return table.NewRow(data)
+}
+
+func (m *branchListView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Select, m.keys.Create, m.keys.Delete, m.keys.Compare, m.keys.Refresh, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
+
+func (m *branchListView) capturingInput() bool {
+	return m.visibleCommand
+}
@@ -0,0 +1,416 @@
+package tui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+// cacheQuota is the storage GitHub gives to the caches of a repository
+// before evicting the least recently used ones
+const cacheQuota = 10 << 30
+
+// cacheSorts are the orders the sort key cycles through
+var cacheSorts = []struct {
+	name    string
+	compare func(a, b github.CacheInfo) int
+}{
+	{"last used", func(a, b github.CacheInfo) int { return b.LastAccessedAt.Compare(a.LastAccessedAt) }},
+	{"size", func(a, b github.CacheInfo) int { return cmp.Compare(b.Size, a.Size) }},
+	{"created", func(a, b github.CacheInfo) int { return b.CreatedAt.Compare(a.CreatedAt) }},
+	{"key", func(a, b github.CacheInfo) int { return strings.Compare(a.Key, b.Key) }},
+}
+
+type cacheListView struct {
+	commonElements
+
+	// Service
+	sess *session
+
+	// Context
+	owner    string
+	repoName string
+
+	// State
+	caches  []github.CacheInfo
+	usage   *github.CacheUsage
+	sortBy  int    // index in cacheSorts
+	filter  string // key prefix or ref
+	marked  map[int64]bool
+	loading bool
+	err     error
+	status  string // result of the last action
+
+	// Actions
+	confirmDelete []github.CacheInfo // caches waiting for the deletion to be confirmed
+
+	// UI
+	EltList        table.Model
+	visibleCommand bool
+	keys           KeyMap
+}
+
+func (m *cacheListView) resizeMain(w int, h int) {
+	headerHeight := lipgloss.Height(m.RenderTopFields())
+	footerHeight := lipgloss.Height(m.RenderBottomFields())
+	cmdHeight := 0
+	if m.visibleCommand {
+		cmdHeight = 3
+	}
+	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2 - cmdHeight)
+	m.EltList = m.EltList.WithPageSize(h - headerHeight - footerHeight - 3 - cmdHeight)
+	constants.CommandStyle = constants.CommandStyle.Width(w - 2).Height(1)
+}
+
+// NewCacheList creates a view listing the Actions caches of a repository
+// and their storage usage, to delete them selectively or per ref
+func NewCacheList(sess *session, owner, repoName string) (tea.Model, tea.Cmd) {
+	m := &cacheListView{
+		sess:     sess,
+		owner:    owner,
+		repoName: repoName,
+		marked:   map[int64]bool{},
+		loading:  true,
+		keys:     keyMapFor(viewCacheList),
+	}
+	m.keys.Filter = withDesc(m.keys.Filter, "Filter key/ref")
+	m.keys.Delete = withDesc(m.keys.Delete, "Delete selected")
+
+	m.InitTop(owner, repoName, "Caches")
+	m.TopFields = []string{owner, repoName, "Caches"}
+	m.InitBottom()
+	m.updateFooter()
+	m.CommandInput = textinput.New()
+	m.CommandInput.Prompt = "Key prefix or ref: "
+
+	return m, loadCachesCmd(sess, owner, repoName)
+}
+
+func (m *cacheListView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *cacheListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case cachesLoadedMsg:
+		m.loading = false
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		m.caches, m.usage = msg.Caches, msg.Usage
+		for id := range m.marked {
+			if !slices.ContainsFunc(m.caches, func(c github.CacheInfo) bool { return c.ID == id }) {
+				delete(m.marked, id)
+			}
+		}
+		m.rebuild()
+		return m, nil
+
+	case cachesDeletedMsg:
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Deleted %d cache(s), then failed on %v", msg.Deleted, msg.Err)
+		} else {
+			m.status = fmt.Sprintf("Deleted %d cache(s)", msg.Deleted)
+		}
+		clear(m.marked)
+		m.loading = true
+		return m, loadCachesCmd(m.sess, m.owner, m.repoName)
+
+	case tea.WindowSizeMsg:
+		constants.WindowSize = msg
+		m.resizeMain(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading {
+			if isQuit(msg, m.keys) {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		if m.visibleCommand {
+			return m.handleFilterInput(msg)
+		}
+
+		if m.confirmDelete != nil {
+			caches := m.confirmDelete
+			m.confirmDelete = nil
+			if key.Matches(msg, m.keys.Select) {
+				m.status = fmt.Sprintf("Deleting %d cache(s)...", len(caches))
+				m.rebuild()
+				return m, deleteCachesCmd(m.sess, m.owner, m.repoName, caches)
+			}
+			m.status = ""
+			m.rebuild()
+			return m, nil
+		}
+
+		switch {
+		case isQuit(msg, m.keys):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return NewRepoView(m.sess, m.owner, m.repoName)
+		case key.Matches(msg, m.keys.Refresh):
+			m.status = ""
+			m.loading = true
+			return m, loadCachesCmd(m.sess, m.owner, m.repoName)
+		case key.Matches(msg, m.keys.Sort):
+			m.sortBy = (m.sortBy + 1) % len(cacheSorts)
+			m.updateFooter()
+			m.rebuild()
+			return m, nil
+		case key.Matches(msg, m.keys.Filter):
+			m.visibleCommand = true
+			m.CommandInput.SetValue(m.filter)
+			m.CommandInput.CursorEnd()
+			m.CommandInput.Focus()
+			m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+			return m, nil
+		}
+
+		cache, ok := m.highlighted()
+		if !ok {
+			break
+		}
+		switch {
+		case key.Matches(msg, m.keys.Mark):
+			if m.marked[cache.ID] {
+				delete(m.marked, cache.ID)
+			} else {
+				m.marked[cache.ID] = true
+			}
+			m.rebuild()
+			m.EltList = m.EltList.WithHighlightedRow(min(m.EltList.GetHighlightedRowIndex()+1, len(m.EltList.GetVisibleRows())-1))
+			return m, nil
+		case key.Matches(msg, m.keys.Delete):
+			caches := []github.CacheInfo{cache}
+			if len(m.marked) > 0 {
+				caches = m.markedCaches()
+			}
+			m.confirm(caches, fmt.Sprintf("%d cache(s)", len(caches)))
+			return m, nil
+		case key.Matches(msg, m.keys.Purge):
+			var caches []github.CacheInfo
+			for _, c := range m.caches {
+				if c.Ref == cache.Ref {
+					caches = append(caches, c)
+				}
+			}
+			m.confirm(caches, fmt.Sprintf("all %d cache(s) of %s", len(caches), shortRef(cache.Ref)))
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.EltList, cmd = m.EltList.Update(msg)
+	return m, cmd
+}
+
+// confirm asks for the deletion of caches to be confirmed
+func (m *cacheListView) confirm(caches []github.CacheInfo, what string) {
+	var size int64
+	for _, c := range caches {
+		size += c.Size
+	}
+	m.confirmDelete = caches
+	m.status = fmt.Sprintf("Delete %s (%s)? (%s) Confirm, any other key cancels", what, formatSize(size), m.keys.Select.Keys()[0])
+	m.rebuild()
+}
+
+func (m *cacheListView) handleFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "esc":
+		m.visibleCommand = false
+		m.CommandInput.Blur()
+		m.filter = strings.TrimSpace(m.CommandInput.Value())
+		if msg.String() == "esc" {
+			m.filter = ""
+		}
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+		m.rebuild()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.CommandInput, cmd = m.CommandInput.Update(msg)
+	return m, cmd
+}
+
+// updateFooter shows the current order in the footer
+func (m *cacheListView) updateFooter() {
+	m.keys.Sort = withDesc(m.keys.Sort, "Sort: "+cacheSorts[m.sortBy].name)
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Mark, m.keys.Delete, m.keys.Purge, m.keys.Sort, m.keys.Filter, m.keys.Back, m.keys.Help)
+}
+
+// visible returns the caches matching the filter, in the current order.
+// The filter matches the start of a key or a whole ref, with or without
+// its refs/heads/ prefix.
+func (m *cacheListView) visible() []github.CacheInfo {
+	var visible []github.CacheInfo
+	filter := strings.ToLower(m.filter)
+	for _, c := range m.caches {
+		if filter != "" && !strings.HasPrefix(strings.ToLower(c.Key), filter) &&
+			filter != strings.ToLower(c.Ref) && filter != strings.ToLower(shortRef(c.Ref)) {
+			continue
+		}
+		visible = append(visible, c)
+	}
+	slices.SortStableFunc(visible, cacheSorts[m.sortBy].compare)
+	return visible
+}
+
+func (m *cacheListView) markedCaches() []github.CacheInfo {
+	var caches []github.CacheInfo
+	for _, c := range m.caches {
+		if m.marked[c.ID] {
+			caches = append(caches, c)
+		}
+	}
+	return caches
+}
+
+func (m *cacheListView) highlighted() (github.CacheInfo, bool) {
+	if len(m.EltList.GetVisibleRows()) == 0 {
+		return github.CacheInfo{}, false
+	}
+	cache, ok := m.EltList.HighlightedRow().Data["cache"].(github.CacheInfo)
+	return cache, ok
+}
+
+// shortRef drops the refs/heads/ prefix of branches, and refs/ of the others
+func shortRef(ref string) string {
+	if branch, ok := strings.CutPrefix(ref, "refs/heads/"); ok {
+		return branch
+	}
+	return strings.TrimPrefix(ref, "refs/")
+}
+
+// rebuild refreshes the header and the table after the list, the order,
+// the filter or the status changed
+func (m *cacheListView) rebuild() {
+	visible := m.visible()
+
+	title := fmt.Sprintf("Caches (%d)", len(m.caches))
+	if m.filter != "" {
+		title = fmt.Sprintf("Caches (%d of %d) filter: %s", len(visible), len(m.caches), m.filter)
+	}
+	m.TopFields = []string{m.owner, m.repoName, title}
+	if m.usage != nil {
+		m.TopFields = append(m.TopFields, fmt.Sprintf("%s of %s used (%d%%)",
+			formatSize(m.usage.Size), formatSize(cacheQuota), m.usage.Size*100/cacheQuota))
+	}
+	if len(m.marked) > 0 {
+		m.TopFields = append(m.TopFields, fmt.Sprintf("%d selected", len(m.marked)))
+	}
+	if m.status != "" {
+		m.TopFields = append(m.TopFields, m.status)
+	}
+
+	m.EltList = m.buildCacheTable(visible, m.EltList.GetHighlightedRowIndex())
+	if constants.WindowSize.Height != 0 {
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	}
+}
+
+func (m *cacheListView) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit or 'backspace' to go back", m.err)
+	}
+
+	if m.loading {
+		return m.RenderTopFields() + "\n\nLoading caches..."
+	}
+
+	var main string
+	switch {
+	case len(m.caches) == 0:
+		main = "No caches."
+	case len(m.EltList.GetVisibleRows()) == 0:
+		main = fmt.Sprintf("No cache key starting with %s, nor ref named so.", m.filter)
+	default:
+		for i, row := range m.EltList.GetVisibleRows() {
+			row.Data["arrow"] = ""
+			if i == m.EltList.GetHighlightedRowIndex() {
+				row.Data["arrow"] = theme.Icons.Arrow
+			}
+		}
+		main = m.EltList.View()
+	}
+
+	if m.visibleCommand {
+		return fmt.Sprintf(
+			"%s\n%s\n%s\n%s",
+			m.RenderTopFields(),
+			constants.CommandStyle.BorderForeground(theme.Current.Accent).Render(m.CommandInput.View()),
+			constants.MainStyle.Render(main),
+			m.RenderBottomFields(),
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s",
+		m.RenderTopFields(),
+		constants.MainStyle.Render(main),
+		m.RenderBottomFields(),
+	)
+}
+
+func (m *cacheListView) buildCacheTable(caches []github.CacheInfo, highlighted int) table.Model {
+	columns := []table.Column{
+		table.NewColumn("arrow", " ", 3),
+		table.NewColumn("mark", " ", 3),
+		table.NewColumn("key", "Key", 50),
+		table.NewColumn("ref", "Ref", 22),
+		table.NewColumn("size", "Size", 10),
+		table.NewColumn("accessed", "Last used", 17),
+		table.NewColumn("created", "Created", 11),
+	}
+
+	rows := []table.Row{}
+	for _, cache := range caches {
+		mark := ""
+		if m.marked[cache.ID] {
+			mark = "[x]"
+		}
+		rows = append(rows, table.NewRow(table.RowData{
+			"arrow":    "",
+			"mark":     mark,
+			"key":      cache.Key,
+			"ref":      shortRef(cache.Ref),
+			"size":     formatSize(cache.Size),
+			"accessed": cache.LastAccessedAt.Format("2006-01-02 15:04"),
+			"created":  cache.CreatedAt.Format("2006-01-02"),
+			"cache":    cache,
+		}))
+	}
+
+	return table.New(columns).WithRows(rows).
+		WithKeyMap(m.keys.tableKeyMap()).
+		Focused(true).
+		Border(table.Border{}).
+		WithBaseStyle(constants.BaseTableStyle).
+		HighlightStyle(constants.HighlightedLineStyle).
+		WithFooterVisibility(false).
+		WithHighlightedRow(min(highlighted, max(len(rows)-1, 0)))
+}
+
+func (m *cacheListView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Mark, m.keys.Delete, m.keys.Purge, m.keys.Sort, m.keys.Filter, m.keys.Refresh, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
+
+func (m *cacheListView) capturingInput() bool {
+	return m.visibleCommand
+}
@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"math"
+	"strings"
+)
+
+// sparkLevels are the block heights of a sparkline, lowest first
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// barEighths are the partial blocks ending a bar, from one to seven eighths
+var barEighths = []rune("▏▎▍▌▋▊▉")
+
+// sparkline draws values as a line of blocks scaled from zero to the
+// largest value
+func sparkline(values []float64) string {
+	top := 0.0
+	for _, v := range values {
+		top = max(top, v)
+	}
+	var line strings.Builder
+	for _, v := range values {
+		level := 0
+		if top > 0 {
+			level = int(math.Round(max(v, 0) / top * float64(len(sparkLevels)-1)))
+		}
+		line.WriteRune(sparkLevels[level])
+	}
+	return line.String()
+}
+
+// bar draws value as a horizontal bar of eighths of a cell, width cells
+// long for top
+func bar(value, top float64, width int) string {
+	if top <= 0 || value <= 0 {
+		return ""
+	}
+	eighths := int(math.Round(min(value/top, 1) * float64(width*8)))
+	b := strings.Repeat("█", eighths/8)
+	if rest := eighths % 8; rest > 0 {
+		b += string(barEighths[rest-1])
+	}
+	return b
+}
@@ -0,0 +1,843 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jjournet/tgr/analytics"
+	"github.com/jjournet/tgr/github"
+)
+
+// Thin adapters turning github.API calls into Bubble Tea commands.
+// Each command runs one API call and wraps its result in a message.
+
+// requestTimeout bounds every API call issued from the TUI
+const requestTimeout = 30 * time.Second
+
+// transferTimeout bounds the calls moving files, such as release assets
+const transferTimeout = 10 * time.Minute
+
+// tick schedules the refreshes and the retries of the views, a variable so
+// tests can stop time
+var tick = tea.Tick
+
+func apiContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), requestTimeout)
+}
+
+func transferContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), transferTimeout)
+}
+
+// loadUserCmd returns a command that loads the current user's information
+func loadUserCmd(api github.API) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		user, err := api.GetUser(ctx)
+		if err != nil {
+			return userLoadedMsg{Err: err}
+		}
+		return userLoadedMsg{Login: user.Login, Name: user.Name}
+	}
+}
+
+// loadOrgsCmd returns a command that loads the user's organizations
+func loadOrgsCmd(api github.API) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		orgs, err := api.ListOrgs(ctx)
+		return orgsLoadedMsg{Orgs: orgs, Err: err}
+	}
+}
+
+// loadReposCmd returns a command that loads repositories for an owner
+func loadReposCmd(api github.API, owner string, isUser bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		repos, err := api.ListRepos(ctx, owner, isUser)
+		return reposLoadedMsg{Owner: owner, Repos: repos, Err: err}
+	}
+}
+
+// loadRepoStatusCmd returns a command that loads the dashboard badges of a repository
+func loadRepoStatusCmd(api github.API, owner, repoName string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		status, err := api.GetRepoStatus(ctx, owner, repoName)
+		return repoStatusLoadedMsg{Repo: owner + "/" + repoName, Status: status, Err: err}
+	}
+}
+
+// loadNotificationsCmd returns a command that loads the inbox notifications
+func loadNotificationsCmd(api github.API, all bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		notifications, err := api.ListNotifications(ctx, all)
+		return notificationsLoadedMsg{Notifications: notifications, Err: err}
+	}
+}
+
+// notificationActionCmd returns a command that marks a notification
+// thread read or done, or unsubscribes from it
+func notificationActionCmd(api github.API, threadID, action string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		var err error
+		switch action {
+		case "read":
+			err = api.MarkNotificationRead(ctx, threadID)
+		case "done":
+			err = api.MarkNotificationDone(ctx, threadID)
+		case "unsubscribe":
+			err = api.Unsubscribe(ctx, threadID)
+		}
+		return notificationActionMsg{ID: threadID, Action: action, Err: err}
+	}
+}
+
+// loadIssueCmd returns a command that loads a single issue or pull request
+func loadIssueCmd(api github.API, owner, repoName string, number int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		issue, err := api.GetIssue(ctx, owner, repoName, number)
+		return issueLoadedMsg{Owner: owner, RepoName: repoName, Issue: issue, Err: err}
+	}
+}
+
+// findNotificationRunCmd returns a command that looks up the latest run of
+// a workflow on a branch
+func findNotificationRunCmd(api github.API, owner, repoName, workflow, branch string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		runs, err := api.ListRepoRuns(ctx, owner, repoName, github.RunFilter{Branch: branch})
+		if err != nil {
+			return notificationRunFoundMsg{Owner: owner, RepoName: repoName, Err: err}
+		}
+		for _, run := range runs {
+			if run.Title == workflow && run.Branch == branch {
+				return notificationRunFoundMsg{Owner: owner, RepoName: repoName, Run: &run}
+			}
+		}
+		return notificationRunFoundMsg{Owner: owner, RepoName: repoName}
+	}
+}
+
+// loadBranchesCmd returns a command that loads the branches of a repository
+func loadBranchesCmd(api github.API, owner, repoName string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		branches, more, err := api.ListBranches(ctx, owner, repoName)
+		return branchesLoadedMsg{Branches: branches, More: more, Err: err}
+	}
+}
+
+// loadBranchStatusCmd returns a command that loads the details of a branch
+// compared to base
+func loadBranchStatusCmd(api github.API, owner, repoName, branch, base string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		status, err := api.GetBranchStatus(ctx, owner, repoName, branch, base)
+		return branchStatusLoadedMsg{Branch: branch, Status: status, Err: err}
+	}
+}
+
+// createBranchCmd returns a command that creates a branch from a ref
+func createBranchCmd(api github.API, owner, repoName, name, fromRef string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		err := api.CreateBranch(ctx, owner, repoName, name, fromRef)
+		return branchActionMsg{Action: "create", Branch: name, Err: err}
+	}
+}
+
+// deleteBranchCmd returns a command that deletes a branch
+func deleteBranchCmd(api github.API, owner, repoName, name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		err := api.DeleteBranch(ctx, owner, repoName, name)
+		return branchActionMsg{Action: "delete", Branch: name, Err: err}
+	}
+}
+
+// compareRefsCmd returns a command that compares head to base
+func compareRefsCmd(api github.API, owner, repoName, base, head string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		comparison, err := api.CompareRefs(ctx, owner, repoName, base, head)
+		return comparisonLoadedMsg{Comparison: comparison, Err: err}
+	}
+}
+
+// loadCommitsCmd returns a command that loads the latest commits of a branch
+func loadCommitsCmd(api github.API, owner, repoName, branch string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		commits, err := api.ListCommits(ctx, owner, repoName, branch)
+		return commitsLoadedMsg{Commits: commits, Err: err}
+	}
+}
+
+// loadCommitChecksCmd returns a command that loads the combined checks of a commit
+func loadCommitChecksCmd(api github.API, owner, repoName, sha string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		status, conclusion, err := api.GetCommitChecks(ctx, owner, repoName, sha)
+		return commitChecksLoadedMsg{SHA: sha, Status: status, Conclusion: conclusion, Err: err}
+	}
+}
+
+// loadCommitCmd returns a command that loads the details of a commit
+func loadCommitCmd(api github.API, owner, repoName, sha string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		commit, err := api.GetCommit(ctx, owner, repoName, sha)
+		return commitLoadedMsg{Commit: commit, Err: err}
+	}
+}
+
+// loadCommitRunsCmd returns a command that loads the runs triggered for a commit
+func loadCommitRunsCmd(api github.API, owner, repoName, sha string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		runs, err := api.ListCommitRuns(ctx, owner, repoName, sha)
+		return commitRunsLoadedMsg{Runs: runs, Err: err}
+	}
+}
+
+// loadEnvironmentsCmd returns a command that loads the environments of a repository
+func loadEnvironmentsCmd(api github.API, owner, repoName string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		envs, err := api.ListEnvironments(ctx, owner, repoName)
+		return environmentsLoadedMsg{Environments: envs, Err: err}
+	}
+}
+
+// loadLatestDeploymentCmd returns a command that loads the latest
+// deployment to an environment
+func loadLatestDeploymentCmd(api github.API, owner, repoName, environment string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		deployment, err := api.LatestDeployment(ctx, owner, repoName, environment)
+		return latestDeploymentLoadedMsg{Environment: environment, Deployment: deployment, Err: err}
+	}
+}
+
+// loadPendingDeploymentsCmd returns a command that loads the deployments a run waits for
+func loadPendingDeploymentsCmd(api github.API, owner, repoName string, runID int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		deployments, err := api.GetPendingDeployments(ctx, owner, repoName, runID)
+		return pendingDeploymentsLoadedMsg{Deployments: deployments, Err: err}
+	}
+}
+
+// reviewDeploymentsCmd returns a command that approves or rejects the
+// pending deployments of a run
+func reviewDeploymentsCmd(api github.API, owner, repoName string, runID int64, deployments []github.PendingDeploymentInfo, approve bool, comment string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		var ids []int64
+		var names []string
+		for _, d := range deployments {
+			ids = append(ids, d.EnvironmentID)
+			names = append(names, d.Environment)
+		}
+		err := api.ReviewPendingDeployments(ctx, owner, repoName, runID, ids, approve, comment)
+		return deploymentsReviewedMsg{Approve: approve, Environments: names, Err: err}
+	}
+}
+
+// loadVariablesCmd returns a command that loads the variables of a scope
+func loadVariablesCmd(api github.API, scope github.VariableScope) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		vars, err := api.ListVariables(ctx, scope)
+		return variablesLoadedMsg{Scope: scope, Variables: vars, Err: err}
+	}
+}
+
+// loadSecretsCmd returns a command that loads the secrets of a scope
+func loadSecretsCmd(api github.API, scope github.VariableScope) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		secrets, err := api.ListSecrets(ctx, scope)
+		return secretsLoadedMsg{Scope: scope, Secrets: secrets, Err: err}
+	}
+}
+
+// setVariableCmd returns a command that creates or updates a variable
+func setVariableCmd(api github.API, scope github.VariableScope, name, value string, create bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		err := api.SetVariable(ctx, scope, name, value, create)
+		return variableActionMsg{Action: "set", Kind: "variable", Name: name, Err: err}
+	}
+}
+
+// deleteVariableCmd returns a command that deletes a variable
+func deleteVariableCmd(api github.API, scope github.VariableScope, name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		err := api.DeleteVariable(ctx, scope, name)
+		return variableActionMsg{Action: "delete", Kind: "variable", Name: name, Err: err}
+	}
+}
+
+// setSecretCmd returns a command that creates or updates a secret
+func setSecretCmd(api github.API, scope github.VariableScope, name, value string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		err := api.SetSecret(ctx, scope, name, value)
+		return variableActionMsg{Action: "set", Kind: "secret", Name: name, Err: err}
+	}
+}
+
+// deleteSecretCmd returns a command that deletes a secret
+func deleteSecretCmd(api github.API, scope github.VariableScope, name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		err := api.DeleteSecret(ctx, scope, name)
+		return variableActionMsg{Action: "delete", Kind: "secret", Name: name, Err: err}
+	}
+}
+
+// loadProjectsCmd returns a command that loads the projects of an owner,
+// or of one of its repositories when repoName is set
+func loadProjectsCmd(api github.API, owner, repoName string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		projects, err := api.ListProjects(ctx, owner, repoName)
+		return projectsLoadedMsg{Projects: projects, Err: err}
+	}
+}
+
+// loadProjectCmd returns a command that loads the fields and items of a project
+func loadProjectCmd(api github.API, projectID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		project, err := api.GetProject(ctx, projectID)
+		return projectLoadedMsg{Project: project, Err: err}
+	}
+}
+
+// setProjectFieldCmd returns a command that sets a field of a project item
+func setProjectFieldCmd(api github.API, projectID, itemID string, field github.ProjectField, value string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		err := api.SetProjectField(ctx, projectID, itemID, field, value)
+		return projectFieldSetMsg{ItemID: itemID, Field: field.Name, Value: value, Err: err}
+	}
+}
+
+// addProjectItemCmd returns a command that adds an issue or pull request to a project
+func addProjectItemCmd(api github.API, projectID, owner, repoName string, number int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		err := api.AddProjectItem(ctx, projectID, owner, repoName, number)
+		return projectItemAddedMsg{Ref: fmt.Sprintf("%s/%s#%d", owner, repoName, number), Err: err}
+	}
+}
+
+// loadReleasesCmd returns a command that loads the releases of a repository
+func loadReleasesCmd(api github.API, owner, repoName string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		releases, err := api.ListReleases(ctx, owner, repoName)
+		return releasesLoadedMsg{Releases: releases, Err: err}
+	}
+}
+
+// loadTagsCmd returns a command that loads the tags of a repository
+func loadTagsCmd(api github.API, owner, repoName string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		tags, err := api.ListTags(ctx, owner, repoName)
+		return tagsLoadedMsg{Tags: tags, Err: err}
+	}
+}
+
+// createReleaseCmd returns a command that creates a release then uploads
+// the given local files as its assets
+func createReleaseCmd(api github.API, owner, repoName string, req github.ReleaseRequest, assets []string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := transferContext()
+		defer cancel()
+		release, err := api.CreateRelease(ctx, owner, repoName, req)
+		if err != nil {
+			return releaseActionMsg{Action: "create", Tag: req.Tag, Err: err}
+		}
+		for _, path := range assets {
+			if _, err := api.UploadReleaseAsset(ctx, owner, repoName, release.ID, path); err != nil {
+				return releaseActionMsg{Action: "upload", Tag: req.Tag, Err: fmt.Errorf("%s: %w", path, err)}
+			}
+		}
+		return releaseActionMsg{Action: "create", Tag: req.Tag}
+	}
+}
+
+// uploadReleaseAssetCmd returns a command that attaches a local file to a release
+func uploadReleaseAssetCmd(api github.API, owner, repoName string, release github.ReleaseInfo, path string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := transferContext()
+		defer cancel()
+		_, err := api.UploadReleaseAsset(ctx, owner, repoName, release.ID, path)
+		return releaseActionMsg{Action: "upload", Tag: release.Tag, Err: err}
+	}
+}
+
+// publishReleaseCmd returns a command that publishes a draft release
+func publishReleaseCmd(api github.API, owner, repoName string, release github.ReleaseInfo) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		err := api.PublishRelease(ctx, owner, repoName, release.ID)
+		return releaseActionMsg{Action: "publish", Tag: release.Tag, Err: err}
+	}
+}
+
+// loadArtifactsCmd returns a command that loads the artifacts of a run
+func loadArtifactsCmd(api github.API, owner, repoName string, runID int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		artifacts, err := api.ListRunArtifacts(ctx, owner, repoName, runID)
+		return artifactsLoadedMsg{Artifacts: artifacts, Err: err}
+	}
+}
+
+// downloadArtifactCmd returns a command that downloads an artifact and
+// extracts it into dir. Progress is reported on the channel, which is
+// closed once done; updates are dropped while the view is busy.
+func downloadArtifactCmd(api github.API, owner, repoName string, artifact github.ArtifactInfo, dir string, progress chan<- artifactProgressMsg) tea.Cmd {
+	return func() tea.Msg {
+		defer close(progress)
+		ctx, cancel := transferContext()
+		defer cancel()
+		files, err := api.DownloadArtifact(ctx, owner, repoName, artifact.ID, dir, func(done, total int64) {
+			select {
+			case progress <- artifactProgressMsg{Done: done, Total: total}:
+			default:
+			}
+		})
+		return artifactDownloadedMsg{Name: artifact.Name, Dir: dir, Files: files, Err: err}
+	}
+}
+
+// waitForArtifactProgressCmd returns a command that waits for the next
+// progress update of a download
+func waitForArtifactProgressCmd(progress <-chan artifactProgressMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-progress
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// previewArtifactCmd returns a command that reads the files of a small artifact
+func previewArtifactCmd(api github.API, owner, repoName string, artifactID int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := transferContext()
+		defer cancel()
+		files, err := api.PreviewArtifact(ctx, owner, repoName, artifactID, artifactPreviewLimit)
+		return artifactPreviewLoadedMsg{Files: files, Err: err}
+	}
+}
+
+// deleteArtifactCmd returns a command that deletes an artifact
+func deleteArtifactCmd(api github.API, owner, repoName string, artifact github.ArtifactInfo) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		err := api.DeleteArtifact(ctx, owner, repoName, artifact.ID)
+		return artifactDeletedMsg{Name: artifact.Name, Err: err}
+	}
+}
+
+// loadCachesCmd returns a command that loads the Actions caches of a
+// repository and their storage usage
+func loadCachesCmd(api github.API, owner, repoName string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		caches, err := api.ListCaches(ctx, owner, repoName)
+		if err != nil {
+			return cachesLoadedMsg{Err: err}
+		}
+		usage, err := api.GetCacheUsage(ctx, owner, repoName)
+		return cachesLoadedMsg{Caches: caches, Usage: usage, Err: err}
+	}
+}
+
+// deleteCachesCmd returns a command that deletes caches one by one
+func deleteCachesCmd(api github.API, owner, repoName string, caches []github.CacheInfo) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		for i, cache := range caches {
+			if err := api.DeleteCache(ctx, owner, repoName, cache.ID); err != nil {
+				return cachesDeletedMsg{Deleted: i, Err: fmt.Errorf("%s: %w", cache.Key, err)}
+			}
+		}
+		return cachesDeletedMsg{Deleted: len(caches)}
+	}
+}
+
+// loadRunnersCmd returns a command that loads the self-hosted runners of
+// a repository, or of its organization when org is set, and the jobs of
+// the repository running on them
+func loadRunnersCmd(api github.API, owner, repoName string, org bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		scope := repoName
+		if org {
+			scope = ""
+		}
+		runners, err := api.ListRunners(ctx, owner, scope)
+		if err != nil || !slices.ContainsFunc(runners, func(r github.RunnerInfo) bool { return r.Busy }) {
+			return runnersLoadedMsg{Org: org, Runners: runners, Err: err}
+		}
+		jobs, err := api.ListRunnerJobs(ctx, owner, repoName)
+		if err != nil {
+			// The jobs are a hint, the runners are still worth showing
+			slog.Debug("Loading runner jobs failed", "error", err)
+		}
+		return runnersLoadedMsg{Org: org, Runners: runners, Jobs: jobs}
+	}
+}
+
+// loadRunnerGroupsCmd returns a command that loads the runner groups of
+// an organization
+func loadRunnerGroupsCmd(api github.API, org string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		groups, err := api.ListRunnerGroups(ctx, org)
+		return runnerGroupsLoadedMsg{Groups: groups, Err: err}
+	}
+}
+
+// removeRunnerCmd returns a command that removes a self-hosted runner of
+// a repository, or of an organization when repoName is empty
+func removeRunnerCmd(api github.API, owner, repoName string, runner github.RunnerInfo) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		err := api.RemoveRunner(ctx, owner, repoName, runner.ID)
+		return runnerActionMsg{Action: "remove", Runner: runner.Name, Err: err}
+	}
+}
+
+// createRegistrationTokenCmd returns a command that creates a token to
+// register a runner to a repository, or to an organization when repoName
+// is empty
+func createRegistrationTokenCmd(api github.API, owner, repoName string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		token, err := api.CreateRegistrationToken(ctx, owner, repoName)
+		return runnerActionMsg{Action: "token", Token: token, Err: err}
+	}
+}
+
+// loadRepoDetailsCmd returns a command that loads detailed repo information
+func loadRepoDetailsCmd(api github.API, owner, repoName string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		repo, err := api.GetRepoDetails(ctx, owner, repoName)
+		return repoDetailsLoadedMsg{Repo: repo, Err: err}
+	}
+}
+
+// loadWorkflowsCmd returns a command that loads workflows for a repository
+func loadWorkflowsCmd(api github.API, owner, repoName string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		workflows, err := api.ListWorkflows(ctx, owner, repoName)
+		return workflowsLoadedMsg{Workflows: workflows, Err: err}
+	}
+}
+
+// loadWorkflowRunsCmd returns a command that loads the runs of a workflow
+// matching a filter
+func loadWorkflowRunsCmd(api github.API, owner, repoName string, workflowID int64, filter github.RunFilter) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		runs, err := api.ListWorkflowRuns(ctx, owner, repoName, workflowID, filter)
+		return workflowRunsLoadedMsg{WorkflowID: workflowID, Runs: runs, Err: err}
+	}
+}
+
+// loadMonitorRunsCmd returns a command that loads the latest runs of a
+// repository for the run monitor, tagged with the poll it belongs to
+func loadMonitorRunsCmd(api github.API, owner, repoName string, gen int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		runs, err := api.ListRepoRuns(ctx, owner, repoName, github.RunFilter{})
+		return monitorRunsLoadedMsg{Repo: owner + "/" + repoName, Runs: runs, Gen: gen, Err: err}
+	}
+}
+
+// loadAllRepoRunsCmd returns a command that loads the workflow runs of a
+// repo matching a filter
+func loadAllRepoRunsCmd(api github.API, owner, repoName string, filter github.RunFilter) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		runs, err := api.ListRepoRuns(ctx, owner, repoName, filter)
+		return workflowRunsLoadedMsg{Runs: runs, Err: err}
+	}
+}
+
+// loadIssuesCmd returns a command that loads issues for a repository
+func loadIssuesCmd(api github.API, owner, repoName string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		issues, err := api.ListIssues(ctx, owner, repoName)
+		return issuesLoadedMsg{Issues: issues, Err: err}
+	}
+}
+
+// loadRunDetailCmd returns a command that loads detailed information for a workflow run
+func loadRunDetailCmd(api github.API, owner, repoName string, runID int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		run, err := api.GetRun(ctx, owner, repoName, runID)
+		return runDetailLoadedMsg{Run: run, Err: err}
+	}
+}
+
+// loadRunJobsCmd returns a command that loads jobs for a workflow run
+func loadRunJobsCmd(api github.API, owner, repoName string, runID int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		jobs, err := api.ListRunJobs(ctx, owner, repoName, runID)
+		return runJobsLoadedMsg{RunID: runID, Jobs: jobs, Err: err}
+	}
+}
+
+// triggerWorkflowCmd returns a command that triggers a workflow dispatch event
+func triggerWorkflowCmd(api github.API, owner, repoName string, workflowID int64, ref string, inputs map[string]interface{}) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		if err := api.TriggerWorkflow(ctx, owner, repoName, workflowID, ref, inputs); err != nil {
+			return workflowTriggeredMsg{Success: false, Err: err}
+		}
+		return workflowTriggeredMsg{Success: true}
+	}
+}
+
+// summaryLoads bounds the workflow summaries loaded at once
+const summaryLoads = 4
+
+// loadWorkflowSummariesCmd returns a command that loads, for each
+// workflow, whether it can be dispatched and its billable time. Each
+// workflow is answered by its own message, at most summaryLoads are
+// loading at once.
+func loadWorkflowSummariesCmd(api github.API, owner, repoName string, workflows []github.WorkflowInfo) tea.Cmd {
+	slots := make(chan struct{}, summaryLoads)
+	cmds := make([]tea.Cmd, len(workflows))
+	for i, workflow := range workflows {
+		cmds[i] = func() tea.Msg {
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			return loadWorkflowSummary(api, owner, repoName, workflow)
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// loadWorkflowSummary loads the trigger and the usage of a workflow, each
+// call with its own deadline
+func loadWorkflowSummary(api github.API, owner, repoName string, workflow github.WorkflowInfo) workflowSummaryLoadedMsg {
+	msg := workflowSummaryLoadedMsg{WorkflowID: workflow.ID}
+	var errs []error
+
+	ctx, cancel := apiContext()
+	content, err := api.GetWorkflowFile(ctx, owner, repoName, workflow.Path)
+	cancel()
+	if err == nil {
+		dispatch := github.HasWorkflowDispatch(content)
+		msg.Dispatch = &dispatch
+	} else {
+		errs = append(errs, fmt.Errorf("loading %s: %w", workflow.Path, err))
+	}
+
+	ctx, cancel = apiContext()
+	msg.Usage, err = api.GetWorkflowUsage(ctx, owner, repoName, workflow.ID)
+	cancel()
+	if err != nil {
+		errs = append(errs, fmt.Errorf("loading the usage: %w", err))
+	}
+
+	msg.Err = errors.Join(errs...)
+	return msg
+}
+
+// setWorkflowEnabledCmd returns a command that enables or disables a workflow
+func setWorkflowEnabledCmd(api github.API, owner, repoName string, workflowID int64, enabled bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		err := api.SetWorkflowEnabled(ctx, owner, repoName, workflowID, enabled)
+		return workflowStateChangedMsg{WorkflowID: workflowID, Enabled: enabled, Err: err}
+	}
+}
+
+// loadWorkflowFileCmd returns a command that loads the YAML source of a
+// workflow
+func loadWorkflowFileCmd(api github.API, owner, repoName, workflowPath string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		content, err := api.GetWorkflowFile(ctx, owner, repoName, workflowPath)
+		return workflowFileLoadedMsg{Content: content, Err: err}
+	}
+}
+
+// loadWorkflowAnalyticsCmd returns a command that loads the latest
+// completed runs of a workflow and their jobs, one call per run. Runs whose
+// jobs cannot be loaded are kept without them.
+func loadWorkflowAnalyticsCmd(api github.API, owner, repoName string, workflowID int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		infos, err := api.ListWorkflowRuns(ctx, owner, repoName, workflowID, github.RunFilter{Status: "completed"})
+		if err != nil {
+			return workflowAnalyticsLoadedMsg{Err: err}
+		}
+
+		runs := make([]analytics.Run, len(infos))
+		for i, info := range infos {
+			runs[i].RunInfo = info
+			jobCtx, jobCancel := apiContext()
+			runs[i].Jobs, err = api.ListRunJobs(jobCtx, owner, repoName, info.ID)
+			jobCancel()
+			if err != nil {
+				slog.Debug("Loading run jobs failed", "run", info.ID, "error", err)
+			}
+		}
+		return workflowAnalyticsLoadedMsg{Runs: runs}
+	}
+}
+
+// exportAnalyticsCmd returns a command that writes a report as CSV to path
+func exportAnalyticsCmd(report analytics.Report, path string) tea.Cmd {
+	return func() tea.Msg {
+		f, err := os.Create(path)
+		if err != nil {
+			return analyticsExportedMsg{Path: path, Err: err}
+		}
+		err = analytics.WriteCSV(f, report)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		return analyticsExportedMsg{Path: path, Err: err}
+	}
+}
+
+// loadWorkflowInputsCmd returns a command that loads the inputs for a workflow
+func loadWorkflowInputsCmd(api github.API, owner, repoName, workflowPath string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		inputs, err := api.GetWorkflowInputs(ctx, owner, repoName, workflowPath)
+		return workflowInputsLoadedMsg{Inputs: inputs, Err: err}
+	}
+}
+
+// checkRefCmd returns a command that checks a ref is a branch or a tag of
+// a repository
+func checkRefCmd(api github.API, owner, repoName, ref string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		exists, err := api.RefExists(ctx, owner, repoName, ref)
+		return refCheckedMsg{Ref: ref, Exists: exists, Err: err}
+	}
+}
+
+// runLinkTimeout is how long the run created by a dispatch is looked for
+const runLinkTimeout = 90 * time.Second
+
+// runLinkDelay is the wait before looking for the run of a dispatch again:
+// none at first, then doubling from a second up to eight
+func runLinkDelay(attempt int) time.Duration {
+	if attempt == 0 {
+		return 0
+	}
+	return min(time.Second<<(attempt-1), 8*time.Second)
+}
+
+// findDispatchedRunsCmd returns a command that waits delay then looks for
+// the runs dispatched by actor on ref since a time. An empty actor is
+// replaced by the current user.
+func findDispatchedRunsCmd(api github.API, owner, repoName string, workflowID int64, actor, ref string, since time.Time, delay time.Duration) tea.Cmd {
+	find := func(time.Time) tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		if actor == "" {
+			user, err := api.GetUser(ctx)
+			if err != nil {
+				return dispatchedRunsFoundMsg{Err: err}
+			}
+			actor = user.Login
+		}
+		runs, err := api.FindDispatchedRuns(ctx, owner, repoName, workflowID, actor, ref, since)
+		return dispatchedRunsFoundMsg{Actor: actor, Runs: runs, Err: err}
+	}
+	if delay == 0 {
+		return func() tea.Msg { return find(time.Now()) }
+	}
+	return tick(delay, find)
+}
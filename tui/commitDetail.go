@@ -0,0 +1,232 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+// commitDetailMaxRuns bounds the height of the runs table, the files
+// take the remaining space
+const commitDetailMaxRuns = 5
+
+type commitDetailView struct {
+	commonElements
+
+	// Service
+	sess *session
+
+	// Context
+	owner    string
+	repoName string
+	sha      string
+
+	// State
+	commit  *github.CommitDetail
+	runs    []github.RunInfo
+	loading int // requests still running
+	err     error
+
+	// UI
+	EltList table.Model // runs triggered for the commit
+	keys    KeyMap
+
+	// Navigation
+	parentView tea.Model
+}
+
+func (m *commitDetailView) resizeMain(w int, h int) {
+	headerHeight := lipgloss.Height(m.RenderTopFields())
+	footerHeight := lipgloss.Height(m.RenderBottomFields())
+	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2)
+}
+
+// NewCommitDetail creates a view showing a commit and the workflow runs
+// triggered for it. Back returns to parentView.
+func NewCommitDetail(sess *session, owner, repoName, sha string, parentView tea.Model) (tea.Model, tea.Cmd) {
+	m := &commitDetailView{
+		sess:       sess,
+		owner:      owner,
+		repoName:   repoName,
+		sha:        sha,
+		loading:    2,
+		parentView: parentView,
+		keys:       keyMapFor(viewCommitDetail),
+	}
+	m.keys.Select = withDesc(m.keys.Select, "Watch run")
+
+	m.InitTop(owner, repoName, "Commit "+shortSHA(sha))
+	m.TopFields = []string{owner, repoName, "Commit " + shortSHA(sha)}
+	m.InitBottom()
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Select, m.keys.Back, m.keys.Help)
+
+	m.EltList = m.buildRunsTable()
+	if constants.WindowSize.Height != 0 {
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	}
+
+	return m, tea.Batch(
+		loadCommitCmd(sess, owner, repoName, sha),
+		loadCommitRunsCmd(sess, owner, repoName, sha),
+	)
+}
+
+func (m *commitDetailView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *commitDetailView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case commitLoadedMsg:
+		m.loading--
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		m.commit = msg.Commit
+		return m, nil
+
+	case commitRunsLoadedMsg:
+		m.loading--
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		m.runs = msg.Runs
+		m.EltList = m.buildRunsTable()
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		constants.WindowSize = msg
+		m.resizeMain(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case isQuit(msg, m.keys):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return m.parentView, m.parentView.Init()
+		case key.Matches(msg, m.keys.Select):
+			if m.loading > 0 || len(m.runs) == 0 {
+				return m, nil
+			}
+			run := m.EltList.HighlightedRow().Data["run"].(github.RunInfo)
+			return NewWorkflowRunWatch(m.sess, m.owner, m.repoName, run.WorkflowID, run.ID, m)
+		}
+	}
+
+	var cmd tea.Cmd
+	m.EltList, cmd = m.EltList.Update(msg)
+	return m, cmd
+}
+
+func (m *commitDetailView) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit or 'backspace' to go back", m.err)
+	}
+
+	if m.loading > 0 {
+		return m.RenderTopFields() + "\n\nLoading commit..."
+	}
+
+	emphasis := lipgloss.NewStyle().Bold(true).Foreground(theme.Current.Emphasis)
+	muted := lipgloss.NewStyle().Foreground(theme.Current.Muted)
+	added := lipgloss.NewStyle().Foreground(theme.Current.Success)
+	removed := lipgloss.NewStyle().Foreground(theme.Current.Failure)
+
+	// Subject, then the body of the message
+	subject, body, _ := strings.Cut(m.commit.Message, "\n")
+	lines := []string{
+		emphasis.Render(subject),
+		muted.Render(fmt.Sprintf("%s by %s on %s", m.commit.SHA, m.commit.Author, m.commit.Date.Format("2006-01-02 15:04:05"))),
+	}
+	if body = strings.TrimSpace(body); body != "" {
+		lines = append(lines, "")
+		lines = append(lines, strings.Split(body, "\n")...)
+	}
+	lines = append(lines, "", fmt.Sprintf("%d file(s) changed, %s, %s",
+		len(m.commit.Files), added.Render(fmt.Sprintf("%d additions(+)", m.commit.Additions)), removed.Render(fmt.Sprintf("%d deletions(-)", m.commit.Deletions))))
+
+	lines = append(lines, "", emphasis.Render(fmt.Sprintf("Runs (%d)", len(m.runs))))
+	if len(m.runs) == 0 {
+		lines = append(lines, muted.Render("No workflow run for this commit"))
+	} else {
+		for i, row := range m.EltList.GetVisibleRows() {
+			row.Data["arrow"] = ""
+			if i == m.EltList.GetHighlightedRowIndex() {
+				row.Data["arrow"] = theme.Icons.Arrow
+			}
+		}
+		lines = append(lines, m.EltList.View())
+	}
+
+	lines = append(lines, "", emphasis.Render(fmt.Sprintf("Files (%d)", len(m.commit.Files))))
+	for _, file := range m.commit.Files {
+		lines = append(lines, fmt.Sprintf("%-9s %s %s  %s",
+			file.Status, added.Render(fmt.Sprintf("+%-5d", file.Additions)), removed.Render(fmt.Sprintf("-%-5d", file.Deletions)), file.Filename))
+	}
+
+	// Files past the bottom of the screen are cut
+	content := strings.Split(strings.Join(lines, "\n"), "\n")
+	if height := constants.MainStyle.GetHeight(); height > 0 && len(content) > height {
+		content = content[:height]
+	}
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s",
+		m.RenderTopFields(),
+		constants.MainStyle.Render(strings.Join(content, "\n")),
+		m.RenderBottomFields(),
+	)
+}
+
+func (m *commitDetailView) buildRunsTable() table.Model {
+	columns := []table.Column{
+		table.NewColumn("arrow", " ", 3),
+		table.NewColumn("indicator", " ", 3),
+		table.NewColumn("workflow", "Workflow", 30),
+		table.NewColumn("event", "Event", 18),
+		table.NewColumn("status", "Status", 12),
+		table.NewColumn("created", "Created", 17),
+	}
+
+	rows := []table.Row{}
+	for _, run := range m.runs {
+		indicator, color := theme.RunStatus(run.Status, run.Conclusion)
+		status := run.Status
+		if run.Status == "completed" {
+			status = run.Conclusion
+		}
+		rows = append(rows, table.NewRow(table.RowData{
+			"arrow":     "",
+			"indicator": table.NewStyledCell(indicator, lipgloss.NewStyle().Foreground(color)),
+			"workflow":  run.Title,
+			"event":     run.Event,
+			"status":    status,
+			"created":   run.CreatedAt.Format("2006-01-02 15:04"),
+			"run":       run,
+		}))
+	}
+
+	return table.New(columns).WithRows(rows).
+		WithKeyMap(m.keys.tableKeyMap()).
+		Focused(true).
+		Border(table.Border{}).
+		WithBaseStyle(constants.BaseTableStyle).
+		HighlightStyle(constants.HighlightedLineStyle).
+		WithFooterVisibility(false).
+		WithPageSize(commitDetailMaxRuns)
+}
+
+func (m *commitDetailView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Select, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.Quit}
+}
@@ -0,0 +1,239 @@
+package tui
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+// commitChecks is the combined check status of a commit
+type commitChecks struct {
+	Status     string
+	Conclusion string
+}
+
+type commitListView struct {
+	commonElements
+
+	// Service
+	sess *session
+
+	// Context
+	owner    string
+	repoName string
+	branch   string
+
+	// State
+	commits   []github.CommitInfo
+	checks    map[string]commitChecks
+	errs      map[string]error
+	requested map[string]bool // commits whose checks are loading or loaded
+	loading   bool
+	err       error
+
+	// UI
+	EltList table.Model
+	keys    KeyMap
+
+	// Navigation
+	parentView tea.Model
+}
+
+func (m *commitListView) resizeMain(w int, h int) {
+	headerHeight := lipgloss.Height(m.RenderTopFields())
+	footerHeight := lipgloss.Height(m.RenderBottomFields())
+	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2)
+	m.EltList = m.EltList.WithPageSize(h - headerHeight - footerHeight - 3)
+}
+
+// NewCommitList creates a view listing the latest commits of a branch.
+// Back returns to parentView.
+func NewCommitList(sess *session, owner, repoName, branch string, parentView tea.Model) (tea.Model, tea.Cmd) {
+	m := &commitListView{
+		sess:       sess,
+		owner:      owner,
+		repoName:   repoName,
+		branch:     branch,
+		checks:     map[string]commitChecks{},
+		errs:       map[string]error{},
+		requested:  map[string]bool{},
+		loading:    true,
+		parentView: parentView,
+		keys:       keyMapFor(viewCommitList),
+	}
+
+	m.InitTop(owner, repoName, "Commits")
+	m.TopFields = []string{owner, repoName, "Commits on " + branch}
+	m.InitBottom()
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Select, m.keys.Refresh, m.keys.Back, m.keys.Help)
+
+	return m, loadCommitsCmd(sess, owner, repoName, branch)
+}
+
+func (m *commitListView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *commitListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case commitsLoadedMsg:
+		m.loading = false
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		m.commits = msg.Commits
+		m.TopFields[2] = fmt.Sprintf("Commits on %s (%d)", m.branch, len(m.commits))
+		m.rebuild()
+		return m, m.loadVisibleChecks()
+
+	case commitChecksLoadedMsg:
+		if msg.Err != nil {
+			slog.Debug("Loading commit checks failed", "sha", msg.SHA, "error", msg.Err)
+			m.errs[msg.SHA] = msg.Err
+		} else {
+			m.checks[msg.SHA] = commitChecks{Status: msg.Status, Conclusion: msg.Conclusion}
+			delete(m.errs, msg.SHA)
+		}
+		m.rebuild()
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		constants.WindowSize = msg
+		m.resizeMain(msg.Width, msg.Height)
+		return m, m.loadVisibleChecks()
+
+	case tea.KeyMsg:
+		if m.loading {
+			if isQuit(msg, m.keys) {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch {
+		case isQuit(msg, m.keys):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return m.parentView, m.parentView.Init()
+		case key.Matches(msg, m.keys.Refresh):
+			m.requested = map[string]bool{}
+			m.loading = true
+			return m, loadCommitsCmd(m.sess, m.owner, m.repoName, m.branch)
+		case key.Matches(msg, m.keys.Select):
+			if len(m.EltList.GetVisibleRows()) == 0 {
+				return m, nil
+			}
+			commit := m.EltList.HighlightedRow().Data["commit"].(github.CommitInfo)
+			return NewCommitDetail(m.sess, m.owner, m.repoName, commit.SHA, m)
+		}
+	}
+
+	var cmd tea.Cmd
+	m.EltList, cmd = m.EltList.Update(msg)
+	return m, tea.Batch(cmd, m.loadVisibleChecks())
+}
+
+// loadVisibleChecks loads the checks of the commits on the current page
+// which were not requested yet
+func (m *commitListView) loadVisibleChecks() tea.Cmd {
+	if m.loading || len(m.commits) == 0 {
+		return nil
+	}
+	rows := m.EltList.GetVisibleRows()
+	start, end := m.EltList.VisibleIndices()
+	var cmds []tea.Cmd
+	for i := start; i <= end && i < len(rows); i++ {
+		commit, ok := rows[i].Data["commit"].(github.CommitInfo)
+		if !ok || m.requested[commit.SHA] {
+			continue
+		}
+		m.requested[commit.SHA] = true
+		cmds = append(cmds, loadCommitChecksCmd(m.sess, m.owner, m.repoName, commit.SHA))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *commitListView) rebuild() {
+	m.EltList = m.buildCommitTable(m.EltList.GetHighlightedRowIndex())
+	if constants.WindowSize.Height != 0 {
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	}
+}
+
+func (m *commitListView) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit or 'backspace' to go back", m.err)
+	}
+
+	if m.loading {
+		return m.RenderTopFields() + "\n\nLoading commits..."
+	}
+
+	for i, row := range m.EltList.GetVisibleRows() {
+		row.Data["arrow"] = ""
+		if i == m.EltList.GetHighlightedRowIndex() {
+			row.Data["arrow"] = theme.Icons.Arrow
+		}
+	}
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s",
+		m.RenderTopFields(),
+		constants.MainStyle.Render(m.EltList.View()),
+		m.RenderBottomFields(),
+	)
+}
+
+func (m *commitListView) buildCommitTable(highlighted int) table.Model {
+	columns := []table.Column{
+		table.NewColumn("arrow", " ", 3),
+		table.NewColumn("indicator", " ", 3),
+		table.NewColumn("sha", "SHA", 9),
+		table.NewColumn("message", "Message", 50),
+		table.NewColumn("author", "Author", 15),
+		table.NewColumn("date", "Date", 17),
+	}
+
+	rows := []table.Row{}
+	for _, commit := range m.commits {
+		indicator := table.NewStyledCell("", lipgloss.NewStyle())
+		if _, ok := m.errs[commit.SHA]; ok {
+			// The error itself is logged, the row only flags it
+			indicator = table.NewStyledCell("!", constants.ErrorStyle)
+		} else if checks, ok := m.checks[commit.SHA]; ok && checks.Status != "" {
+			icon, color := theme.RunStatus(checks.Status, checks.Conclusion)
+			indicator = table.NewStyledCell(icon, lipgloss.NewStyle().Foreground(color))
+		}
+		rows = append(rows, table.NewRow(table.RowData{
+			"arrow":     "",
+			"indicator": indicator,
+			"sha":       shortSHA(commit.SHA),
+			"message":   commit.Message,
+			"author":    commit.Author,
+			"date":      commit.Date.Format("2006-01-02 15:04"),
+			"commit":    commit,
+		}))
+	}
+
+	return table.New(columns).WithRows(rows).
+		WithKeyMap(m.keys.tableKeyMap()).
+		Focused(true).
+		Border(table.Border{}).
+		WithBaseStyle(constants.BaseTableStyle).
+		HighlightStyle(constants.HighlightedLineStyle).
+		WithFooterVisibility(false).
+		WithHighlightedRow(min(highlighted, max(len(rows)-1, 0)))
+}
+
+func (m *commitListView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Select, m.keys.Refresh, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
@@ -4,8 +4,8 @@ import (
 	"fmt"
 
 	"github.com/charmbracelet/bubbles/textinput"
-	"github.com/charmbracelet/lipgloss"
 	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
 )
 
 // func BuildBottom()
@@ -17,16 +17,8 @@ type commonElements struct {
 	CommandInput textinput.Model
 }
 
-var statusStyle = lipgloss.NewStyle().
-	Inherit(constants.StatusBarStyle).
-	Foreground(lipgloss.Color("#FFFDF5")).
-	Background(lipgloss.Color("#FF5F87")).
-	Padding(0, 1).
-	MarginRight(1)
-
 func (c *commonElements) InitBottom() {
-	// cat \ueeed , github \uf09b \uf113
-	c.Bottom = statusStyle.Render("\uf113") + constants.StatusBarStyle.Render("Default Bottom")
+	c.Bottom = constants.BadgeStyle.Render(theme.Icons.Logo) + constants.StatusBarStyle.Render("Default Bottom")
 }
 
 func (c *commonElements) InitTop(topText ...string) {
@@ -0,0 +1,190 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+// compareStatuses phrases the status of a comparison between head and base
+var compareStatuses = map[string]string{
+	"ahead":     "is ahead of",
+	"behind":    "is behind",
+	"diverged":  "has diverged from",
+	"identical": "is identical to",
+}
+
+type compareView struct {
+	commonElements
+
+	// Service
+	sess *session
+
+	// Context
+	owner    string
+	repoName string
+	base     string
+	head     string
+
+	// State
+	comparison *github.Comparison
+	loading    bool
+	err        error
+
+	// UI
+	offset int // first line shown
+	keys   KeyMap
+
+	// Navigation
+	parentView tea.Model
+}
+
+func (m *compareView) resizeMain(w int, h int) {
+	headerHeight := lipgloss.Height(m.RenderTopFields())
+	footerHeight := lipgloss.Height(m.RenderBottomFields())
+	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2)
+}
+
+// NewCompare creates a view listing the commits and files changed from
+// base to head. Back returns to parentView.
+func NewCompare(sess *session, owner, repoName, base, head string, parentView tea.Model) (tea.Model, tea.Cmd) {
+	m := &compareView{
+		sess:       sess,
+		owner:      owner,
+		repoName:   repoName,
+		base:       base,
+		head:       head,
+		loading:    true,
+		parentView: parentView,
+		keys:       keyMapFor(viewCompare),
+	}
+
+	m.InitTop(owner, repoName, "Compare")
+	m.TopFields = []string{owner, repoName, fmt.Sprintf("Compare %s...%s", base, head)}
+	m.InitBottom()
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Back, m.keys.Help)
+
+	if constants.WindowSize.Height != 0 {
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	}
+
+	return m, compareRefsCmd(sess, owner, repoName, base, head)
+}
+
+func (m *compareView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *compareView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case comparisonLoadedMsg:
+		m.loading = false
+		m.comparison = msg.Comparison
+		m.err = msg.Err
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		constants.WindowSize = msg
+		m.resizeMain(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case isQuit(msg, m.keys):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return m.parentView, m.parentView.Init()
+		case key.Matches(msg, m.keys.Down):
+			m.scroll(1)
+		case key.Matches(msg, m.keys.Up):
+			m.scroll(-1)
+		case key.Matches(msg, m.keys.PageDown):
+			m.scroll(m.pageHeight())
+		case key.Matches(msg, m.keys.PageUp):
+			m.scroll(-m.pageHeight())
+		}
+	}
+
+	return m, nil
+}
+
+func (m *compareView) pageHeight() int {
+	return max(constants.MainStyle.GetHeight(), 1)
+}
+
+func (m *compareView) scroll(delta int) {
+	m.offset = max(min(m.offset+delta, len(m.lines())-m.pageHeight()), 0)
+}
+
+// lines renders the comparison, one entry per line
+func (m *compareView) lines() []string {
+	c := m.comparison
+	if c == nil {
+		return nil
+	}
+
+	emphasis := lipgloss.NewStyle().Bold(true).Foreground(theme.Current.Emphasis)
+	muted := lipgloss.NewStyle().Foreground(theme.Current.Muted)
+	added := lipgloss.NewStyle().Foreground(theme.Current.Success)
+	removed := lipgloss.NewStyle().Foreground(theme.Current.Failure)
+
+	lines := []string{
+		emphasis.Render(fmt.Sprintf("%s %s %s", c.Head, compareStatuses[c.Status], c.Base)),
+		muted.Render(fmt.Sprintf("%d commit(s) ahead, %d behind, %d file(s) changed", c.AheadBy, c.BehindBy, len(c.Files))),
+		"",
+		emphasis.Render(fmt.Sprintf("Commits (%d)", len(c.Commits))),
+	}
+	for _, commit := range c.Commits {
+		lines = append(lines, fmt.Sprintf("%s  %s  %-15s %s",
+			muted.Render(shortSHA(commit.SHA)), commit.Date.Format("2006-01-02"), commit.Author, commit.Message))
+	}
+	if len(c.Commits) == 0 {
+		lines = append(lines, muted.Render("No commits"))
+	}
+
+	lines = append(lines, "", emphasis.Render(fmt.Sprintf("Files (%d)", len(c.Files))))
+	for _, file := range c.Files {
+		lines = append(lines, fmt.Sprintf("%-9s %s %s  %s",
+			file.Status, added.Render(fmt.Sprintf("+%-5d", file.Additions)), removed.Render(fmt.Sprintf("-%-5d", file.Deletions)), file.Filename))
+	}
+	return lines
+}
+
+func (m *compareView) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit or 'backspace' to go back", m.err)
+	}
+
+	if m.loading {
+		return m.RenderTopFields() + "\n\nComparing branches..."
+	}
+
+	lines := m.lines()
+	end := min(m.offset+m.pageHeight(), len(lines))
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s",
+		m.RenderTopFields(),
+		constants.MainStyle.Render(strings.Join(lines[m.offset:end], "\n")),
+		m.RenderBottomFields(),
+	)
+}
+
+// shortSHA abbreviates a commit SHA as git does
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+func (m *compareView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
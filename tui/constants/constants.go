@@ -3,37 +3,66 @@ package constants
 import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/jjournet/tgr/tui/theme"
 )
 
 // WindowSize stores the current terminal window size
 // This is the only piece of "global" state we keep for UI purposes
 var WindowSize tea.WindowSizeMsg
 
-// Styling constants
+// Styling constants, built from the current theme by ApplyTheme
 var (
 	DocStyle = lipgloss.NewStyle().Margin(1)
 
-	StatusBarStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.AdaptiveColor{Light: "#343433", Dark: "#C1C6B2"}).
-			Background(lipgloss.AdaptiveColor{Light: "#D9DCCF", Dark: "#353533"})
-
-	TopBarStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.AdaptiveColor{Light: "#343433", Dark: "#C1C6B2"}).
-			Background(lipgloss.AdaptiveColor{Light: "#D9DCCF", Dark: "#353533"})
+	StatusBarStyle lipgloss.Style
+	TopBarStyle    lipgloss.Style
+	BadgeStyle     lipgloss.Style
 
 	MainStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder())
 
 	CommandStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder())
 
-	BaseTableStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Align(lipgloss.Left).Padding(0, 1).
-			Foreground(lipgloss.Color("#77c2f9"))
-
-	HighlightedLineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#000000")).Background(lipgloss.Color("#77c2f9")).Padding(0, 1)
+	BaseTableStyle       lipgloss.Style
+	HighlightedLineStyle lipgloss.Style
 
-	FocusedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
-	BlurredStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
-	CursorStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	FocusedStyle lipgloss.Style
+	BlurredStyle lipgloss.Style
+	CursorStyle  lipgloss.Style
 	NoStyle      = lipgloss.NewStyle()
-	ErrorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000"))
+	ErrorStyle   lipgloss.Style
 )
+
+func init() {
+	ApplyTheme()
+}
+
+// ApplyTheme rebuilds the shared styles from theme.Current
+func ApplyTheme() {
+	t := theme.Current
+
+	StatusBarStyle = lipgloss.NewStyle().
+		Foreground(t.BarText).
+		Background(t.BarBackground)
+
+	TopBarStyle = lipgloss.NewStyle().
+		Foreground(t.BarText).
+		Background(t.BarBackground)
+
+	BadgeStyle = lipgloss.NewStyle().
+		Inherit(StatusBarStyle).
+		Foreground(t.OnBadge).
+		Background(t.Badge).
+		Padding(0, 1).
+		MarginRight(1)
+
+	BaseTableStyle = lipgloss.NewStyle().Align(lipgloss.Left).Padding(0, 1).
+		Foreground(t.Accent)
+
+	HighlightedLineStyle = lipgloss.NewStyle().Foreground(t.OnAccent).Background(t.Accent).Padding(0, 1)
+
+	FocusedStyle = lipgloss.NewStyle().Foreground(t.Focus)
+	BlurredStyle = lipgloss.NewStyle().Foreground(t.Muted)
+	CursorStyle = lipgloss.NewStyle().Foreground(t.Focus)
+	ErrorStyle = lipgloss.NewStyle().Foreground(t.Failure)
+}
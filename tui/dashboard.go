@@ -0,0 +1,293 @@
+package tui
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+	"github.com/jjournet/tgr/config"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+// dashboardRepo is a repository listed on the dashboard
+type dashboardRepo struct {
+	Name   string // "owner/repo"
+	Pinned bool
+}
+
+// dashboardRepos returns the favorites (config file then pins) followed
+// by the recently visited repositories that are not favorites
+func (s *session) dashboardRepos() []dashboardRepo {
+	var repos []dashboardRepo
+	seen := map[string]bool{}
+	add := func(name string, pinned bool) {
+		if !seen[name] {
+			seen[name] = true
+			repos = append(repos, dashboardRepo{Name: name, Pinned: pinned})
+		}
+	}
+	for _, name := range s.settings.Favorites {
+		add(name, true)
+	}
+	for _, name := range s.state.Pinned {
+		add(name, true)
+	}
+	for _, name := range s.state.Recent {
+		add(name, false)
+	}
+	return repos
+}
+
+// isFavorite reports whether a repository is pinned locally or in the config file
+func (s *session) isFavorite(repo string) bool {
+	return slices.Contains(s.settings.Favorites, repo) || s.state.IsPinned(repo)
+}
+
+// togglePin pins or unpins a repository and returns a message for the user
+func (s *session) togglePin(repo string) string {
+	if slices.Contains(s.settings.Favorites, repo) {
+		return repo + " is a favorite in the config file"
+	}
+	pinned := s.state.TogglePin(repo)
+	s.saveState()
+	if pinned {
+		return "Pinned " + repo
+	}
+	return "Unpinned " + repo
+}
+
+// visitRepo records a repository in the recent list
+func (s *session) visitRepo(repo string) {
+	s.state.Visit(repo)
+	s.saveState()
+}
+
+func (s *session) saveState() {
+	if err := s.state.Save(); err != nil {
+		slog.Warn("Saving state failed", "error", err)
+	}
+}
+
+type dashboard struct {
+	commonElements
+
+	// Service
+	sess *session
+
+	// State
+	repos    []dashboardRepo
+	statuses map[string]*github.RepoStatus
+	errs     map[string]error
+
+	// UI
+	EltList table.Model
+	keys    KeyMap
+}
+
+func (m *dashboard) resizeMain(w int, h int) {
+	headerHeight := lipgloss.Height(m.RenderTopFields())
+	footerHeight := lipgloss.Height(m.RenderBottomFields())
+	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2)
+	m.EltList = m.EltList.WithPageSize(h - headerHeight - footerHeight - 3)
+}
+
+// NewDashboard creates the home view listing favorite and recent repositories
+func NewDashboard(sess *session) (tea.Model, tea.Cmd) {
+	m := &dashboard{
+		sess:     sess,
+		repos:    sess.dashboardRepos(),
+		statuses: map[string]*github.RepoStatus{},
+		errs:     map[string]error{},
+		keys:     keyMapFor(viewDashboard),
+	}
+
+	m.InitTop("Dashboard")
+	m.TopFields = []string{"Dashboard", fmt.Sprintf("(%d repos)", len(m.repos))}
+	m.InitBottom()
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Select, m.keys.Pin, m.keys.Monitor, m.keys.Inbox, m.keys.Refresh, withDesc(m.keys.Back, "Owners"), m.keys.Help)
+
+	m.EltList = m.buildDashboardTable(0)
+	if constants.WindowSize.Height != 0 {
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	}
+
+	return m, m.loadStatuses()
+}
+
+// loadStatuses loads the badges of every listed repository in parallel
+func (m *dashboard) loadStatuses() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, repo := range m.repos {
+		owner, repoName, ok := config.SplitRepo(repo.Name)
+		if !ok {
+			continue
+		}
+		cmds = append(cmds, loadRepoStatusCmd(m.sess, owner, repoName))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *dashboard) Init() tea.Cmd {
+	return nil
+}
+
+func (m *dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case repoStatusLoadedMsg:
+		if msg.Err != nil {
+			slog.Debug("Loading repository status failed", "repo", msg.Repo, "error", msg.Err)
+			m.errs[msg.Repo] = msg.Err
+		} else {
+			m.statuses[msg.Repo] = msg.Status
+			delete(m.errs, msg.Repo)
+		}
+		m.EltList = m.buildDashboardTable(m.EltList.GetHighlightedRowIndex())
+		if constants.WindowSize.Height != 0 {
+			m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+		}
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		constants.WindowSize = msg
+		m.resizeMain(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case isQuit(msg, m.keys):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return NewProfileSelection(m.sess)
+		case key.Matches(msg, m.keys.Select):
+			if len(m.repos) == 0 {
+				return m, nil
+			}
+			repo := m.EltList.HighlightedRow().Data["repo"].(string)
+			if owner, repoName, ok := config.SplitRepo(repo); ok {
+				return NewRepoView(m.sess, owner, repoName)
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.Pin):
+			if len(m.repos) == 0 {
+				return m, nil
+			}
+			repo := m.EltList.HighlightedRow().Data["repo"].(string)
+			m.TopFields[1] = m.sess.togglePin(repo)
+			m.repos = m.sess.dashboardRepos()
+			m.EltList = m.buildDashboardTable(min(m.EltList.GetHighlightedRowIndex(), max(len(m.repos)-1, 0)))
+			if constants.WindowSize.Height != 0 {
+				m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.Monitor):
+			return NewRunMonitor(m.sess)
+		case key.Matches(msg, m.keys.Inbox):
+			return NewInbox(m.sess)
+		case key.Matches(msg, m.keys.Refresh):
+			m.TopFields[1] = fmt.Sprintf("(%d repos)", len(m.repos))
+			return m, m.loadStatuses()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.EltList, cmd = m.EltList.Update(msg)
+	return m, cmd
+}
+
+func (m *dashboard) View() string {
+	if len(m.repos) == 0 {
+		return fmt.Sprintf(
+			"%s\n%s\n%s",
+			m.RenderTopFields(),
+			constants.MainStyle.Render(fmt.Sprintf(
+				"No favorite or recent repository yet.\n\nPress %s to pick an owner, open a repository and press %s in the list to pin it.",
+				m.keys.Back.Help().Key, m.keys.Pin.Help().Key,
+			)),
+			m.RenderBottomFields(),
+		)
+	}
+
+	for i, row := range m.EltList.GetVisibleRows() {
+		row.Data["arrow"] = ""
+		if i == m.EltList.GetHighlightedRowIndex() {
+			row.Data["arrow"] = theme.Icons.Arrow
+		}
+	}
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s",
+		m.RenderTopFields(),
+		constants.MainStyle.Render(m.EltList.View()),
+		m.RenderBottomFields(),
+	)
+}
+
+func (m *dashboard) buildDashboardTable(highlighted int) table.Model {
+	columns := []table.Column{
+		table.NewColumn("arrow", " ", 3),
+		table.NewColumn("kind", " ", 3),
+		table.NewColumn("repo", "Repository", 35),
+		table.NewColumn("indicator", " ", 3),
+		table.NewColumn("run", "Latest Run", 30),
+		table.NewColumn("prs", "PRs", 6),
+		table.NewColumn("issues", "Issues", 8),
+	}
+
+	rows := []table.Row{}
+	for _, repo := range m.repos {
+		rows = append(rows, m.makeDashboardRow(repo))
+	}
+
+	return table.New(columns).WithRows(rows).
+		WithKeyMap(m.keys.tableKeyMap()).
+		Focused(true).
+		Border(table.Border{}).
+		WithBaseStyle(constants.BaseTableStyle).
+		HighlightStyle(constants.HighlightedLineStyle).
+		WithFooterVisibility(false).
+		WithHighlightedRow(highlighted)
+}
+
+func (m *dashboard) makeDashboardRow(repo dashboardRepo) table.Row {
+	kind := theme.Icons.Recent
+	if repo.Pinned {
+		kind = theme.Icons.Pinned
+	}
+
+	data := table.RowData{
+		"arrow":     "",
+		"kind":      kind,
+		"repo":      repo.Name,
+		"indicator": "",
+		"run":       "Loading...",
+		"prs":       "",
+		"issues":    "",
+	}
+
+	if err, ok := m.errs[repo.Name]; ok {
+		data["run"] = table.NewStyledCell(err.Error(), constants.ErrorStyle)
+	} else if status, ok := m.statuses[repo.Name]; ok {
+		data["run"] = "No runs on " + status.MainBranch
+		if run := status.LatestRun; run != nil {
+			indicator, color := theme.RunStatus(run.Status, run.Conclusion)
+			data["indicator"] = table.NewStyledCell(indicator, lipgloss.NewStyle().Foreground(color))
+			data["run"] = run.Title
+		}
+		data["prs"] = strconv.Itoa(status.OpenPRs)
+		data["issues"] = strconv.Itoa(status.OpenIssues)
+	}
+
+	return table.NewRow(data)
+}
+
+func (m *dashboard) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Select, m.keys.Pin, m.keys.Monitor, m.keys.Inbox, m.keys.Refresh, withDesc(m.keys.Back, "Owners"), m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
@@ -0,0 +1,243 @@
+package tui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+// deploymentStates maps deployment states to the run status and
+// conclusion drawing their icon
+var deploymentStates = map[string][2]string{
+	"success":     {"completed", "success"},
+	"failure":     {"completed", "failure"},
+	"error":       {"completed", "failure"},
+	"inactive":    {"completed", "cancelled"},
+	"in_progress": {"in_progress", ""},
+	"queued":      {"queued", ""},
+	"pending":     {"queued", ""},
+}
+
+type environmentListView struct {
+	commonElements
+
+	// Service
+	sess *session
+
+	// Context
+	owner    string
+	repoName string
+
+	// State
+	environments []github.EnvironmentInfo
+	deployments  map[string]*github.DeploymentInfo // latest deployment per environment, nil when none
+	errs         map[string]error                  // why the deployment of an environment is unknown
+	loading      bool
+	err          error
+
+	// UI
+	EltList table.Model
+	keys    KeyMap
+}
+
+func (m *environmentListView) resizeMain(w int, h int) {
+	headerHeight := lipgloss.Height(m.RenderTopFields())
+	footerHeight := lipgloss.Height(m.RenderBottomFields())
+	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2)
+	m.EltList = m.EltList.WithPageSize(h - headerHeight - footerHeight - 3)
+}
+
+// NewEnvironmentList creates a view listing the deployment environments
+// of a repository
+func NewEnvironmentList(sess *session, owner, repoName string) (tea.Model, tea.Cmd) {
+	m := &environmentListView{
+		sess:     sess,
+		owner:    owner,
+		repoName: repoName,
+		loading:  true,
+		keys:     keyMapFor(viewEnvironmentList),
+	}
+
+	m.InitTop(owner, repoName, "Environments")
+	m.TopFields = []string{owner, repoName, "Environments"}
+	m.InitBottom()
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Refresh, m.keys.Back, m.keys.Help)
+
+	return m, loadEnvironmentsCmd(sess, owner, repoName)
+}
+
+func (m *environmentListView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *environmentListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case environmentsLoadedMsg:
+		m.loading = false
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		m.environments = msg.Environments
+		m.deployments = map[string]*github.DeploymentInfo{}
+		m.errs = map[string]error{}
+		m.TopFields[2] = fmt.Sprintf("Environments (%d)", len(m.environments))
+		m.rebuild()
+
+		// Deployments come later, they need two calls per environment
+		cmds := make([]tea.Cmd, len(m.environments))
+		for i, env := range m.environments {
+			cmds[i] = loadLatestDeploymentCmd(m.sess, m.owner, m.repoName, env.Name)
+		}
+		return m, tea.Batch(cmds...)
+
+	case latestDeploymentLoadedMsg:
+		if msg.Err != nil {
+			slog.Debug("Loading latest deployment failed", "environment", msg.Environment, "error", msg.Err)
+			m.errs[msg.Environment] = msg.Err
+		} else {
+			m.deployments[msg.Environment] = msg.Deployment
+			delete(m.errs, msg.Environment)
+		}
+		m.rebuild()
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		constants.WindowSize = msg
+		m.resizeMain(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading {
+			if isQuit(msg, m.keys) {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch {
+		case isQuit(msg, m.keys):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return NewRepoView(m.sess, m.owner, m.repoName)
+		case key.Matches(msg, m.keys.Refresh):
+			m.loading = true
+			return m, loadEnvironmentsCmd(m.sess, m.owner, m.repoName)
+		}
+	}
+
+	var cmd tea.Cmd
+	m.EltList, cmd = m.EltList.Update(msg)
+	return m, cmd
+}
+
+func (m *environmentListView) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit or 'backspace' to go back", m.err)
+	}
+
+	if m.loading {
+		return m.RenderTopFields() + "\n\nLoading environments..."
+	}
+
+	if len(m.environments) == 0 {
+		return fmt.Sprintf(
+			"%s\n%s\n%s",
+			m.RenderTopFields(),
+			constants.MainStyle.Render("No deployment environment."),
+			m.RenderBottomFields(),
+		)
+	}
+
+	for i, row := range m.EltList.GetVisibleRows() {
+		row.Data["arrow"] = ""
+		if i == m.EltList.GetHighlightedRowIndex() {
+			row.Data["arrow"] = theme.Icons.Arrow
+		}
+	}
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s",
+		m.RenderTopFields(),
+		constants.MainStyle.Render(m.EltList.View()),
+		m.RenderBottomFields(),
+	)
+}
+
+// rebuild refreshes the table after the environments or their deployments
+// were loaded
+func (m *environmentListView) rebuild() {
+	m.EltList = m.buildEnvironmentTable(m.EltList.GetHighlightedRowIndex())
+	if constants.WindowSize.Height != 0 {
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	}
+}
+
+func (m *environmentListView) buildEnvironmentTable(highlighted int) table.Model {
+	columns := []table.Column{
+		table.NewColumn("arrow", " ", 3),
+		table.NewColumn("name", "Environment", 13),
+		table.NewColumn("reviewers", "Reviewers", 35),
+		table.NewColumn("wait", "Wait", 6),
+		table.NewColumn("branches", "Branches", 10),
+		table.NewColumn("indicator", " ", 3),
+		table.NewColumn("deployment", "Latest Deployment", 32),
+		table.NewColumn("date", "Date", 16),
+	}
+
+	rows := []table.Row{}
+	for _, env := range m.environments {
+		data := table.RowData{
+			"arrow":      "",
+			"name":       env.Name,
+			"reviewers":  strings.Join(env.Reviewers, ", "),
+			"wait":       "",
+			"branches":   env.BranchPolicy,
+			"indicator":  "",
+			"deployment": "",
+			"date":       "",
+		}
+		if env.PreventSelfReview {
+			data["reviewers"] = strings.Join(env.Reviewers, ", ") + " (no self)"
+		}
+		if env.WaitTimer > 0 {
+			data["wait"] = fmt.Sprintf("%dm", env.WaitTimer)
+		}
+		if err, ok := m.errs[env.Name]; ok {
+			data["deployment"] = table.NewStyledCell(err.Error(), constants.ErrorStyle)
+		} else if d, ok := m.deployments[env.Name]; !ok {
+			data["deployment"] = "Loading..."
+		} else if d == nil {
+			data["deployment"] = "Never deployed"
+		} else {
+			state := deploymentStates[d.State]
+			indicator, color := theme.RunStatus(state[0], state[1])
+			data["indicator"] = table.NewStyledCell(indicator, lipgloss.NewStyle().Foreground(color))
+			data["deployment"] = fmt.Sprintf("%s %s (%s) by %s", d.State, d.Ref, shortSHA(d.SHA), d.Creator)
+			data["date"] = d.CreatedAt.Format("2006-01-02 15:04")
+		}
+		rows = append(rows, table.NewRow(data))
+	}
+
+	return table.New(columns).WithRows(rows).
+		WithKeyMap(m.keys.tableKeyMap()).
+		Focused(true).
+		Border(table.Border{}).
+		WithBaseStyle(constants.BaseTableStyle).
+		HighlightStyle(constants.HighlightedLineStyle).
+		WithFooterVisibility(false).
+		WithHighlightedRow(min(highlighted, max(len(rows)-1, 0)))
+}
+
+func (m *environmentListView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Refresh, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
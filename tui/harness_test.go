@@ -0,0 +1,335 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jjournet/tgr/config"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/state"
+	"github.com/muesli/termenv"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata/golden")
+
+// cmdTimeout bounds how long the harness waits for a command. Refresh
+// ticks and retry delays never fire in tests, so a command still running
+// after it is stuck and fails the test.
+const cmdTimeout = 5 * time.Second
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	// Render without colors so snapshots do not depend on the terminal
+	lipgloss.SetColorProfile(termenv.Ascii)
+	lipgloss.SetHasDarkBackground(true)
+	// Stop time: tests deliver refreshes and retries by sending their
+	// messages
+	tick = func(time.Duration, func(time.Time) tea.Msg) tea.Cmd {
+		return func() tea.Msg { return nil }
+	}
+	os.Exit(m.Run())
+}
+
+// request is a call received by the fixture server
+type request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	// Body is the JSON body decoded, nil for other bodies such as uploads
+	Body map[string]any
+	Raw  []byte
+}
+
+// recorder keeps the calls received by the fixture server, which answers
+// them from its own goroutines
+type recorder struct {
+	mu       sync.Mutex
+	requests []request
+}
+
+func (rec *recorder) add(r *http.Request) {
+	raw, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(raw))
+	req := request{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Raw: raw}
+	json.Unmarshal(raw, &req.Body)
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.requests = append(rec.requests, req)
+}
+
+// newFixtureServer serves the recorded GitHub API responses found in
+// testdata/fixtures. A GET on /repos/acme/api is answered with
+// testdata/fixtures/repos/acme/api.json; query strings are ignored. A GET
+// asking for the SHA of a commit only is answered with the sha field of the
+// fixture.
+// Other methods (dispatches, deletions) answer 204, or 201 with the body
+// of testdata/fixtures/<path>.<method>.json when the call returns
+// something, such as a token created by a POST. GraphQL calls differ:
+// the operation named Project is answered with
+// testdata/fixtures/graphql/Project.json, mutations without a fixture
+// with empty data. Archive downloads (paths ending in /zip) redirect, as
+// GitHub does, to /blobs/<path> served from testdata/fixtures/blobs/<path>.zip.
+// Every call is kept in rec.
+func newFixtureServer(t *testing.T, rec *recorder) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.add(r)
+		if r.URL.Path == "/graphql" {
+			serveGraphQLFixture(t, w, r)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/blobs/") {
+			http.ServeFile(w, r, filepath.Join("testdata", "fixtures", filepath.FromSlash(strings.Trim(r.URL.Path, "/"))+".zip"))
+			return
+		}
+		if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/zip") {
+			http.Redirect(w, r, "http://"+r.Host+"/blobs"+r.URL.Path, http.StatusFound)
+			return
+		}
+		if r.Method != http.MethodGet {
+			path := filepath.Join("testdata", "fixtures", filepath.FromSlash(strings.Trim(r.URL.Path, "/"))+"."+strings.ToLower(r.Method)+".json")
+			if data, err := os.ReadFile(path); err == nil {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				w.Write(data)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		path := filepath.Join("testdata", "fixtures", filepath.FromSlash(strings.Trim(r.URL.Path, "/"))+".json")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Logf("fixture server: no fixture for %s %s", r.Method, r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"Not Found"}`)
+			return
+		}
+		if r.Header.Get("Accept") == "application/vnd.github.v3.sha" {
+			var commit struct{ SHA string }
+			json.Unmarshal(data, &commit)
+			fmt.Fprint(w, commit.SHA)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func serveGraphQLFixture(t *testing.T, w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Query         string `json:"query"`
+		OperationName string `json:"operationName"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		t.Errorf("fixture server: decoding GraphQL call: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	data, err := os.ReadFile(filepath.Join("testdata", "fixtures", "graphql", body.OperationName+".json"))
+	switch {
+	case err == nil:
+		w.Write(data)
+	case strings.HasPrefix(strings.TrimSpace(body.Query), "mutation"):
+		fmt.Fprint(w, `{"data":{}}`)
+	default:
+		t.Logf("fixture server: no fixture for GraphQL operation %s", body.OperationName)
+		fmt.Fprintf(w, `{"errors":[{"message":"no fixture for %s"}]}`, body.OperationName)
+	}
+}
+
+// harness drives a tea.Model synchronously: every message is passed to
+// Update and the returned commands are executed until they settle.
+type harness struct {
+	t     *testing.T
+	sess  *session
+	model tea.Model
+	// out is the terminal, receiving the notifications
+	out bytes.Buffer
+	rec recorder
+}
+
+func newHarness(t *testing.T, width, height int) *harness {
+	t.Helper()
+	h := &harness{t: t}
+	srv := newFixtureServer(t, &h.rec)
+	api, err := github.NewGitHubServiceWithBaseURL("test-token", srv.URL)
+	if err != nil {
+		t.Fatalf("creating service: %v", err)
+	}
+
+	app := NewApp(api, config.Default(), &state.State{}, &h.out)
+	h.sess, h.model = app.sess, app
+	h.send(tea.WindowSizeMsg{Width: width, Height: height})
+	h.run(app.Init())
+	return h
+}
+
+func (h *harness) send(msg tea.Msg) {
+	var cmd tea.Cmd
+	h.model, cmd = h.model.Update(msg)
+	h.run(cmd)
+}
+
+func (h *harness) run(cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+
+	var msg tea.Msg
+	select {
+	case msg = <-done:
+	case <-time.After(cmdTimeout):
+		h.t.Fatalf("command still running after %s", cmdTimeout)
+	}
+
+	switch msg := msg.(type) {
+	case nil:
+	case cursor.BlinkMsg:
+		// The cursor blinks on its own timer, ignored as time is stopped
+	case tea.BatchMsg:
+		for _, c := range msg {
+			h.run(c)
+		}
+	case tea.QuitMsg:
+		h.t.Fatalf("unexpected quit")
+	default:
+		h.send(msg)
+	}
+}
+
+// open replaces the current view, for views not reachable from the
+// fixtures by navigation
+func (h *harness) open(view func(sess *session) (tea.Model, tea.Cmd)) {
+	var cmd tea.Cmd
+	h.model, cmd = view(h.sess)
+	h.run(cmd)
+}
+
+// requests returns the calls received with method on path, oldest first
+func (h *harness) requests(method, path string) []request {
+	h.rec.mu.Lock()
+	defer h.rec.mu.Unlock()
+	var found []request
+	for _, r := range h.rec.requests {
+		if r.Method == method && r.Path == path {
+			found = append(found, r)
+		}
+	}
+	return found
+}
+
+// request returns the last call received with method on path
+func (h *harness) request(method, path string) request {
+	h.t.Helper()
+	found := h.requests(method, path)
+	if len(found) == 0 {
+		h.t.Fatalf("no %s %s received", method, path)
+	}
+	return found[len(found)-1]
+}
+
+// graphql returns the variables of the calls of a GraphQL operation,
+// oldest first, each in JSON with sorted keys
+func (h *harness) graphql(operation string) []string {
+	var found []string
+	for _, r := range h.requests(http.MethodPost, "/graphql") {
+		if r.Body["operationName"] == operation {
+			data, _ := json.Marshal(r.Body["variables"])
+			found = append(found, string(data))
+		}
+	}
+	return found
+}
+
+// json returns the body in JSON with sorted keys, to be compared with a
+// literal
+func (r request) json() string {
+	data, _ := json.Marshal(r.Body)
+	return string(data)
+}
+
+func (h *harness) keys(keys ...string) {
+	for _, k := range keys {
+		h.send(keyMsg(k))
+	}
+}
+
+func keyMsg(k string) tea.KeyMsg {
+	switch k {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "backspace":
+		return tea.KeyMsg{Type: tea.KeyBackspace}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	case "shift+tab":
+		return tea.KeyMsg{Type: tea.KeyShiftTab}
+	case "up":
+		return tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "left":
+		return tea.KeyMsg{Type: tea.KeyLeft}
+	case "right":
+		return tea.KeyMsg{Type: tea.KeyRight}
+	case "ctrl+s":
+		return tea.KeyMsg{Type: tea.KeyCtrlS}
+	case "ctrl+o":
+		return tea.KeyMsg{Type: tea.KeyCtrlO}
+	case " ":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+// snapshot compares the current View() with testdata/golden/<name>.golden
+func (h *harness) snapshot(name string) {
+	h.t.Helper()
+	got := h.model.View()
+	path := filepath.Join("testdata", "golden", name+".golden")
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			h.t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			h.t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		h.t.Fatalf("reading golden file (run with -update to create it): %v", err)
+	}
+	if got != string(want) {
+		h.t.Errorf("view %s does not match golden file %s\n--- got ---\n%s\n--- want ---\n%s", name, path, got, want)
+	}
+}
@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+// helpColumnSize is the number of bindings listed per help column
+const helpColumnSize = 8
+
+// renderHelp renders the help overlay listing the given bindings
+func renderHelp(bindings []key.Binding) string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.Current.OnPrimary).
+		Background(theme.Current.Primary).
+		Padding(0, 2)
+	keyStyle := lipgloss.NewStyle().
+		Foreground(theme.Current.Accent).
+		Bold(true)
+	descStyle := lipgloss.NewStyle().
+		Foreground(theme.Current.Text)
+	instrStyle := lipgloss.NewStyle().
+		Foreground(theme.Current.Subtle).
+		Italic(true)
+
+	// Split the bindings into columns of keys and descriptions
+	var columns []string
+	for start := 0; start < len(bindings); start += helpColumnSize {
+		end := min(start+helpColumnSize, len(bindings))
+		var keys, descs []string
+		for _, b := range bindings[start:end] {
+			if !b.Enabled() {
+				continue
+			}
+			keys = append(keys, keyStyle.Render(b.Help().Key))
+			descs = append(descs, descStyle.Render(b.Help().Desc))
+		}
+		columns = append(columns, lipgloss.JoinHorizontal(lipgloss.Top,
+			strings.Join(keys, "\n"), "  ", strings.Join(descs, "\n"), "    "))
+	}
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Key Bindings"))
+	content.WriteString("\n\n")
+	content.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, columns...))
+	content.WriteString("\n\n")
+	content.WriteString(instrStyle.Render("Press any key to close"))
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Current.Primary).
+		Padding(1, 2).
+		Render(content.String())
+
+	return lipgloss.Place(
+		constants.WindowSize.Width,
+		constants.WindowSize.Height,
+		lipgloss.Center,
+		lipgloss.Center,
+		box,
+		lipgloss.WithWhitespaceChars(" "),
+	)
+}
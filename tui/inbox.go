@@ -0,0 +1,409 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+	"github.com/jjournet/tgr/config"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+// ciTitle matches the titles of CI notifications, such as
+// "CI workflow run failed for main branch"
+var ciTitle = regexp.MustCompile(`^(.+) workflow run \w+ for (.+) branch$`)
+
+// notificationActions describes the notification actions in error messages
+var notificationActions = map[string]string{
+	"read":        "mark as read",
+	"done":        "mark as done",
+	"unsubscribe": "unsubscribe",
+}
+
+// subjectTypes shortens the notification subject types for the table
+var subjectTypes = map[string]string{
+	"Issue":       "Issue",
+	"PullRequest": "PR",
+	"CheckSuite":  "CI",
+	"WorkflowRun": "CI",
+	"Release":     "Release",
+	"Discussion":  "Discussion",
+	"Commit":      "Commit",
+}
+
+type inboxView struct {
+	commonElements
+
+	// Service
+	sess *session
+
+	// State
+	notifications []github.NotificationInfo
+	showAll       bool   // include read notifications
+	reason        string // only show this reason when set
+	repoFilter    string
+	status        string // result of the last action
+	loading       bool
+	err           error
+
+	// UI
+	EltList        table.Model
+	visibleCommand bool
+	keys           KeyMap
+}
+
+func (m *inboxView) resizeMain(w int, h int) {
+	headerHeight := lipgloss.Height(m.RenderTopFields())
+	footerHeight := lipgloss.Height(m.RenderBottomFields())
+	cmdHeight := 0
+	if m.visibleCommand {
+		cmdHeight = 3
+	}
+	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2 - cmdHeight)
+	m.EltList = m.EltList.WithPageSize(h - headerHeight - footerHeight - 3 - cmdHeight)
+	constants.CommandStyle = constants.CommandStyle.Width(w - 2).Height(1)
+}
+
+// NewInbox creates a view listing the GitHub notifications of the user
+func NewInbox(sess *session) (tea.Model, tea.Cmd) {
+	m := &inboxView{
+		sess:    sess,
+		loading: true,
+		keys:    keyMapFor(viewInbox),
+	}
+	m.keys.Select = withDesc(m.keys.Select, "Open")
+	m.keys.Filter = withDesc(m.keys.Filter, "Filter repo")
+
+	m.InitTop("Inbox")
+	m.TopFields = []string{"Inbox", "Loading..."}
+	m.InitBottom()
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Select, m.keys.MarkRead, m.keys.MarkDone, m.keys.Reason, m.keys.ShowAll, m.keys.Help)
+	m.CommandInput = textinput.New()
+
+	return m, loadNotificationsCmd(sess, false)
+}
+
+func (m *inboxView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *inboxView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case notificationsLoadedMsg:
+		m.loading = false
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		m.notifications = msg.Notifications
+		m.rebuild()
+		return m, nil
+
+	case notificationActionMsg:
+		i := slices.IndexFunc(m.notifications, func(n github.NotificationInfo) bool { return n.ID == msg.ID })
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Could not %s: %v", notificationActions[msg.Action], msg.Err)
+		} else if i >= 0 {
+			switch msg.Action {
+			case "read":
+				m.markRead(msg.ID)
+			case "done":
+				m.status = "Done: " + m.notifications[i].Title
+				m.notifications = slices.Delete(m.notifications, i, i+1)
+			case "unsubscribe":
+				m.status = "Unsubscribed from " + m.notifications[i].Title
+			}
+		}
+		m.rebuild()
+		return m, nil
+
+	case issueLoadedMsg:
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Opening issue failed: %v", msg.Err)
+			m.rebuild()
+			return m, nil
+		}
+		return NewIssueDetail(m.sess, msg.Owner, msg.RepoName, *msg.Issue, m)
+
+	case notificationRunFoundMsg:
+		if msg.Err != nil || msg.Run == nil {
+			// Without a matching run, fall back to the repository
+			return NewRepoView(m.sess, msg.Owner, msg.RepoName)
+		}
+		return NewWorkflowRunWatch(m.sess, msg.Owner, msg.RepoName, msg.Run.WorkflowID, msg.Run.ID, m)
+
+	case tea.WindowSizeMsg:
+		constants.WindowSize = msg
+		m.resizeMain(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading {
+			if isQuit(msg, m.keys) {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		if m.visibleCommand {
+			return m.handleFilterInput(msg)
+		}
+
+		switch {
+		case isQuit(msg, m.keys):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return NewDashboard(m.sess)
+		case key.Matches(msg, m.keys.Refresh):
+			m.loading = true
+			return m, loadNotificationsCmd(m.sess, m.showAll)
+		case key.Matches(msg, m.keys.ShowAll):
+			m.showAll = !m.showAll
+			m.loading = true
+			return m, loadNotificationsCmd(m.sess, m.showAll)
+		case key.Matches(msg, m.keys.Reason):
+			m.reason = m.nextReason()
+			m.rebuild()
+			return m, nil
+		case key.Matches(msg, m.keys.Filter):
+			m.visibleCommand = true
+			m.CommandInput.SetValue(m.repoFilter)
+			m.CommandInput.Focus()
+			m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+			return m, nil
+		}
+
+		n, ok := m.highlighted()
+		if !ok {
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, m.keys.Select):
+			// Opening a thread reads it, as on github.com. The answer may
+			// reach the opened view, so the inbox is updated right away.
+			m.markRead(n.ID)
+			return m, tea.Batch(notificationActionCmd(m.sess, n.ID, "read"), m.open(n))
+		case key.Matches(msg, m.keys.MarkRead):
+			return m, notificationActionCmd(m.sess, n.ID, "read")
+		case key.Matches(msg, m.keys.MarkDone):
+			return m, notificationActionCmd(m.sess, n.ID, "done")
+		case key.Matches(msg, m.keys.Unsubscribe):
+			return m, notificationActionCmd(m.sess, n.ID, "unsubscribe")
+		}
+	}
+
+	var cmd tea.Cmd
+	m.EltList, cmd = m.EltList.Update(msg)
+	return m, cmd
+}
+
+func (m *inboxView) markRead(id string) {
+	for i := range m.notifications {
+		if m.notifications[i].ID == id {
+			m.notifications[i].Unread = false
+		}
+	}
+	m.rebuild()
+}
+
+// open returns the command loading the view matching a notification
+func (m *inboxView) open(n github.NotificationInfo) tea.Cmd {
+	owner, repoName, ok := config.SplitRepo(n.Repo)
+	if !ok {
+		return nil
+	}
+	switch {
+	case (n.Type == "Issue" || n.Type == "PullRequest") && n.Number > 0:
+		return loadIssueCmd(m.sess, owner, repoName, n.Number)
+	case ciTitle.MatchString(n.Title):
+		match := ciTitle.FindStringSubmatch(n.Title)
+		return findNotificationRunCmd(m.sess, owner, repoName, match[1], match[2])
+	default:
+		return func() tea.Msg {
+			return notificationRunFoundMsg{Owner: owner, RepoName: repoName}
+		}
+	}
+}
+
+func (m *inboxView) handleFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "esc":
+		m.visibleCommand = false
+		m.CommandInput.Blur()
+		m.repoFilter = m.CommandInput.Value()
+		if msg.String() == "esc" {
+			m.repoFilter = ""
+		}
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+		m.rebuild()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.CommandInput, cmd = m.CommandInput.Update(msg)
+	return m, cmd
+}
+
+// nextReason cycles through the reasons of the loaded notifications,
+// then back to every reason
+func (m *inboxView) nextReason() string {
+	var reasons []string
+	for _, n := range m.notifications {
+		if !slices.Contains(reasons, n.Reason) {
+			reasons = append(reasons, n.Reason)
+		}
+	}
+	slices.Sort(reasons)
+
+	i := slices.Index(reasons, m.reason)
+	if i+1 < len(reasons) {
+		return reasons[i+1]
+	}
+	return ""
+}
+
+// visible returns the notifications matching the reason and repository filters
+func (m *inboxView) visible() []github.NotificationInfo {
+	var visible []github.NotificationInfo
+	for _, n := range m.notifications {
+		if m.reason != "" && n.Reason != m.reason {
+			continue
+		}
+		if m.repoFilter != "" && !strings.Contains(strings.ToLower(n.Repo), strings.ToLower(m.repoFilter)) {
+			continue
+		}
+		visible = append(visible, n)
+	}
+	return visible
+}
+
+func (m *inboxView) highlighted() (github.NotificationInfo, bool) {
+	if len(m.EltList.GetVisibleRows()) == 0 {
+		return github.NotificationInfo{}, false
+	}
+	n, ok := m.EltList.HighlightedRow().Data["notification"].(github.NotificationInfo)
+	return n, ok
+}
+
+// rebuild refreshes the header and the table after the list or the
+// filters changed
+func (m *inboxView) rebuild() {
+	visible := m.visible()
+
+	unread := 0
+	for _, n := range visible {
+		if n.Unread {
+			unread++
+		}
+	}
+	summary := fmt.Sprintf("(%d notifications, %d unread)", len(visible), unread)
+	if m.reason != "" {
+		summary += " reason: " + m.reason
+	}
+	if m.repoFilter != "" {
+		summary += " repo: " + m.repoFilter
+	}
+	m.TopFields = []string{"Inbox", summary}
+	if m.status != "" {
+		m.TopFields = append(m.TopFields, m.status)
+	}
+
+	m.EltList = m.buildInboxTable(visible, m.EltList.GetHighlightedRowIndex())
+	if constants.WindowSize.Height != 0 {
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	}
+}
+
+func (m *inboxView) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit or 'backspace' to go back", m.err)
+	}
+
+	if m.loading {
+		return m.RenderTopFields() + "\n\nLoading notifications..."
+	}
+
+	for i, row := range m.EltList.GetVisibleRows() {
+		row.Data["arrow"] = ""
+		if i == m.EltList.GetHighlightedRowIndex() {
+			row.Data["arrow"] = theme.Icons.Arrow
+		}
+	}
+
+	if m.visibleCommand {
+		return fmt.Sprintf(
+			"%s\n%s\n%s\n%s",
+			m.RenderTopFields(),
+			constants.CommandStyle.BorderForeground(theme.Current.Accent).Render(m.CommandInput.View()),
+			constants.MainStyle.Render(m.EltList.View()),
+			m.RenderBottomFields(),
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s",
+		m.RenderTopFields(),
+		constants.MainStyle.Render(m.EltList.View()),
+		m.RenderBottomFields(),
+	)
+}
+
+func (m *inboxView) buildInboxTable(notifications []github.NotificationInfo, highlighted int) table.Model {
+	columns := []table.Column{
+		table.NewColumn("arrow", " ", 3),
+		table.NewColumn("unread", " ", 3),
+		table.NewColumn("type", "Type", 8),
+		table.NewColumn("repo", "Repository", 20),
+		table.NewColumn("title", "Title", 45),
+		table.NewColumn("reason", "Reason", 18),
+		table.NewColumn("updated", "Updated", 18),
+	}
+
+	rows := []table.Row{}
+	for _, n := range notifications {
+		unread := ""
+		if n.Unread {
+			unread = theme.Icons.Unread
+		}
+		kind, ok := subjectTypes[n.Type]
+		if !ok {
+			kind = n.Type
+		}
+		rows = append(rows, table.NewRow(table.RowData{
+			"arrow":        "",
+			"unread":       table.NewStyledCell(unread, lipgloss.NewStyle().Foreground(theme.Current.Link)),
+			"type":         kind,
+			"repo":         n.Repo,
+			"title":        n.Title,
+			"reason":       n.Reason,
+			"updated":      n.UpdatedAt.Format("2006-01-02 15:04"),
+			"notification": n,
+		}))
+	}
+
+	return table.New(columns).WithRows(rows).
+		WithKeyMap(m.keys.tableKeyMap()).
+		Focused(true).
+		Border(table.Border{}).
+		WithBaseStyle(constants.BaseTableStyle).
+		HighlightStyle(constants.HighlightedLineStyle).
+		WithFooterVisibility(false).
+		WithHighlightedRow(min(highlighted, max(len(rows)-1, 0)))
+}
+
+func (m *inboxView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Select, m.keys.MarkRead, m.keys.MarkDone, m.keys.Unsubscribe, m.keys.Reason, m.keys.ShowAll, m.keys.Filter, m.keys.Refresh, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
+
+func (m *inboxView) capturingInput() bool {
+	return m.visibleCommand
+}
@@ -4,22 +4,30 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/jjournet/tgr/github"
 	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
 )
 
 type issueDetailView struct {
 	commonElements
 
 	// Service
-	ghService *github.GitHubService
+	sess *session
 
 	// Context
 	owner    string
 	repoName string
 	issue    github.IssueInfo
+
+	// UI
+	keys KeyMap
+
+	// Navigation
+	parentView tea.Model // view to return to, the issue list when nil
 }
 
 func (m *issueDetailView) resizeMain(w int, h int) {
@@ -28,19 +36,22 @@ func (m *issueDetailView) resizeMain(w int, h int) {
 	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2)
 }
 
-// NewIssueDetail creates a new issue detail view model
-func NewIssueDetail(ghService *github.GitHubService, owner, repoName string, issue github.IssueInfo) (tea.Model, tea.Cmd) {
+// NewIssueDetail creates a new issue detail view model. Back returns to
+// parentView, or to the issues of the repository when it is nil.
+func NewIssueDetail(sess *session, owner, repoName string, issue github.IssueInfo, parentView tea.Model) (tea.Model, tea.Cmd) {
 	m := &issueDetailView{
-		ghService: ghService,
-		owner:     owner,
-		repoName:  repoName,
-		issue:     issue,
+		sess:       sess,
+		owner:      owner,
+		repoName:   repoName,
+		issue:      issue,
+		parentView: parentView,
+		keys:       keyMapFor(viewIssueDetail),
 	}
 
 	m.InitTop(owner, repoName, fmt.Sprintf("Issue #%d", issue.Number))
 	m.TopFields = []string{owner, repoName, fmt.Sprintf("Issue #%d", issue.Number)}
 	m.InitBottom()
-	m.BottomFields = []string{"(q) Quit", "(backspace) Back"}
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Back, m.keys.Help)
 
 	if constants.WindowSize.Height != 0 {
 		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
@@ -62,11 +73,14 @@ func (m *issueDetailView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
+		switch {
+		case isQuit(msg, m.keys):
 			return m, tea.Quit
-		case "backspace":
-			return NewIssueList(m.ghService, m.owner, m.repoName)
+		case key.Matches(msg, m.keys.Back):
+			if m.parentView != nil {
+				return m.parentView, m.parentView.Init()
+			}
+			return NewIssueList(m.sess, m.owner, m.repoName)
 		}
 	}
 
@@ -77,14 +91,8 @@ func (m *issueDetailView) View() string {
 	var content strings.Builder
 
 	// State indicator and title
-	stateColor := lipgloss.Color("#22EE82")
-	stateIcon := "\uf468"
-	stateText := "OPEN"
-	if m.issue.State == "closed" {
-		stateColor = lipgloss.Color("#b19cd9")
-		stateIcon = "\uf46a"
-		stateText = "CLOSED"
-	}
+	stateIcon, stateColor := theme.IssueState(m.issue.State)
+	stateText := strings.ToUpper(m.issue.State)
 
 	stateStyle := lipgloss.NewStyle().
 		Foreground(stateColor).
@@ -92,7 +100,7 @@ func (m *issueDetailView) View() string {
 
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#FFFFFF"))
+		Foreground(theme.Current.Emphasis)
 
 	content.WriteString(stateStyle.Render(fmt.Sprintf("%s %s", stateIcon, stateText)))
 	content.WriteString("  ")
@@ -101,7 +109,7 @@ func (m *issueDetailView) View() string {
 
 	// Metadata
 	metadataStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#8B949E"))
+		Foreground(theme.Current.Muted)
 
 	content.WriteString(metadataStyle.Render(fmt.Sprintf("Author: %s", m.issue.Author)))
 	content.WriteString("\n")
@@ -117,7 +125,7 @@ func (m *issueDetailView) View() string {
 		content.WriteString("\n")
 		content.WriteString(metadataStyle.Render("Labels: "))
 		labelStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#58A6FF")).
+			Foreground(theme.Current.Link).
 			Bold(true)
 		content.WriteString(labelStyle.Render(strings.Join(m.issue.Labels, ", ")))
 		content.WriteString("\n")
@@ -125,17 +133,17 @@ func (m *issueDetailView) View() string {
 
 	// Separator
 	content.WriteString("\n")
-	content.WriteString(strings.Repeat("─", constants.WindowSize.Width-4))
+	content.WriteString(strings.Repeat(theme.Icons.Separator, constants.WindowSize.Width-4))
 	content.WriteString("\n\n")
 
 	// Body
 	if m.issue.Body != "" {
 		bodyStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#C9D1D9"))
+			Foreground(theme.Current.Text)
 		content.WriteString(bodyStyle.Render(m.issue.Body))
 	} else {
 		emptyStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#8B949E")).
+			Foreground(theme.Current.Muted).
 			Italic(true)
 		content.WriteString(emptyStyle.Render("No description provided."))
 	}
@@ -147,3 +155,7 @@ func (m *issueDetailView) View() string {
 		m.RenderBottomFields(),
 	)
 }
+
+func (m *issueDetailView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Back, m.keys.Quit}
+}
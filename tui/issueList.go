@@ -4,18 +4,20 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/evertras/bubble-table/table"
 	"github.com/jjournet/tgr/github"
 	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
 )
 
 type issueListView struct {
 	commonElements
 
 	// Service
-	ghService *github.GitHubService
+	sess *session
 
 	// Context
 	owner    string
@@ -28,6 +30,7 @@ type issueListView struct {
 
 	// UI
 	EltList table.Model
+	keys    KeyMap
 }
 
 func (m *issueListView) resizeMain(w int, h int) {
@@ -37,21 +40,22 @@ func (m *issueListView) resizeMain(w int, h int) {
 }
 
 // NewIssueList creates a new issue list view model
-func NewIssueList(ghService *github.GitHubService, owner, repoName string) (tea.Model, tea.Cmd) {
+func NewIssueList(sess *session, owner, repoName string) (tea.Model, tea.Cmd) {
 	m := &issueListView{
-		ghService: ghService,
-		owner:     owner,
-		repoName:  repoName,
-		loading:   true,
+		sess:     sess,
+		owner:    owner,
+		repoName: repoName,
+		loading:  true,
+		keys:     keyMapFor(viewIssueList),
 	}
 
 	m.InitTop(owner, repoName, "Loading issues...")
 	m.TopFields = []string{owner, repoName, "Issue List"}
 	m.InitBottom()
-	m.BottomFields = []string{"(q) Quit", "(enter) Select", "(backspace) Back"}
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Select, m.keys.Back, m.keys.Help)
 
 	// Load issues asynchronously
-	return m, ghService.LoadIssuesCmd(owner, repoName)
+	return m, loadIssuesCmd(sess, owner, repoName)
 }
 
 func (m *issueListView) Init() tea.Cmd {
@@ -61,7 +65,7 @@ func (m *issueListView) Init() tea.Cmd {
 func (m *issueListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
-	case github.IssuesLoadedMsg:
+	case issuesLoadedMsg:
 		if msg.Err != nil {
 			m.err = msg.Err
 			m.loading = false
@@ -88,18 +92,18 @@ func (m *issueListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		if m.loading {
-			if msg.String() == "q" || msg.String() == "ctrl+c" {
+			if isQuit(msg, m.keys) {
 				return m, tea.Quit
 			}
 			return m, nil
 		}
 
-		switch msg.String() {
-		case "q", "ctrl+c":
+		switch {
+		case isQuit(msg, m.keys):
 			return m, tea.Quit
-		case "backspace":
-			return NewRepoView(m.ghService, m.owner, m.repoName)
-		case "enter":
+		case key.Matches(msg, m.keys.Back):
+			return NewRepoView(m.sess, m.owner, m.repoName)
+		case key.Matches(msg, m.keys.Select):
 			// Get the selected issue
 			row := m.EltList.HighlightedRow()
 			issueNumber := row.Data["number"].(int)
@@ -107,7 +111,7 @@ func (m *issueListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Find the issue in our list
 			for _, issue := range m.issues {
 				if issue.Number == issueNumber {
-					return NewIssueDetail(m.ghService, m.owner, m.repoName, issue)
+					return NewIssueDetail(m.sess, m.owner, m.repoName, issue, nil)
 				}
 			}
 		}
@@ -134,7 +138,7 @@ func (m *issueListView) View() string {
 	for i, row := range m.EltList.GetVisibleRows() {
 		row.Data["arrow"] = ""
 		if i == m.EltList.GetHighlightedRowIndex() {
-			row.Data["arrow"] = "\uf0a9"
+			row.Data["arrow"] = theme.Icons.Arrow
 		}
 	}
 
@@ -164,6 +168,7 @@ func (m *issueListView) buildIssueListModel() table.Model {
 	}
 
 	return table.New(columns).WithRows(rows).
+		WithKeyMap(m.keys.tableKeyMap()).
 		Focused(true).
 		Border(table.Border{}).
 		WithBaseStyle(constants.BaseTableStyle).
@@ -174,17 +179,7 @@ func (m *issueListView) buildIssueListModel() table.Model {
 }
 
 func makeIssueRow(issue github.IssueInfo) table.Row {
-	indicator := "\uf128"
-	color := lipgloss.Color("#77c2f9")
-
-	switch issue.State {
-	case "open":
-		indicator = "\uf468"
-		color = lipgloss.Color("#22EE82")
-	case "closed":
-		indicator = "\uf46a"
-		color = lipgloss.Color("#b19cd9")
-	}
+	indicator, color := theme.IssueState(issue.State)
 
 	labels := strings.Join(issue.Labels, ", ")
 	if len(labels) > 30 {
@@ -202,3 +197,7 @@ func makeIssueRow(issue github.IssueInfo) table.Row {
 		"labels":    labels,
 	})
 }
+
+func (m *issueListView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Select, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
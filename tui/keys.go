@@ -0,0 +1,445 @@
+package tui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/evertras/bubble-table/table"
+	"github.com/jjournet/tgr/config"
+)
+
+// KeyMap holds the key bindings used by the views
+type KeyMap struct {
+	Up        key.Binding
+	Down      key.Binding
+	PageUp    key.Binding
+	PageDown  key.Binding
+	Select    key.Binding
+	Back      key.Binding
+	Quit      key.Binding
+	Filter    key.Binding
+	Watch     key.Binding
+	Trigger   key.Binding
+	Refresh   key.Binding
+	Help      key.Binding
+	Cancel    key.Binding
+	NextField key.Binding
+	PrevField key.Binding
+	Pin       key.Binding
+	Monitor   key.Binding
+
+	// Inbox
+	Inbox       key.Binding
+	MarkRead    key.Binding
+	MarkDone    key.Binding
+	Unsubscribe key.Binding
+	ShowAll     key.Binding
+	Reason      key.Binding
+
+	// Branches
+	Create  key.Binding
+	Delete  key.Binding
+	Compare key.Binding
+
+	// Deployments
+	Approve key.Binding
+	Reject  key.Binding
+
+	// Variables and secrets
+	Scope   key.Binding
+	Secrets key.Binding
+
+	// Projects
+	Layout    key.Binding
+	Group     key.Binding
+	MoveLeft  key.Binding
+	MoveRight key.Binding
+	Edit      key.Binding
+
+	// Releases
+	Publish key.Binding
+	Upload  key.Binding
+
+	// Artifacts
+	Artifacts key.Binding
+	Download  key.Binding
+
+	// Caches
+	Sort  key.Binding
+	Mark  key.Binding
+	Purge key.Binding
+
+	// Workflows
+	Enable    key.Binding
+	Source    key.Binding
+	Analytics key.Binding
+
+	// Dispatch presets
+	SavePreset key.Binding
+	Presets    key.Binding
+
+	// Run filters
+	OnlyMine     key.Binding
+	OnlyFailures key.Binding
+}
+
+// View names used for per-view key overrides in the config file
+const (
+	viewDashboard         = "dashboard"
+	viewProfileSelection  = "profile_selection"
+	viewRepoSelection     = "repo_selection"
+	viewRepoSummary       = "repo_summary"
+	viewWorkflowList      = "workflow_list"
+	viewWorkflowRunList   = "workflow_run_list"
+	viewWorkflowRunDetail = "workflow_run_detail"
+	viewWorkflowRunWatch  = "workflow_run_watch"
+	viewWorkflowInputForm = "workflow_input_form"
+	viewIssueList         = "issue_list"
+	viewIssueDetail       = "issue_detail"
+	viewRunMonitor        = "run_monitor"
+	viewInbox             = "inbox"
+	viewBranchList        = "branch_list"
+	viewCompare           = "compare"
+	viewCommitList        = "commit_list"
+	viewCommitDetail      = "commit_detail"
+	viewEnvironmentList   = "environment_list"
+	viewVariableList      = "variable_list"
+	viewProjectList       = "project_list"
+	viewProjectBoard      = "project_board"
+	viewReleaseList       = "release_list"
+	viewReleaseNotes      = "release_notes"
+	viewReleaseForm       = "release_form"
+	viewArtifactList      = "artifact_list"
+	viewArtifactPreview   = "artifact_preview"
+	viewCacheList         = "cache_list"
+	viewRunnerList        = "runner_list"
+	viewWorkflowSource    = "workflow_source"
+	viewRunFilterForm     = "run_filter_form"
+	viewWorkflowAnalytics = "workflow_analytics"
+)
+
+var viewNames = []string{
+	viewDashboard,
+	viewProfileSelection,
+	viewRepoSelection,
+	viewRepoSummary,
+	viewWorkflowList,
+	viewWorkflowRunList,
+	viewWorkflowRunDetail,
+	viewWorkflowRunWatch,
+	viewWorkflowInputForm,
+	viewIssueList,
+	viewIssueDetail,
+	viewRunMonitor,
+	viewInbox,
+	viewBranchList,
+	viewCompare,
+	viewCommitList,
+	viewCommitDetail,
+	viewEnvironmentList,
+	viewVariableList,
+	viewProjectList,
+	viewProjectBoard,
+	viewReleaseList,
+	viewReleaseNotes,
+	viewReleaseForm,
+	viewArtifactList,
+	viewArtifactPreview,
+	viewCacheList,
+	viewRunnerList,
+	viewWorkflowSource,
+	viewRunFilterForm,
+	viewWorkflowAnalytics,
+}
+
+// bindingDef describes a configurable binding: its config name, where it
+// lives in the KeyMap and its default help text
+type bindingDef struct {
+	name  string
+	desc  string
+	field func(*KeyMap) *key.Binding
+}
+
+var bindingDefs = []bindingDef{
+	{"up", "Up", func(k *KeyMap) *key.Binding { return &k.Up }},
+	{"down", "Down", func(k *KeyMap) *key.Binding { return &k.Down }},
+	{"page_up", "Previous page", func(k *KeyMap) *key.Binding { return &k.PageUp }},
+	{"page_down", "Next page", func(k *KeyMap) *key.Binding { return &k.PageDown }},
+	{"select", "Select", func(k *KeyMap) *key.Binding { return &k.Select }},
+	{"back", "Back", func(k *KeyMap) *key.Binding { return &k.Back }},
+	{"quit", "Quit", func(k *KeyMap) *key.Binding { return &k.Quit }},
+	{"filter", "Filter", func(k *KeyMap) *key.Binding { return &k.Filter }},
+	{"watch", "Watch", func(k *KeyMap) *key.Binding { return &k.Watch }},
+	{"trigger", "Trigger", func(k *KeyMap) *key.Binding { return &k.Trigger }},
+	{"refresh", "Refresh", func(k *KeyMap) *key.Binding { return &k.Refresh }},
+	{"help", "Help", func(k *KeyMap) *key.Binding { return &k.Help }},
+	{"cancel", "Cancel", func(k *KeyMap) *key.Binding { return &k.Cancel }},
+	{"next_field", "Next field", func(k *KeyMap) *key.Binding { return &k.NextField }},
+	{"prev_field", "Previous field", func(k *KeyMap) *key.Binding { return &k.PrevField }},
+	{"pin", "Pin/Unpin", func(k *KeyMap) *key.Binding { return &k.Pin }},
+	{"monitor", "Run monitor", func(k *KeyMap) *key.Binding { return &k.Monitor }},
+	{"inbox", "Inbox", func(k *KeyMap) *key.Binding { return &k.Inbox }},
+	{"mark_read", "Mark read", func(k *KeyMap) *key.Binding { return &k.MarkRead }},
+	{"mark_done", "Done", func(k *KeyMap) *key.Binding { return &k.MarkDone }},
+	{"unsubscribe", "Unsubscribe", func(k *KeyMap) *key.Binding { return &k.Unsubscribe }},
+	{"show_all", "Unread/All", func(k *KeyMap) *key.Binding { return &k.ShowAll }},
+	{"reason", "Next reason", func(k *KeyMap) *key.Binding { return &k.Reason }},
+	{"create", "New", func(k *KeyMap) *key.Binding { return &k.Create }},
+	{"delete", "Delete", func(k *KeyMap) *key.Binding { return &k.Delete }},
+	{"compare", "Compare", func(k *KeyMap) *key.Binding { return &k.Compare }},
+	{"approve", "Approve", func(k *KeyMap) *key.Binding { return &k.Approve }},
+	{"reject", "Reject", func(k *KeyMap) *key.Binding { return &k.Reject }},
+	{"scope", "Next scope", func(k *KeyMap) *key.Binding { return &k.Scope }},
+	{"secrets", "Variables/Secrets", func(k *KeyMap) *key.Binding { return &k.Secrets }},
+	{"layout", "Board/Table", func(k *KeyMap) *key.Binding { return &k.Layout }},
+	{"group", "Group by", func(k *KeyMap) *key.Binding { return &k.Group }},
+	{"move_left", "Move left", func(k *KeyMap) *key.Binding { return &k.MoveLeft }},
+	{"move_right", "Move right", func(k *KeyMap) *key.Binding { return &k.MoveRight }},
+	{"edit", "Edit field", func(k *KeyMap) *key.Binding { return &k.Edit }},
+	{"publish", "Publish", func(k *KeyMap) *key.Binding { return &k.Publish }},
+	{"upload", "Upload asset", func(k *KeyMap) *key.Binding { return &k.Upload }},
+	{"artifacts", "Artifacts", func(k *KeyMap) *key.Binding { return &k.Artifacts }},
+	{"download", "Download", func(k *KeyMap) *key.Binding { return &k.Download }},
+	{"sort", "Sort", func(k *KeyMap) *key.Binding { return &k.Sort }},
+	{"mark", "Select", func(k *KeyMap) *key.Binding { return &k.Mark }},
+	{"purge", "Delete all of ref", func(k *KeyMap) *key.Binding { return &k.Purge }},
+	{"enable", "Enable/disable", func(k *KeyMap) *key.Binding { return &k.Enable }},
+	{"source", "View source", func(k *KeyMap) *key.Binding { return &k.Source }},
+	{"analytics", "Analytics", func(k *KeyMap) *key.Binding { return &k.Analytics }},
+	{"save_preset", "Save preset", func(k *KeyMap) *key.Binding { return &k.SavePreset }},
+	{"presets", "Presets", func(k *KeyMap) *key.Binding { return &k.Presets }},
+	{"only_mine", "Only mine", func(k *KeyMap) *key.Binding { return &k.OnlyMine }},
+	{"only_failures", "Only failures", func(k *KeyMap) *key.Binding { return &k.OnlyFailures }},
+}
+
+// keyPresets maps a preset name to its keys. Presets other than
+// "default" only list the bindings they change.
+var keyPresets = map[string]map[string][]string{
+	"default": {
+		"up":         {"up", "k"},
+		"down":       {"down", "j"},
+		"page_up":    {"pgup", "left", "h"},
+		"page_down":  {"pgdown", "right", "l"},
+		"select":     {"enter"},
+		"back":       {"backspace"},
+		"quit":       {"q"},
+		"filter":     {"/"},
+		"watch":      {"w"},
+		"trigger":    {"t", "T"},
+		"refresh":    {"r"},
+		"help":       {"?"},
+		"cancel":     {"esc"},
+		"next_field": {"tab", "down"},
+		"prev_field": {"shift+tab", "up"},
+		"pin":        {"p"},
+		"monitor":    {"m"},
+		// Inbox keys follow the GitHub web shortcuts where there is one
+		"inbox":       {"i"},
+		"mark_read":   {"I"},
+		"mark_done":   {"e"},
+		"unsubscribe": {"M"},
+		"show_all":    {"a"},
+		"reason":      {"f"},
+		"create":      {"n"},
+		"delete":      {"D"},
+		"compare":     {"c"},
+		"approve":     {"a"},
+		"reject":      {"x"},
+		"scope":       {"s"},
+		"secrets":     {"S"},
+		"layout":      {"v"},
+		"group":       {"g"},
+		"move_left":   {"<"},
+		"move_right":  {">"},
+		"edit":        {"e"},
+		"publish":     {"P"},
+		"upload":      {"u"},
+		"artifacts":   {"a"},
+		"download":    {"d"},
+		"sort":        {"o"},
+		"mark":        {"x"},
+		"purge":       {"X"},
+		"enable":      {"E"},
+		"source":      {"y"},
+		"analytics":   {"A"},
+		// The trigger form types printable keys, so presets use ctrl
+		"save_preset":   {"ctrl+s"},
+		"presets":       {"ctrl+o"},
+		"only_mine":     {"m"},
+		"only_failures": {"f"},
+	},
+	"vim": {
+		"page_up":    {"ctrl+b", "pgup", "left"},
+		"page_down":  {"ctrl+f", "pgdown", "right"},
+		"select":     {"enter", "l"},
+		"back":       {"backspace", "h"},
+		"next_field": {"tab", "ctrl+n"},
+		"prev_field": {"shift+tab", "ctrl+p"},
+	},
+	"emacs": {
+		"up":         {"up", "ctrl+p"},
+		"down":       {"down", "ctrl+n"},
+		"page_up":    {"pgup", "alt+v"},
+		"page_down":  {"pgdown", "ctrl+v"},
+		"back":       {"backspace", "ctrl+g"},
+		"next_field": {"tab", "ctrl+n"},
+		"prev_field": {"shift+tab", "ctrl+p"},
+	},
+}
+
+// forceQuitKey always exits, whatever the configuration
+var forceQuitKey = key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "Force quit"))
+
+// keyConfig is the user's key configuration, applied by keyMapFor
+var keyConfig config.KeysConfig
+
+// LoadKeyMap validates and installs the key configuration used by every view
+func LoadKeyMap(cfg config.KeysConfig) error {
+	preset := cfg.Preset
+	if preset == "" {
+		preset = "default"
+	}
+	if _, ok := keyPresets[preset]; !ok {
+		return fmt.Errorf("keys: unknown preset %q (expected one of %s)", preset, strings.Join(presetNames(), ", "))
+	}
+	if err := checkBindingNames(cfg.Bindings); err != nil {
+		return fmt.Errorf("keys.bindings: %w", err)
+	}
+	for view, bindings := range cfg.Views {
+		if !slices.Contains(viewNames, view) {
+			return fmt.Errorf("keys.views: unknown view %q (expected one of %s)", view, strings.Join(viewNames, ", "))
+		}
+		if err := checkBindingNames(bindings); err != nil {
+			return fmt.Errorf("keys.views.%s: %w", view, err)
+		}
+	}
+
+	cfg.Preset = preset
+	keyConfig = cfg
+	return nil
+}
+
+// keyMapFor builds the key map of a view: the preset, then the global
+// overrides, then the overrides for that view
+func keyMapFor(view string) KeyMap {
+	preset := keyConfig.Preset
+	if preset == "" {
+		preset = "default"
+	}
+
+	var k KeyMap
+	for _, def := range bindingDefs {
+		keys := keyPresets["default"][def.name]
+		if override, ok := keyPresets[preset][def.name]; ok {
+			keys = override
+		}
+		if override, ok := keyConfig.Bindings[def.name]; ok {
+			keys = override
+		}
+		if override, ok := keyConfig.Views[view][def.name]; ok {
+			keys = override
+		}
+		*def.field(&k) = key.NewBinding(
+			key.WithKeys(keys...),
+			key.WithHelp(strings.Join(keys, "/"), def.desc),
+		)
+	}
+	return k
+}
+
+// tableKeyMap returns the bubble-table key map matching the navigation keys
+func (k KeyMap) tableKeyMap() table.KeyMap {
+	km := table.DefaultKeyMap()
+	km.RowUp = k.Up
+	km.RowDown = k.Down
+	km.PageUp = k.PageUp
+	km.PageDown = k.PageDown
+	km.Filter = k.Filter
+	return km
+}
+
+// withDesc returns a copy of the binding with a view specific description
+func withDesc(b key.Binding, desc string) key.Binding {
+	b.SetHelp(b.Help().Key, desc)
+	return b
+}
+
+// withoutRunes returns a copy of the binding without its single character
+// keys, for views where those are typed as text
+func withoutRunes(b key.Binding) key.Binding {
+	var keys []string
+	for _, k := range b.Keys() {
+		if len([]rune(k)) > 1 {
+			keys = append(keys, k)
+		}
+	}
+	b.SetKeys(keys...)
+	b.SetHelp(strings.Join(keys, "/"), b.Help().Desc)
+	return b
+}
+
+// footerFields renders bindings as bottom bar entries, e.g. "(q) Quit"
+func footerFields(bindings ...key.Binding) []string {
+	fields := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		if !b.Enabled() || len(b.Keys()) == 0 {
+			continue
+		}
+		fields = append(fields, fmt.Sprintf("(%s) %s", b.Keys()[0], b.Help().Desc))
+	}
+	return fields
+}
+
+// helpLine renders bindings on a single line, e.g. "enter: Trigger  esc: Cancel"
+func helpLine(bindings ...key.Binding) string {
+	parts := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		parts = append(parts, fmt.Sprintf("%s: %s", b.Help().Key, b.Help().Desc))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// isQuit reports whether the message should quit the application
+func isQuit(msg tea.KeyMsg, k KeyMap) bool {
+	return key.Matches(msg, k.Quit) || key.Matches(msg, forceQuitKey)
+}
+
+// helpProvider is implemented by views exposing their active bindings
+// for the help overlay
+type helpProvider interface {
+	helpBindings() []key.Binding
+}
+
+// inputCapturer is implemented by views which may be typing text, so
+// global keys such as help are left to them
+type inputCapturer interface {
+	capturingInput() bool
+}
+
+func checkBindingNames(bindings map[string][]string) error {
+	for name, keys := range bindings {
+		if !slices.Contains(bindingNames(), name) {
+			return fmt.Errorf("unknown binding %q (expected one of %s)", name, strings.Join(bindingNames(), ", "))
+		}
+		if len(keys) == 0 {
+			return fmt.Errorf("binding %q has no keys", name)
+		}
+	}
+	return nil
+}
+
+func bindingNames() []string {
+	names := make([]string, len(bindingDefs))
+	for i, def := range bindingDefs {
+		names[i] = def.name
+	}
+	return names
+}
+
+func presetNames() []string {
+	return slices.Sorted(maps.Keys(keyPresets))
+}
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/jjournet/tgr/config"
+)
+
+func TestLoadKeyMap(t *testing.T) {
+	t.Cleanup(func() { keyConfig = config.KeysConfig{} })
+
+	err := LoadKeyMap(config.KeysConfig{
+		Preset:   "vim",
+		Bindings: map[string][]string{"quit": {"Q"}},
+		Views:    map[string]map[string][]string{viewWorkflowRunWatch: {"refresh": {"R"}}},
+	})
+	if err != nil {
+		t.Fatalf("LoadKeyMap: %v", err)
+	}
+
+	watch := keyMapFor(viewWorkflowRunWatch)
+	if !key.Matches(keyMsg("R"), watch.Refresh) || key.Matches(keyMsg("r"), watch.Refresh) {
+		t.Errorf("view override not applied: refresh keys %v", watch.Refresh.Keys())
+	}
+	if !key.Matches(keyMsg("Q"), watch.Quit) {
+		t.Errorf("global override not applied: quit keys %v", watch.Quit.Keys())
+	}
+
+	list := keyMapFor(viewWorkflowRunList)
+	if !key.Matches(keyMsg("r"), list.Refresh) {
+		t.Errorf("view override leaked into another view: refresh keys %v", list.Refresh.Keys())
+	}
+	if !key.Matches(keyMsg("h"), list.Back) {
+		t.Errorf("vim preset not applied: back keys %v", list.Back.Keys())
+	}
+}
+
+func TestLoadKeyMapErrors(t *testing.T) {
+	t.Cleanup(func() { keyConfig = config.KeysConfig{} })
+
+	for name, cfg := range map[string]config.KeysConfig{
+		"unknown preset":  {Preset: "nano"},
+		"unknown binding": {Bindings: map[string][]string{"explode": {"x"}}},
+		"empty binding":   {Bindings: map[string][]string{"quit": {}}},
+		"unknown view":    {Views: map[string]map[string][]string{"nowhere": {"quit": {"x"}}}},
+	} {
+		if err := LoadKeyMap(cfg); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,406 @@
+package tui
+
+import (
+	"github.com/jjournet/tgr/analytics"
+	"github.com/jjournet/tgr/github"
+)
+
+// Messages for the Bubble Tea update cycle
+// Each message represents the result of an async operation
+
+// userLoadedMsg is sent when current user info is loaded
+type userLoadedMsg struct {
+	Login string
+	Name  string
+	Err   error
+}
+
+// orgsLoadedMsg is sent when user's organizations are loaded
+type orgsLoadedMsg struct {
+	Orgs []github.Owner
+	Err  error
+}
+
+// reposLoadedMsg is sent when repositories are loaded
+type reposLoadedMsg struct {
+	Owner string
+	Repos []github.RepoInfo
+	Err   error
+}
+
+// repoStatusLoadedMsg is sent when the dashboard badges of a repository are loaded
+type repoStatusLoadedMsg struct {
+	Repo   string // "owner/repo"
+	Status *github.RepoStatus
+	Err    error
+}
+
+// monitorRunsLoadedMsg is sent when the run monitor has loaded the runs of a repository
+type monitorRunsLoadedMsg struct {
+	Repo string // "owner/repo"
+	Runs []github.RunInfo
+	Gen  int // poll of the run monitor
+	Err  error
+}
+
+// notificationsLoadedMsg is sent when the inbox notifications are loaded
+type notificationsLoadedMsg struct {
+	Notifications []github.NotificationInfo
+	Err           error
+}
+
+// notificationActionMsg is sent when a notification thread has been marked
+// read or done, or unsubscribed
+type notificationActionMsg struct {
+	ID     string
+	Action string // "read", "done" or "unsubscribe"
+	Err    error
+}
+
+// issueLoadedMsg is sent when a single issue is loaded
+type issueLoadedMsg struct {
+	Owner    string
+	RepoName string
+	Issue    *github.IssueInfo
+	Err      error
+}
+
+// notificationRunFoundMsg is sent when the run of a CI notification has
+// been looked up; Run is nil when no run matches
+type notificationRunFoundMsg struct {
+	Owner    string
+	RepoName string
+	Run      *github.RunInfo
+	Err      error
+}
+
+// branchesLoadedMsg is sent when the branches of a repository are loaded
+type branchesLoadedMsg struct {
+	Branches []github.BranchInfo
+	More     bool // the repository has more than github.MaxBranches branches
+	Err      error
+}
+
+// branchStatusLoadedMsg is sent when the details of a branch are loaded
+type branchStatusLoadedMsg struct {
+	Branch string
+	Status *github.BranchStatus
+	Err    error
+}
+
+// branchActionMsg is sent when a branch has been created or deleted
+type branchActionMsg struct {
+	Action string // "create" or "delete"
+	Branch string
+	Err    error
+}
+
+// comparisonLoadedMsg is sent when two refs have been compared
+type comparisonLoadedMsg struct {
+	Comparison *github.Comparison
+	Err        error
+}
+
+// commitsLoadedMsg is sent when the commits of a branch are loaded
+type commitsLoadedMsg struct {
+	Commits []github.CommitInfo
+	Err     error
+}
+
+// commitChecksLoadedMsg is sent when the combined checks of a commit are loaded
+type commitChecksLoadedMsg struct {
+	SHA        string
+	Status     string
+	Conclusion string
+	Err        error
+}
+
+// commitLoadedMsg is sent when the details of a commit are loaded
+type commitLoadedMsg struct {
+	Commit *github.CommitDetail
+	Err    error
+}
+
+// commitRunsLoadedMsg is sent when the runs triggered for a commit are loaded
+type commitRunsLoadedMsg struct {
+	Runs []github.RunInfo
+	Err  error
+}
+
+// environmentsLoadedMsg is sent when the environments of a repository are loaded
+type environmentsLoadedMsg struct {
+	Environments []github.EnvironmentInfo
+	Err          error
+}
+
+// latestDeploymentLoadedMsg is sent when the latest deployment to an
+// environment is loaded. Deployment is nil when there is none.
+type latestDeploymentLoadedMsg struct {
+	Environment string
+	Deployment  *github.DeploymentInfo
+	Err         error
+}
+
+// pendingDeploymentsLoadedMsg is sent when the deployments a run waits for are loaded
+type pendingDeploymentsLoadedMsg struct {
+	Deployments []github.PendingDeploymentInfo
+	Err         error
+}
+
+// deploymentsReviewedMsg is sent when pending deployments have been
+// approved or rejected
+type deploymentsReviewedMsg struct {
+	Approve      bool
+	Environments []string
+	Err          error
+}
+
+// variablesLoadedMsg is sent when the variables of a scope are loaded
+type variablesLoadedMsg struct {
+	Scope     github.VariableScope
+	Variables []github.VariableInfo
+	Err       error
+}
+
+// secretsLoadedMsg is sent when the secrets of a scope are loaded
+type secretsLoadedMsg struct {
+	Scope   github.VariableScope
+	Secrets []github.SecretInfo
+	Err     error
+}
+
+// variableActionMsg is sent when a variable or secret has been set or
+// deleted
+type variableActionMsg struct {
+	Action string // "set" or "delete"
+	Kind   string // "variable" or "secret"
+	Name   string
+	Err    error
+}
+
+// projectsLoadedMsg is sent when the projects of an owner or repository are loaded
+type projectsLoadedMsg struct {
+	Projects []github.ProjectInfo
+	Err      error
+}
+
+// projectLoadedMsg is sent when the fields and items of a project are loaded
+type projectLoadedMsg struct {
+	Project *github.ProjectDetail
+	Err     error
+}
+
+// projectFieldSetMsg is sent when a field of a project item has been set
+type projectFieldSetMsg struct {
+	ItemID string
+	Field  string
+	Value  string
+	Err    error
+}
+
+// projectItemAddedMsg is sent when an issue or pull request has been
+// added to a project
+type projectItemAddedMsg struct {
+	Ref string // "owner/repo#number"
+	Err error
+}
+
+// releasesLoadedMsg is sent when the releases of a repository are loaded
+type releasesLoadedMsg struct {
+	Releases []github.ReleaseInfo
+	Err      error
+}
+
+// tagsLoadedMsg is sent when the tags of a repository are loaded
+type tagsLoadedMsg struct {
+	Tags []github.TagInfo
+	Err  error
+}
+
+// releaseActionMsg is sent when a release has been created or published,
+// or an asset uploaded to it
+type releaseActionMsg struct {
+	Action string // "create", "publish" or "upload"
+	Tag    string
+	Err    error
+}
+
+// artifactsLoadedMsg is sent when the artifacts of a run are loaded
+type artifactsLoadedMsg struct {
+	Artifacts []github.ArtifactInfo
+	Err       error
+}
+
+// artifactProgressMsg is sent while an artifact is downloaded
+type artifactProgressMsg struct {
+	Done  int64
+	Total int64 // -1 when unknown
+}
+
+// artifactDownloadedMsg is sent when an artifact has been downloaded and
+// extracted
+type artifactDownloadedMsg struct {
+	Name  string
+	Dir   string
+	Files []string
+	Err   error
+}
+
+// artifactPreviewLoadedMsg is sent when the files of an artifact are read
+type artifactPreviewLoadedMsg struct {
+	Files []github.ArtifactFile
+	Err   error
+}
+
+// artifactDeletedMsg is sent when an artifact has been deleted
+type artifactDeletedMsg struct {
+	Name string
+	Err  error
+}
+
+// cachesLoadedMsg is sent when the Actions caches of a repository and
+// their storage usage are loaded
+type cachesLoadedMsg struct {
+	Caches []github.CacheInfo
+	Usage  *github.CacheUsage
+	Err    error
+}
+
+// cachesDeletedMsg is sent when a batch of caches has been deleted
+type cachesDeletedMsg struct {
+	Deleted int
+	Err     error // the first failure, the deletion stops there
+}
+
+// runnersLoadedMsg is sent when the self-hosted runners of a repository,
+// or of its organization, are loaded
+type runnersLoadedMsg struct {
+	Org     bool
+	Runners []github.RunnerInfo
+	Jobs    []github.RunnerJob // jobs of the repository running on a runner
+	Err     error
+}
+
+// runnerGroupsLoadedMsg is sent when the runner groups of an organization
+// are loaded
+type runnerGroupsLoadedMsg struct {
+	Groups []github.RunnerGroupInfo
+	Err    error
+}
+
+// runnerActionMsg is sent when a runner has been removed ("remove") or a
+// registration token created ("token")
+type runnerActionMsg struct {
+	Action string
+	Runner string
+	Token  *github.RegistrationToken
+	Err    error
+}
+
+// workflowSummaryLoadedMsg is sent when the trigger and the billable time
+// of a workflow are loaded. Dispatch is nil when the workflow file could
+// not be loaded, Usage when the usage could not; Err tells why.
+type workflowSummaryLoadedMsg struct {
+	WorkflowID int64
+	Dispatch   *bool
+	Usage      *github.WorkflowUsage
+	Err        error
+}
+
+// workflowStateChangedMsg is sent when a workflow has been enabled or
+// disabled
+type workflowStateChangedMsg struct {
+	WorkflowID int64
+	Enabled    bool
+	Err        error
+}
+
+// workflowFileLoadedMsg is sent when the YAML source of a workflow is loaded
+type workflowFileLoadedMsg struct {
+	Content string
+	Err     error
+}
+
+// workflowAnalyticsLoadedMsg is sent when the runs of a workflow and their
+// jobs are loaded for its analytics
+type workflowAnalyticsLoadedMsg struct {
+	Runs []analytics.Run
+	Err  error
+}
+
+// analyticsExportedMsg is sent when the analytics of a workflow have been
+// written as CSV
+type analyticsExportedMsg struct {
+	Path string
+	Err  error
+}
+
+// repoDetailsLoadedMsg is sent when detailed repo info is loaded
+type repoDetailsLoadedMsg struct {
+	Repo *github.RepoDetails
+	Err  error
+}
+
+// workflowsLoadedMsg is sent when workflows are loaded
+type workflowsLoadedMsg struct {
+	Workflows []github.WorkflowInfo
+	Err       error
+}
+
+// workflowRunsLoadedMsg is sent when workflow runs are loaded
+type workflowRunsLoadedMsg struct {
+	WorkflowID int64
+	Runs       []github.RunInfo
+	Err        error
+}
+
+// runDetailLoadedMsg is sent when detailed run info is loaded
+type runDetailLoadedMsg struct {
+	Run *github.RunDetailInfo
+	Err error
+}
+
+// workflowTriggeredMsg is sent when a workflow is triggered
+type workflowTriggeredMsg struct {
+	Success bool
+	Err     error
+}
+
+// runJobsLoadedMsg is sent when workflow run jobs are loaded
+type runJobsLoadedMsg struct {
+	RunID int64
+	Jobs  []github.JobInfo
+	Err   error
+}
+
+// issuesLoadedMsg is sent when issues are loaded
+type issuesLoadedMsg struct {
+	Issues []github.IssueInfo
+	Err    error
+}
+
+// workflowInputsLoadedMsg is sent when workflow inputs are loaded
+type workflowInputsLoadedMsg struct {
+	Inputs []github.WorkflowInputDefinition
+	Err    error
+}
+
+// runFilterChangedMsg is sent by the run filter form when it is applied
+type runFilterChangedMsg struct {
+	Filter github.RunFilter
+}
+
+// refCheckedMsg is sent when a ref typed in the trigger form is checked
+type refCheckedMsg struct {
+	Ref    string
+	Exists bool
+	Err    error
+}
+
+// dispatchedRunsFoundMsg is sent with the runs which may have been created
+// by a dispatch
+type dispatchedRunsFoundMsg struct {
+	Actor string // the current user, looked up on the first attempt
+	Runs  []github.RunInfo
+	Err   error
+}
@@ -0,0 +1,29 @@
+package tui
+
+import (
+	"fmt"
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jjournet/tgr/notify"
+)
+
+// notifyRunCmd returns a command notifying the end of a run
+func (s *session) notifyRunCmd(repo, workflow, branch string, runID int64, conclusion string) tea.Cmd {
+	if s.notifier == nil || s.notifiedRuns[runID] {
+		return nil
+	}
+	s.notifiedRuns[runID] = true
+
+	msg := notify.Message{
+		Title:  fmt.Sprintf("%s %s", workflow, conclusion),
+		Body:   fmt.Sprintf("%s on %s", repo, branch),
+		Urgent: conclusion == "failure",
+	}
+	return func() tea.Msg {
+		if err := s.notifier.Notify(conclusion, msg); err != nil {
+			slog.Warn("Notifying run end failed", "run", runID, "error", err)
+		}
+		return nil
+	}
+}
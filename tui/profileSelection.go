@@ -3,19 +3,22 @@ package tui
 import (
 	"fmt"
 	"log/slog"
+	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/evertras/bubble-table/table"
 	"github.com/jjournet/tgr/github"
 	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
 )
 
 type profileSelection struct {
 	commonElements
 
 	// Service
-	ghService *github.GitHubService
+	sess *session
 
 	// State
 	currentUser string
@@ -25,9 +28,11 @@ type profileSelection struct {
 	orgsLoaded  bool
 	loading     bool
 	err         error
+	autoSelect  string // owner opened as soon as the owners are loaded
 
 	// UI
 	OwnerList table.Model
+	keys      KeyMap
 }
 
 func (m *profileSelection) resizeMain(w int, h int) {
@@ -37,23 +42,31 @@ func (m *profileSelection) resizeMain(w int, h int) {
 }
 
 // NewProfileSelection creates a new profile selection model
-func NewProfileSelection(ghService *github.GitHubService) (tea.Model, tea.Cmd) {
-	slog.Debug("NewProfileSelection called")
+func NewProfileSelection(sess *session) (tea.Model, tea.Cmd) {
+	return newProfileSelection(sess, "")
+}
+
+// newProfileSelection creates a profile selection model which opens the
+// repositories of autoSelect once the owners are loaded
+func newProfileSelection(sess *session, autoSelect string) (tea.Model, tea.Cmd) {
+	slog.Debug("NewProfileSelection called", "autoSelect", autoSelect)
 	m := &profileSelection{
-		ghService: ghService,
-		loading:   true,
+		sess:       sess,
+		loading:    true,
+		autoSelect: autoSelect,
+		keys:       keyMapFor(viewProfileSelection),
 	}
 
 	m.InitTop("Profile Selection", "Loading...")
 	m.TopFields = []string{"Profile Selection", "Loading..."}
 	m.InitBottom()
-	m.BottomFields = []string{"(q) Quit", "(enter) Select"}
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Select, withDesc(m.keys.Back, "Dashboard"), m.keys.Help)
 
 	slog.Debug("Returning model with LoadUserCmd and LoadOrgsCmd")
 	// Return model and commands to load data
 	return m, tea.Batch(
-		ghService.LoadUserCmd(),
-		ghService.LoadOrgsCmd(),
+		loadUserCmd(sess),
+		loadOrgsCmd(sess),
 	)
 }
 
@@ -64,7 +77,7 @@ func (m *profileSelection) Init() tea.Cmd {
 func (m *profileSelection) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
-	case github.UserLoadedMsg:
+	case userLoadedMsg:
 		slog.Debug("Received UserLoadedMsg", "Login", msg.Login, "Err", msg.Err)
 		if msg.Err != nil {
 			m.err = msg.Err
@@ -75,9 +88,9 @@ func (m *profileSelection) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.userLoaded = true
 		m.TopFields = []string{msg.Login, "Profile Selection"}
 		m.checkLoadingComplete()
-		return m, nil
+		return m.openDefaultOwner()
 
-	case github.OrgsLoadedMsg:
+	case orgsLoadedMsg:
 		slog.Debug("Received OrgsLoadedMsg", "Orgs count", len(msg.Orgs), "Err", msg.Err)
 		if msg.Err != nil {
 			m.err = msg.Err
@@ -88,7 +101,7 @@ func (m *profileSelection) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.orgs = msg.Orgs
 		m.orgsLoaded = true
 		m.checkLoadingComplete()
-		return m, nil
+		return m.openDefaultOwner()
 
 	case tea.WindowSizeMsg:
 		constants.WindowSize = msg
@@ -97,19 +110,21 @@ func (m *profileSelection) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		if m.loading {
-			if msg.String() == "q" || msg.String() == "ctrl+c" {
+			if isQuit(msg, m.keys) {
 				return m, tea.Quit
 			}
 			return m, nil // Ignore other keys while loading
 		}
 
-		switch msg.String() {
-		case "q", "ctrl+c":
+		switch {
+		case isQuit(msg, m.keys):
 			return m, tea.Quit
-		case "enter":
+		case key.Matches(msg, m.keys.Select):
 			selectedOwner := m.OwnerList.HighlightedRow().Data["profile"].(string)
 			isUser := m.OwnerList.HighlightedRow().Data["isUser"].(bool)
-			return NewRepoSelection(m.ghService, selectedOwner, isUser)
+			return NewRepoSelection(m.sess, selectedOwner, isUser)
+		case key.Matches(msg, m.keys.Back):
+			return NewDashboard(m.sess)
 		}
 	}
 
@@ -149,6 +164,24 @@ func (m *profileSelection) checkLoadingComplete() {
 	}
 }
 
+// openDefaultOwner jumps to the repositories of the default owner once
+// the owners are known
+func (m *profileSelection) openDefaultOwner() (tea.Model, tea.Cmd) {
+	if m.loading || m.autoSelect == "" {
+		return m, nil
+	}
+
+	owner := m.autoSelect
+	m.autoSelect = ""
+	for _, o := range m.owners {
+		if strings.EqualFold(o.Login, owner) {
+			return NewRepoSelection(m.sess, o.Login, o.IsUser)
+		}
+	}
+	slog.Warn("Default owner not found among the user and its organizations", "owner", owner)
+	return m, nil
+}
+
 func (m *profileSelection) View() string {
 	if m.err != nil {
 		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit", m.err)
@@ -162,7 +195,7 @@ func (m *profileSelection) View() string {
 	for i, row := range m.OwnerList.GetVisibleRows() {
 		row.Data["arrow"] = ""
 		if i == m.OwnerList.GetHighlightedRowIndex() {
-			row.Data["arrow"] = "\uf0a9"
+			row.Data["arrow"] = theme.Icons.Arrow
 		}
 	}
 
@@ -192,9 +225,14 @@ func (m *profileSelection) buildOwnerTable(owners []github.Owner) table.Model {
 	}
 
 	return table.New(columns).WithRows(rows).
+		WithKeyMap(m.keys.tableKeyMap()).
 		Focused(true).
 		Border(table.Border{}).
 		WithBaseStyle(constants.BaseTableStyle).
 		HighlightStyle(constants.HighlightedLineStyle).
 		Filtered(true)
 }
+
+func (m *profileSelection) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Select, withDesc(m.keys.Back, "Dashboard"), m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
@@ -0,0 +1,707 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/evertras/bubble-table/table"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+// Board and table layout sizes
+const (
+	boardMinColumnWidth = 24
+	tableFieldWidth     = 16
+	tableMinTitleWidth  = 30
+)
+
+// boardColumn is a column of the board: the items having one option of
+// the grouping field, or the items without a value
+type boardColumn struct {
+	name   string
+	option string // empty for the items without a value
+	items  []github.ProjectItem
+}
+
+type projectBoardView struct {
+	commonElements
+
+	// Service
+	sess *session
+
+	// Context
+	owner    string
+	repoName string
+	info     github.ProjectInfo
+
+	// State
+	project *github.ProjectDetail
+	loading bool
+	err     error
+	status  string // result of the last action
+
+	// Layout
+	tableLayout bool
+	group       int // index in groupFields of the field the board is grouped by
+	column      int // focused column of the board
+	row         int // focused item of the column
+
+	// Inline forms
+	adding    bool // the input reads an item to add rather than a field value
+	editField int  // index in project.Fields of the field being edited
+	editItem  github.ProjectItem
+
+	// UI
+	EltList        table.Model // table layout
+	visibleCommand bool
+	keys           KeyMap
+
+	// Navigation
+	parentView tea.Model
+}
+
+func (m *projectBoardView) resizeMain(w int, h int) {
+	headerHeight := lipgloss.Height(m.RenderTopFields())
+	footerHeight := lipgloss.Height(m.RenderBottomFields())
+	cmdHeight := 0
+	if m.visibleCommand {
+		cmdHeight = 3
+	}
+	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2 - cmdHeight)
+	m.EltList = m.EltList.WithPageSize(h - headerHeight - footerHeight - 3 - cmdHeight)
+	constants.CommandStyle = constants.CommandStyle.Width(w - 2).Height(1)
+}
+
+// NewProjectBoard creates a view showing the items of a project as a
+// board, with a column per option of a single select field, or as a
+// table. Back returns to parentView.
+func NewProjectBoard(sess *session, owner, repoName string, info github.ProjectInfo, parentView tea.Model) (tea.Model, tea.Cmd) {
+	m := &projectBoardView{
+		sess:       sess,
+		owner:      owner,
+		repoName:   repoName,
+		info:       info,
+		loading:    true,
+		parentView: parentView,
+		keys:       keyMapFor(viewProjectBoard),
+	}
+	m.keys.Select = withDesc(m.keys.Select, "Open")
+	m.keys.Create = withDesc(m.keys.Create, "Add item")
+	m.keys.PageUp = withDesc(m.keys.PageUp, "Previous column")
+	m.keys.PageDown = withDesc(m.keys.PageDown, "Next column")
+
+	m.InitTop(owner, repoName, info.Title)
+	m.TopFields = []string{owner, repoName, fmt.Sprintf("%s #%d", info.Title, info.Number)}
+	m.InitBottom()
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Select, m.keys.Layout, m.keys.MoveLeft, m.keys.MoveRight, m.keys.Edit, m.keys.Create, m.keys.Back, m.keys.Help)
+	m.CommandInput = textinput.New()
+
+	return m, loadProjectCmd(sess, info.ID)
+}
+
+func (m *projectBoardView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *projectBoardView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case projectLoadedMsg:
+		m.loading = false
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		m.project = msg.Project
+		if len(m.groupFields()) == 0 {
+			m.tableLayout = true
+		}
+		m.group = min(m.group, max(len(m.groupFields())-1, 0))
+		m.clampFocus()
+		m.rebuild()
+		return m, nil
+
+	case projectFieldSetMsg:
+		item, ok := m.findItem(msg.ItemID)
+		if !ok {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Could not set %s of %s: %v", msg.Field, itemLabel(*item, m.owner, m.repoName), msg.Err)
+			m.rebuild()
+			return m, nil
+		}
+		// Apply the change locally rather than reloading the whole project
+		if msg.Value == "" {
+			delete(item.Values, msg.Field)
+			m.status = fmt.Sprintf("Cleared %s of %s", msg.Field, itemLabel(*item, m.owner, m.repoName))
+		} else {
+			item.Values[msg.Field] = m.canonicalValue(msg.Field, msg.Value)
+			m.status = fmt.Sprintf("Set %s of %s to %s", msg.Field, itemLabel(*item, m.owner, m.repoName), item.Values[msg.Field])
+		}
+		m.focusItem(msg.ItemID)
+		m.rebuild()
+		return m, nil
+
+	case projectItemAddedMsg:
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Could not add %s: %v", msg.Ref, msg.Err)
+			m.rebuild()
+			return m, nil
+		}
+		m.status = "Added " + msg.Ref
+		m.loading = true
+		return m, loadProjectCmd(m.sess, m.info.ID)
+
+	case issueLoadedMsg:
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Opening issue failed: %v", msg.Err)
+			m.rebuild()
+			return m, nil
+		}
+		return NewIssueDetail(m.sess, msg.Owner, msg.RepoName, *msg.Issue, m)
+
+	case tea.WindowSizeMsg:
+		constants.WindowSize = msg
+		if m.project != nil {
+			// The table columns depend on the width
+			m.rebuild()
+		}
+		m.resizeMain(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading {
+			if isQuit(msg, m.keys) {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		if m.visibleCommand {
+			return m.handleInput(msg)
+		}
+
+		switch {
+		case isQuit(msg, m.keys):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return m.parentView, m.parentView.Init()
+		case key.Matches(msg, m.keys.Refresh):
+			m.status = ""
+			m.loading = true
+			return m, loadProjectCmd(m.sess, m.info.ID)
+		case key.Matches(msg, m.keys.Layout):
+			if len(m.groupFields()) == 0 {
+				m.status = "No single select or iteration field to group the board by"
+				m.rebuild()
+				return m, nil
+			}
+			item, ok := m.selectedItem()
+			m.tableLayout = !m.tableLayout
+			if ok {
+				m.focusItem(item.ID)
+			}
+			m.rebuild()
+			return m, nil
+		case key.Matches(msg, m.keys.Group):
+			if fields := m.groupFields(); len(fields) > 1 {
+				m.group = (m.group + 1) % len(fields)
+				m.column, m.row = 0, 0
+				m.rebuild()
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.Create):
+			m.adding = true
+			m.openInput("Add issue or pull request (number or owner/repo#number): ", "")
+			return m, nil
+		}
+
+		if !m.tableLayout {
+			switch {
+			case key.Matches(msg, m.keys.Up):
+				m.row--
+				m.clampFocus()
+				return m, nil
+			case key.Matches(msg, m.keys.Down):
+				m.row++
+				m.clampFocus()
+				return m, nil
+			case key.Matches(msg, m.keys.PageUp):
+				m.column--
+				m.clampFocus()
+				m.rebuild()
+				return m, nil
+			case key.Matches(msg, m.keys.PageDown):
+				m.column++
+				m.clampFocus()
+				m.rebuild()
+				return m, nil
+			}
+		}
+
+		item, ok := m.selectedItem()
+		if !ok {
+			break
+		}
+		switch {
+		case key.Matches(msg, m.keys.Select):
+			if item.Type == "DRAFT_ISSUE" {
+				m.status = "Draft issues have no page to open"
+				m.rebuild()
+				return m, nil
+			}
+			owner, repoName, _ := strings.Cut(item.Repo, "/")
+			return m, loadIssueCmd(m.sess, owner, repoName, item.Number)
+		case key.Matches(msg, m.keys.MoveLeft):
+			return m, m.move(item, -1)
+		case key.Matches(msg, m.keys.MoveRight):
+			return m, m.move(item, 1)
+		case key.Matches(msg, m.keys.Edit):
+			if len(m.project.Fields) == 0 {
+				m.status = "The project has no editable field"
+				m.rebuild()
+				return m, nil
+			}
+			m.adding = false
+			m.editItem = item
+			m.editField = 0
+			m.openFieldInput()
+			return m, nil
+		}
+	}
+
+	if !m.tableLayout || m.project == nil {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.EltList, cmd = m.EltList.Update(msg)
+	return m, cmd
+}
+
+// move sets the grouping field of an item to the previous or next option,
+// moving it to the neighbouring column of the board
+func (m *projectBoardView) move(item github.ProjectItem, delta int) tea.Cmd {
+	fields := m.groupFields()
+	if len(fields) == 0 {
+		return nil
+	}
+	field := fields[m.group]
+
+	// The column without a value is the first one, as on github.com
+	options := append([]string{""}, optionNames(field)...)
+	current := 0
+	for i, option := range options {
+		if option != "" && option == item.Values[field.Name] {
+			current = i
+		}
+	}
+	target := current + delta
+	if target < 0 || target >= len(options) {
+		return nil
+	}
+
+	m.status = fmt.Sprintf("Moving %s...", itemLabel(item, m.owner, m.repoName))
+	m.rebuild()
+	return setProjectFieldCmd(m.sess, m.project.ID, item.ID, field, options[target])
+}
+
+// openInput shows the inline form with the given prompt and value
+func (m *projectBoardView) openInput(prompt, value string) {
+	m.CommandInput.Prompt = prompt
+	m.CommandInput.SetValue(value)
+	m.CommandInput.CursorEnd()
+	m.CommandInput.Focus()
+	m.visibleCommand = true
+	m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+}
+
+// openFieldInput prompts for the value of the field being edited,
+// listing its options
+func (m *projectBoardView) openFieldInput() {
+	field := m.project.Fields[m.editField]
+	prompt := field.Name
+	switch field.Type {
+	case "SINGLE_SELECT", "ITERATION":
+		prompt += " [" + strings.Join(optionNames(field), "|") + "]"
+	case "DATE":
+		prompt += " (YYYY-MM-DD)"
+	}
+	m.openInput(prompt+": ", m.editItem.Values[field.Name])
+}
+
+// handleInput reads the item to add, or the value of a field where the
+// field keys switch to the other fields
+func (m *projectBoardView) handleInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.String() == "esc":
+		m.closeInput()
+		return m, nil
+	case !m.adding && key.Matches(msg, m.keys.NextField):
+		m.editField = (m.editField + 1) % len(m.project.Fields)
+		m.openFieldInput()
+		return m, nil
+	case !m.adding && key.Matches(msg, m.keys.PrevField):
+		m.editField = (m.editField + len(m.project.Fields) - 1) % len(m.project.Fields)
+		m.openFieldInput()
+		return m, nil
+	case msg.String() == "enter":
+		value := strings.TrimSpace(m.CommandInput.Value())
+		m.closeInput()
+		if m.adding {
+			return m, m.addItem(value)
+		}
+		field := m.project.Fields[m.editField]
+		m.status = fmt.Sprintf("Setting %s of %s...", field.Name, itemLabel(m.editItem, m.owner, m.repoName))
+		m.rebuild()
+		return m, setProjectFieldCmd(m.sess, m.project.ID, m.editItem.ID, field, value)
+	}
+
+	var cmd tea.Cmd
+	m.CommandInput, cmd = m.CommandInput.Update(msg)
+	return m, cmd
+}
+
+// addItem adds the issue or pull request referenced as "12", "#12",
+// "repo#12" or "owner/repo#12", relative to the current repository
+func (m *projectBoardView) addItem(ref string) tea.Cmd {
+	owner, repoName := m.owner, m.repoName
+	number := strings.TrimPrefix(ref, "#")
+	if repo, n, ok := strings.Cut(ref, "#"); ok && repo != "" {
+		number = n
+		if o, r, ok := strings.Cut(repo, "/"); ok {
+			owner, repoName = o, r
+		} else {
+			repoName = repo
+		}
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil || n <= 0 {
+		if ref != "" {
+			m.status = fmt.Sprintf("Invalid reference %q, expected a number or owner/repo#number", ref)
+			m.rebuild()
+		}
+		return nil
+	}
+
+	m.status = fmt.Sprintf("Adding %s/%s#%d...", owner, repoName, n)
+	m.rebuild()
+	return addProjectItemCmd(m.sess, m.project.ID, owner, repoName, n)
+}
+
+func (m *projectBoardView) closeInput() {
+	m.visibleCommand = false
+	m.CommandInput.Blur()
+	m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+}
+
+// groupFields returns the fields the board can be grouped by
+func (m *projectBoardView) groupFields() []github.ProjectField {
+	var fields []github.ProjectField
+	if m.project == nil {
+		return nil
+	}
+	for _, field := range m.project.Fields {
+		if field.Type == "SINGLE_SELECT" || field.Type == "ITERATION" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// columns splits the items by the grouping field. The column of the
+// items without a value only shows when it is not empty.
+func (m *projectBoardView) columns() []boardColumn {
+	fields := m.groupFields()
+	if len(fields) == 0 {
+		return nil
+	}
+	field := fields[m.group]
+
+	none := boardColumn{name: "No " + field.Name}
+	columns := make([]boardColumn, len(field.Options))
+	for i, option := range field.Options {
+		columns[i] = boardColumn{name: option.Name, option: option.Name}
+	}
+	for _, item := range m.project.Items {
+		i := -1
+		for j := range columns {
+			if columns[j].option == item.Values[field.Name] {
+				i = j
+			}
+		}
+		if i < 0 {
+			none.items = append(none.items, item)
+		} else {
+			columns[i].items = append(columns[i].items, item)
+		}
+	}
+	if len(none.items) > 0 {
+		columns = append([]boardColumn{none}, columns...)
+	}
+	return columns
+}
+
+func (m *projectBoardView) selectedItem() (github.ProjectItem, bool) {
+	if m.project == nil {
+		return github.ProjectItem{}, false
+	}
+	if m.tableLayout {
+		if len(m.EltList.GetVisibleRows()) == 0 {
+			return github.ProjectItem{}, false
+		}
+		item, ok := m.EltList.HighlightedRow().Data["item"].(github.ProjectItem)
+		return item, ok
+	}
+	columns := m.columns()
+	if m.column >= len(columns) || m.row >= len(columns[m.column].items) {
+		return github.ProjectItem{}, false
+	}
+	return columns[m.column].items[m.row], true
+}
+
+// findItem returns the item of the project with the given ID
+func (m *projectBoardView) findItem(id string) (*github.ProjectItem, bool) {
+	if m.project == nil {
+		return nil, false
+	}
+	for i := range m.project.Items {
+		if m.project.Items[i].ID == id {
+			return &m.project.Items[i], true
+		}
+	}
+	return nil, false
+}
+
+// focusItem moves the focus of the board to an item, the table keeps its
+// highlighted row
+func (m *projectBoardView) focusItem(id string) {
+	for c, column := range m.columns() {
+		for r, item := range column.items {
+			if item.ID == id {
+				m.column, m.row = c, r
+				return
+			}
+		}
+	}
+}
+
+// clampFocus keeps the focus of the board on an existing column and item
+func (m *projectBoardView) clampFocus() {
+	columns := m.columns()
+	m.column = max(min(m.column, len(columns)-1), 0)
+	if len(columns) == 0 {
+		m.row = 0
+		return
+	}
+	m.row = max(min(m.row, len(columns[m.column].items)-1), 0)
+}
+
+// canonicalValue returns the option name matching a value typed with a
+// different case, as GitHub stores it
+func (m *projectBoardView) canonicalValue(fieldName, value string) string {
+	for _, field := range m.project.Fields {
+		if field.Name != fieldName {
+			continue
+		}
+		for _, option := range optionNames(field) {
+			if strings.EqualFold(option, value) {
+				return option
+			}
+		}
+	}
+	return value
+}
+
+// rebuild refreshes the header and the table after the project, the
+// layout or the status changed
+func (m *projectBoardView) rebuild() {
+	m.TopFields = []string{m.owner, m.repoName, fmt.Sprintf("%s #%d (%d)", m.info.Title, m.info.Number, len(m.project.Items))}
+	if fields := m.groupFields(); !m.tableLayout && len(fields) > 0 {
+		m.TopFields = append(m.TopFields, "Board by "+fields[m.group].Name)
+	} else {
+		m.TopFields = append(m.TopFields, "Table")
+	}
+	if m.status != "" {
+		m.TopFields = append(m.TopFields, m.status)
+	}
+
+	m.EltList = m.buildItemTable(m.EltList.GetHighlightedRowIndex())
+	if constants.WindowSize.Height != 0 {
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	}
+}
+
+func (m *projectBoardView) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit or 'backspace' to go back", m.err)
+	}
+
+	if m.loading {
+		return m.RenderTopFields() + "\n\nLoading project..."
+	}
+
+	var main string
+	if m.tableLayout {
+		for i, row := range m.EltList.GetVisibleRows() {
+			row.Data["arrow"] = ""
+			if i == m.EltList.GetHighlightedRowIndex() {
+				row.Data["arrow"] = theme.Icons.Arrow
+			}
+		}
+		main = m.EltList.View()
+	} else {
+		main = m.renderBoard()
+	}
+
+	if m.visibleCommand {
+		return fmt.Sprintf(
+			"%s\n%s\n%s\n%s",
+			m.RenderTopFields(),
+			constants.CommandStyle.BorderForeground(theme.Current.Accent).Render(m.CommandInput.View()),
+			constants.MainStyle.Render(main),
+			m.RenderBottomFields(),
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s",
+		m.RenderTopFields(),
+		constants.MainStyle.Render(main),
+		m.RenderBottomFields(),
+	)
+}
+
+// renderBoard renders the columns side by side. Columns are at least
+// boardMinColumnWidth wide, the ones which do not fit are scrolled to.
+func (m *projectBoardView) renderBoard() string {
+	columns := m.columns()
+	width := max(constants.MainStyle.GetWidth(), boardMinColumnWidth)
+	height := max(constants.MainStyle.GetHeight(), 3)
+
+	visible := max(min(len(columns), width/boardMinColumnWidth), 1)
+	start := max(m.column-visible+1, 0)
+	end := min(start+visible, len(columns))
+	columnWidth := width / visible
+
+	emphasis := lipgloss.NewStyle().Bold(true).Foreground(theme.Current.Emphasis)
+	muted := lipgloss.NewStyle().Foreground(theme.Current.Muted)
+	focused := lipgloss.NewStyle().Foreground(theme.Current.OnAccent).Background(theme.Current.Accent)
+
+	rendered := make([]string, 0, end-start)
+	for c := start; c < end; c++ {
+		column := columns[c]
+		header := fmt.Sprintf("%s (%d)", column.name, len(column.items))
+		if c == start && start > 0 {
+			header = "< " + header
+		}
+		if c == end-1 && end < len(columns) {
+			header += " >"
+		}
+		lines := []string{
+			emphasis.Render(ansi.Truncate(header, columnWidth-1, "…")),
+			muted.Render(strings.Repeat("─", columnWidth-1)),
+		}
+
+		// Scroll the focused column to its focused item
+		offset := 0
+		if c == m.column {
+			offset = max(m.row-(height-2)+1, 0)
+		}
+		for r := offset; r < len(column.items) && len(lines) < height; r++ {
+			item := column.items[r]
+			text := ansi.Truncate(itemLabel(item, m.owner, m.repoName)+" "+item.Title, columnWidth-3, "…")
+			switch {
+			case c == m.column && r == m.row:
+				lines = append(lines, focused.Render(theme.Icons.Arrow+" "+text))
+			case item.State == "CLOSED" || item.State == "MERGED":
+				lines = append(lines, "  "+muted.Render(text))
+			default:
+				lines = append(lines, "  "+text)
+			}
+		}
+		rendered = append(rendered, lipgloss.NewStyle().Width(columnWidth).Render(strings.Join(lines, "\n")))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+}
+
+// buildItemTable builds the table layout: the title then as many fields
+// as the width allows
+func (m *projectBoardView) buildItemTable(highlighted int) table.Model {
+	width := max(constants.WindowSize.Width-2, 0)
+	fieldCount := max(min(len(m.project.Fields), (width-3-10-tableMinTitleWidth)/tableFieldWidth), 0)
+	titleWidth := max(width-3-10-fieldCount*tableFieldWidth, tableMinTitleWidth)
+
+	columns := []table.Column{
+		table.NewColumn("arrow", " ", 3),
+		table.NewColumn("ref", "Item", 10),
+		table.NewColumn("title", "Title", titleWidth),
+	}
+	for i, field := range m.project.Fields[:fieldCount] {
+		columns = append(columns, table.NewColumn(fmt.Sprintf("field%d", i), field.Name, tableFieldWidth))
+	}
+
+	rows := []table.Row{}
+	for _, item := range m.project.Items {
+		data := table.RowData{
+			"arrow": "",
+			"ref":   itemLabel(item, m.owner, m.repoName),
+			"title": item.Title,
+			"item":  item,
+		}
+		for i, field := range m.project.Fields[:fieldCount] {
+			data[fmt.Sprintf("field%d", i)] = item.Values[field.Name]
+		}
+		rows = append(rows, table.NewRow(data))
+	}
+
+	return table.New(columns).WithRows(rows).
+		WithKeyMap(m.keys.tableKeyMap()).
+		Focused(true).
+		Border(table.Border{}).
+		WithBaseStyle(constants.BaseTableStyle).
+		HighlightStyle(constants.HighlightedLineStyle).
+		WithFooterVisibility(false).
+		WithHighlightedRow(min(highlighted, max(len(rows)-1, 0)))
+}
+
+// itemLabel names an item by its number, prefixed by its repository when
+// it is not the current one
+func itemLabel(item github.ProjectItem, owner, repoName string) string {
+	switch {
+	case item.Type == "DRAFT_ISSUE":
+		return "Draft"
+	case item.Repo == owner+"/"+repoName:
+		return fmt.Sprintf("#%d", item.Number)
+	case strings.HasPrefix(item.Repo, owner+"/"):
+		return fmt.Sprintf("%s#%d", strings.TrimPrefix(item.Repo, owner+"/"), item.Number)
+	default:
+		return fmt.Sprintf("%s#%d", item.Repo, item.Number)
+	}
+}
+
+// optionNames returns the names of the options of a single select or
+// iteration field
+func optionNames(field github.ProjectField) []string {
+	names := make([]string, len(field.Options))
+	for i, option := range field.Options {
+		names[i] = option.Name
+	}
+	return names
+}
+
+func (m *projectBoardView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Select, m.keys.Layout, m.keys.Group, m.keys.MoveLeft, m.keys.MoveRight, m.keys.Edit, m.keys.Create, m.keys.Refresh, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
+
+func (m *projectBoardView) capturingInput() bool {
+	return m.visibleCommand
+}
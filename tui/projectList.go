@@ -0,0 +1,204 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+type projectListView struct {
+	commonElements
+
+	// Service
+	sess *session
+
+	// Context
+	owner    string
+	repoName string
+
+	// State
+	ownerProjects bool // all the projects of the owner rather than those linked to the repository
+	projects      []github.ProjectInfo
+	loading       bool
+	err           error
+
+	// UI
+	EltList table.Model
+	keys    KeyMap
+}
+
+func (m *projectListView) resizeMain(w int, h int) {
+	headerHeight := lipgloss.Height(m.RenderTopFields())
+	footerHeight := lipgloss.Height(m.RenderBottomFields())
+	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2)
+	m.EltList = m.EltList.WithPageSize(h - headerHeight - footerHeight - 3)
+}
+
+// NewProjectList creates a view listing the projects linked to a
+// repository, or all the projects of its owner
+func NewProjectList(sess *session, owner, repoName string) (tea.Model, tea.Cmd) {
+	m := &projectListView{
+		sess:     sess,
+		owner:    owner,
+		repoName: repoName,
+		keys:     keyMapFor(viewProjectList),
+	}
+	m.keys.Select = withDesc(m.keys.Select, "Open")
+	m.keys.Scope = withDesc(m.keys.Scope, "Repository/Owner")
+
+	m.InitTop(owner, repoName, "Projects")
+	m.InitBottom()
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Select, m.keys.Scope, m.keys.Refresh, m.keys.Back, m.keys.Help)
+
+	return m, m.load()
+}
+
+func (m *projectListView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *projectListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case projectsLoadedMsg:
+		m.loading = false
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		m.projects = msg.Projects
+		m.TopFields[2] = fmt.Sprintf("%s (%d)", m.TopFields[2], len(m.projects))
+		m.EltList = m.buildProjectTable()
+		if constants.WindowSize.Height != 0 {
+			m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+		}
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		constants.WindowSize = msg
+		m.resizeMain(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading {
+			if isQuit(msg, m.keys) {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch {
+		case isQuit(msg, m.keys):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return NewRepoView(m.sess, m.owner, m.repoName)
+		case key.Matches(msg, m.keys.Refresh):
+			return m, m.load()
+		case key.Matches(msg, m.keys.Scope):
+			m.ownerProjects = !m.ownerProjects
+			return m, m.load()
+		case key.Matches(msg, m.keys.Select):
+			if len(m.projects) == 0 {
+				return m, nil
+			}
+			project := m.EltList.HighlightedRow().Data["project"].(github.ProjectInfo)
+			return NewProjectBoard(m.sess, m.owner, m.repoName, project, m)
+		}
+	}
+
+	var cmd tea.Cmd
+	m.EltList, cmd = m.EltList.Update(msg)
+	return m, cmd
+}
+
+// load reloads the projects of the repository or of the owner
+func (m *projectListView) load() tea.Cmd {
+	m.loading = true
+	if m.ownerProjects {
+		m.TopFields = []string{m.owner, m.repoName, "Projects of " + m.owner}
+		return loadProjectsCmd(m.sess, m.owner, "")
+	}
+	m.TopFields = []string{m.owner, m.repoName, "Projects linked to " + m.repoName}
+	return loadProjectsCmd(m.sess, m.owner, m.repoName)
+}
+
+func (m *projectListView) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit or 'backspace' to go back", m.err)
+	}
+
+	if m.loading {
+		return m.RenderTopFields() + "\n\nLoading projects..."
+	}
+
+	if len(m.projects) == 0 {
+		return fmt.Sprintf(
+			"%s\n%s\n%s",
+			m.RenderTopFields(),
+			constants.MainStyle.Render(fmt.Sprintf("No project. Press %s to list the projects of %s.", m.keys.Scope.Help().Key, m.owner)),
+			m.RenderBottomFields(),
+		)
+	}
+
+	for i, row := range m.EltList.GetVisibleRows() {
+		row.Data["arrow"] = ""
+		if i == m.EltList.GetHighlightedRowIndex() {
+			row.Data["arrow"] = theme.Icons.Arrow
+		}
+	}
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s",
+		m.RenderTopFields(),
+		constants.MainStyle.Render(m.EltList.View()),
+		m.RenderBottomFields(),
+	)
+}
+
+func (m *projectListView) buildProjectTable() table.Model {
+	columns := []table.Column{
+		table.NewColumn("arrow", " ", 3),
+		table.NewColumn("number", "#", 6),
+		table.NewColumn("title", "Project", 50),
+		table.NewColumn("owner", "Owner", 15),
+		table.NewColumn("items", "Items", 7),
+		table.NewColumn("state", "State", 8),
+		table.NewColumn("updated", "Updated", 17),
+	}
+
+	rows := []table.Row{}
+	for _, project := range m.projects {
+		state := "open"
+		if project.Closed {
+			state = "closed"
+		}
+		rows = append(rows, table.NewRow(table.RowData{
+			"arrow":   "",
+			"number":  fmt.Sprintf("%d", project.Number),
+			"title":   project.Title,
+			"owner":   project.Owner,
+			"items":   fmt.Sprintf("%d", project.ItemCount),
+			"state":   state,
+			"updated": project.UpdatedAt.Format("2006-01-02 15:04"),
+			"project": project,
+		}))
+	}
+
+	return table.New(columns).WithRows(rows).
+		WithKeyMap(m.keys.tableKeyMap()).
+		Focused(true).
+		Border(table.Border{}).
+		WithBaseStyle(constants.BaseTableStyle).
+		HighlightStyle(constants.HighlightedLineStyle).
+		WithFooterVisibility(false)
+}
+
+func (m *projectListView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Select, m.keys.Scope, m.keys.Refresh, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
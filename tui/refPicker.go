@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+)
+
+// maxRefSuggestions is the number of refs suggested under the ref field
+const maxRefSuggestions = 5
+
+// refOption is a branch or a tag offered by the ref picker
+type refOption struct {
+	name string
+	tag  bool
+}
+
+// matchRefs returns the refs matching query, best first: those starting
+// with it, then those containing it, then those holding its characters in
+// order. Case is ignored and refs keep their order within a rank.
+func matchRefs(refs []refOption, query string) []refOption {
+	query = strings.ToLower(query)
+	rank := func(name string) int {
+		name = strings.ToLower(name)
+		switch {
+		case strings.HasPrefix(name, query):
+			return 0
+		case strings.Contains(name, query):
+			return 1
+		case isSubsequence(query, name):
+			return 2
+		}
+		return -1
+	}
+
+	var matches []refOption
+	ranks := map[string]int{}
+	for _, ref := range refs {
+		if r := rank(ref.name); r >= 0 {
+			matches = append(matches, ref)
+			ranks[ref.name] = r
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b refOption) int {
+		return ranks[a.name] - ranks[b.name]
+	})
+	return matches
+}
+
+// isSubsequence reports whether the characters of s appear in t in order
+func isSubsequence(s, t string) bool {
+	for _, c := range s {
+		i := strings.IndexRune(t, c)
+		if i < 0 {
+			return false
+		}
+		t = t[i+len(string(c)):]
+	}
+	return true
+}
+
+// refName returns the branch or tag name of a ref, without its refs/heads/
+// or refs/tags/ prefix
+func refName(ref string) string {
+	if name, ok := strings.CutPrefix(ref, "refs/heads/"); ok {
+		return name
+	}
+	return strings.TrimPrefix(ref, "refs/tags/")
+}
@@ -0,0 +1,276 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+// Fields of the release form, in focus order
+const (
+	releaseFieldTag = iota
+	releaseFieldTarget
+	releaseFieldName
+	releaseFieldAssets
+	releaseFieldDraft
+	releaseFieldPrerelease
+	releaseFieldGenerateNotes
+	releaseFieldCount
+)
+
+type releaseFormView struct {
+	// Service
+	sess *session
+
+	// Context
+	owner    string
+	repoName string
+
+	// State
+	text         [releaseFieldAssets + 1]string // text fields, indexed by field
+	draft        bool
+	prerelease   bool
+	generate     bool
+	focusedIndex int
+	creating     bool
+	err          error // shown under the fields, which stay editable
+
+	// Return to parent
+	parentView tea.Model
+
+	// UI
+	keys KeyMap
+}
+
+// NewReleaseForm creates a form creating a release as an overlay. The tag
+// is created from target when it does not exist yet.
+func NewReleaseForm(sess *session, owner, repoName, tag, target string, parentView tea.Model) (tea.Model, tea.Cmd) {
+	m := &releaseFormView{
+		sess:       sess,
+		owner:      owner,
+		repoName:   repoName,
+		generate:   true,
+		parentView: parentView,
+		keys:       keyMapFor(viewReleaseForm),
+	}
+	m.text[releaseFieldTag] = tag
+	m.text[releaseFieldTarget] = target
+	m.text[releaseFieldName] = tag
+	// Printable keys are typed into the fields, they cannot be actions here
+	m.keys.Select = withDesc(withoutRunes(m.keys.Select), "Create")
+	m.keys.NextField = withoutRunes(m.keys.NextField)
+	m.keys.PrevField = withoutRunes(m.keys.PrevField)
+	m.keys.Cancel = withoutRunes(m.keys.Cancel)
+
+	return m, nil
+}
+
+func (m *releaseFormView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *releaseFormView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case releaseActionMsg:
+		m.creating = false
+		if msg.Action == "create" && msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		// Created, maybe without all its assets: the list reports it
+		return m.parentView, func() tea.Msg { return msg }
+
+	case tea.KeyMsg:
+		if m.creating {
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.Cancel):
+			return m.parentView, nil
+
+		case key.Matches(msg, m.keys.NextField):
+			m.focusedIndex = (m.focusedIndex + 1) % releaseFieldCount
+
+		case key.Matches(msg, m.keys.PrevField):
+			m.focusedIndex = (m.focusedIndex + releaseFieldCount - 1) % releaseFieldCount
+
+		case key.Matches(msg, m.keys.Select):
+			req, assets, err := m.request()
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.err = nil
+			m.creating = true
+			return m, createReleaseCmd(m.sess, m.owner, m.repoName, req, assets)
+
+		case m.focusedIndex > releaseFieldAssets:
+			if msg.Type == tea.KeySpace {
+				m.toggle()
+			}
+
+		case msg.Type == tea.KeyBackspace:
+			field := &m.text[m.focusedIndex]
+			if runes := []rune(*field); len(runes) > 0 {
+				*field = string(runes[:len(runes)-1])
+			}
+
+		case msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace:
+			m.text[m.focusedIndex] += string(msg.Runes)
+		}
+	}
+
+	return m, nil
+}
+
+func (m *releaseFormView) toggle() {
+	switch m.focusedIndex {
+	case releaseFieldDraft:
+		m.draft = !m.draft
+	case releaseFieldPrerelease:
+		m.prerelease = !m.prerelease
+	case releaseFieldGenerateNotes:
+		m.generate = !m.generate
+	}
+}
+
+// request validates the form, checking the asset files exist before
+// anything is created
+func (m *releaseFormView) request() (github.ReleaseRequest, []string, error) {
+	req := github.ReleaseRequest{
+		Tag:           strings.TrimSpace(m.text[releaseFieldTag]),
+		Target:        strings.TrimSpace(m.text[releaseFieldTarget]),
+		Name:          strings.TrimSpace(m.text[releaseFieldName]),
+		Draft:         m.draft,
+		Prerelease:    m.prerelease,
+		GenerateNotes: m.generate,
+	}
+	if req.Tag == "" {
+		return req, nil, fmt.Errorf("a tag is required")
+	}
+
+	var assets []string
+	for _, path := range strings.Split(m.text[releaseFieldAssets], ",") {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return req, nil, err
+		}
+		if info.IsDir() {
+			return req, nil, fmt.Errorf("%s is a directory", path)
+		}
+		assets = append(assets, path)
+	}
+	return req, assets, nil
+}
+
+func (m *releaseFormView) View() string {
+	var popup strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.Current.OnPrimary).
+		Background(theme.Current.Primary).
+		Padding(0, 2)
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Current.Muted).
+		Bold(true)
+	focusedStyle := lipgloss.NewStyle().
+		Foreground(theme.Current.Emphasis).
+		Background(theme.Current.Surface).
+		Padding(0, 1)
+	unfocusedStyle := lipgloss.NewStyle().
+		Foreground(theme.Current.Text).
+		Padding(0, 1)
+	instrStyle := lipgloss.NewStyle().
+		Foreground(theme.Current.Subtle).
+		Italic(true)
+
+	popup.WriteString(titleStyle.Render("New Release"))
+	popup.WriteString("\n\n")
+
+	if m.creating {
+		popup.WriteString("Creating release " + strings.TrimSpace(m.text[releaseFieldTag]) + "...")
+	} else {
+		labels := [...]string{"Tag:", "Target (when the tag is new):", "Title:", "Assets (comma separated paths):"}
+		for i, label := range labels {
+			popup.WriteString(labelStyle.Render(label))
+			popup.WriteString("\n")
+			if m.focusedIndex == i {
+				popup.WriteString(focusedStyle.Render(m.text[i] + "█"))
+			} else {
+				popup.WriteString(unfocusedStyle.Render(m.text[i]))
+			}
+			popup.WriteString("\n\n")
+		}
+
+		toggles := []struct {
+			label string
+			on    bool
+		}{
+			{"Draft", m.draft},
+			{"Pre-release", m.prerelease},
+			{"Generate notes", m.generate},
+		}
+		for i, t := range toggles {
+			box := "[ ]"
+			if t.on {
+				box = "[x]"
+			}
+			line := box + " " + t.label
+			if m.focusedIndex == releaseFieldDraft+i {
+				popup.WriteString(focusedStyle.Render(line))
+			} else {
+				popup.WriteString(unfocusedStyle.Render(line))
+			}
+			popup.WriteString("\n")
+		}
+
+		if m.err != nil {
+			popup.WriteString("\n")
+			errorStyle := lipgloss.NewStyle().
+				Foreground(theme.Current.Failure)
+			popup.WriteString(errorStyle.Render(fmt.Sprintf("%s %v", theme.Icons.JobFailure, m.err)))
+			popup.WriteString("\n")
+		}
+
+		popup.WriteString("\n")
+		popup.WriteString(instrStyle.Render(helpLine(m.keys.NextField, m.keys.PrevField)))
+		popup.WriteString("\n")
+		popup.WriteString(instrStyle.Render("space: Toggle  " + helpLine(m.keys.Select, m.keys.Cancel)))
+	}
+
+	popupStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Current.Primary).
+		Padding(1, 2).
+		Width(60)
+
+	return lipgloss.Place(
+		constants.WindowSize.Width,
+		constants.WindowSize.Height,
+		lipgloss.Center,
+		lipgloss.Center,
+		popupStyle.Render(popup.String()),
+		lipgloss.WithWhitespaceChars(" "),
+	)
+}
+
+func (m *releaseFormView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Select, m.keys.NextField, m.keys.PrevField, m.keys.Cancel}
+}
+
+func (m *releaseFormView) capturingInput() bool {
+	return !m.creating
+}
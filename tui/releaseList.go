@@ -0,0 +1,462 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+type releaseListView struct {
+	commonElements
+
+	// Service
+	sess *session
+
+	// Context
+	owner      string
+	repoName   string
+	mainBranch string
+
+	// State
+	showTags bool
+	releases []github.ReleaseInfo
+	tags     []github.TagInfo
+	loading  bool
+	listErr  error  // shown instead of the list
+	status   string // result of the last action
+
+	// Actions
+	confirmPublish *github.ReleaseInfo // draft waiting for the publication to be confirmed
+	uploadTo       *github.ReleaseInfo // release the typed path is uploaded to
+
+	// UI
+	EltList        table.Model
+	visibleCommand bool
+	keys           KeyMap
+}
+
+func (m *releaseListView) resizeMain(w int, h int) {
+	headerHeight := lipgloss.Height(m.RenderTopFields())
+	footerHeight := lipgloss.Height(m.RenderBottomFields())
+	cmdHeight := 0
+	if m.visibleCommand {
+		cmdHeight = 3
+	}
+	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2 - cmdHeight)
+	m.EltList = m.EltList.WithPageSize(h - headerHeight - footerHeight - 3 - cmdHeight)
+	constants.CommandStyle = constants.CommandStyle.Width(w - 2).Height(1)
+}
+
+// NewReleaseList creates a view listing the releases of a repository, or
+// its tags. New releases target mainBranch unless told otherwise.
+func NewReleaseList(sess *session, owner, repoName, mainBranch string) (tea.Model, tea.Cmd) {
+	m := &releaseListView{
+		sess:       sess,
+		owner:      owner,
+		repoName:   repoName,
+		mainBranch: mainBranch,
+		keys:       keyMapFor(viewReleaseList),
+	}
+	m.keys.Select = withDesc(m.keys.Select, "Notes")
+	m.keys.Create = withDesc(m.keys.Create, "New release")
+	m.keys.Scope = withDesc(m.keys.Scope, "Releases/Tags")
+
+	m.InitTop(owner, repoName, "Releases")
+	m.InitBottom()
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Select, m.keys.Create, m.keys.Upload, m.keys.Publish, m.keys.Scope, m.keys.Back, m.keys.Help)
+	m.CommandInput = textinput.New()
+
+	return m, m.load()
+}
+
+func (m *releaseListView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *releaseListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case releasesLoadedMsg:
+		if m.showTags {
+			// Loaded alongside the tags, to tell which have a release
+			if msg.Err == nil {
+				m.releases = msg.Releases
+				if !m.loading {
+					m.rebuild()
+				}
+			}
+			return m, nil
+		}
+		m.loading = false
+		m.releases, m.listErr = msg.Releases, msg.Err
+		m.rebuild()
+		return m, nil
+
+	case tagsLoadedMsg:
+		if !m.showTags {
+			return m, nil
+		}
+		m.loading = false
+		m.tags, m.listErr = msg.Tags, msg.Err
+		m.rebuild()
+		return m, nil
+
+	case releaseActionMsg:
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Could not %s %s: %v", msg.Action, msg.Tag, msg.Err)
+			if msg.Action == "upload" {
+				// The upload may follow the creation of the release
+				m.showTags = false
+				return m, m.load()
+			}
+			m.rebuild()
+			return m, nil
+		}
+		switch msg.Action {
+		case "create":
+			m.status = "Created release " + msg.Tag
+		case "publish":
+			m.status = "Published " + msg.Tag
+		default:
+			m.status = "Uploaded asset to " + msg.Tag
+		}
+		m.showTags = false
+		return m, m.load()
+
+	case tea.WindowSizeMsg:
+		constants.WindowSize = msg
+		m.resizeMain(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading {
+			if isQuit(msg, m.keys) {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		if m.visibleCommand {
+			return m.handleUploadInput(msg)
+		}
+
+		if m.confirmPublish != nil {
+			release := *m.confirmPublish
+			m.confirmPublish = nil
+			if key.Matches(msg, m.keys.Select) {
+				m.status = "Publishing " + release.Tag + "..."
+				m.rebuild()
+				return m, publishReleaseCmd(m.sess, m.owner, m.repoName, release)
+			}
+			m.status = ""
+			m.rebuild()
+			return m, nil
+		}
+
+		switch {
+		case isQuit(msg, m.keys):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return NewRepoView(m.sess, m.owner, m.repoName)
+		case key.Matches(msg, m.keys.Refresh):
+			m.status = ""
+			return m, m.load()
+		case key.Matches(msg, m.keys.Scope):
+			m.showTags = !m.showTags
+			m.status = ""
+			return m, m.load()
+		case key.Matches(msg, m.keys.Create):
+			return NewReleaseForm(m.sess, m.owner, m.repoName, "", m.mainBranch, m)
+		}
+
+		if m.showTags {
+			if tag, ok := m.highlightedTag(); ok && key.Matches(msg, m.keys.Select) {
+				if release, ok := m.releaseOf(tag.Name); ok {
+					return NewReleaseNotes(m.sess, m.owner, m.repoName, release, m)
+				}
+				// Tags without a release are the usual starting point of one
+				return NewReleaseForm(m.sess, m.owner, m.repoName, tag.Name, m.mainBranch, m)
+			}
+			break
+		}
+
+		release, ok := m.highlightedRelease()
+		if !ok {
+			break
+		}
+		switch {
+		case key.Matches(msg, m.keys.Select):
+			return NewReleaseNotes(m.sess, m.owner, m.repoName, release, m)
+		case key.Matches(msg, m.keys.Upload):
+			m.uploadTo = &release
+			m.CommandInput.Prompt = fmt.Sprintf("Upload to %s: ", release.Tag)
+			m.CommandInput.SetValue("")
+			m.CommandInput.Focus()
+			m.visibleCommand = true
+			m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+			return m, nil
+		case key.Matches(msg, m.keys.Publish):
+			if !release.Draft {
+				m.status = release.Tag + " is already published"
+			} else {
+				m.confirmPublish = &release
+				m.status = fmt.Sprintf("Publish %s? (%s) Confirm, any other key cancels", release.Tag, m.keys.Select.Keys()[0])
+			}
+			m.rebuild()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.EltList, cmd = m.EltList.Update(msg)
+	return m, cmd
+}
+
+// load reloads the releases or the tags
+func (m *releaseListView) load() tea.Cmd {
+	m.loading = true
+	m.listErr = nil
+	if m.showTags {
+		m.TopFields = []string{m.owner, m.repoName, "Tags"}
+		return tea.Batch(loadTagsCmd(m.sess, m.owner, m.repoName), loadReleasesCmd(m.sess, m.owner, m.repoName))
+	}
+	m.TopFields = []string{m.owner, m.repoName, "Releases"}
+	return loadReleasesCmd(m.sess, m.owner, m.repoName)
+}
+
+// handleUploadInput reads the path of the file to upload
+func (m *releaseListView) handleUploadInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeUploadInput()
+		return m, nil
+	case "enter":
+		path := strings.TrimSpace(m.CommandInput.Value())
+		if path == "" {
+			return m, nil
+		}
+		release := *m.uploadTo
+		m.closeUploadInput()
+		m.status = fmt.Sprintf("Uploading %s to %s...", path, release.Tag)
+		m.rebuild()
+		return m, uploadReleaseAssetCmd(m.sess, m.owner, m.repoName, release, path)
+	}
+
+	var cmd tea.Cmd
+	m.CommandInput, cmd = m.CommandInput.Update(msg)
+	return m, cmd
+}
+
+func (m *releaseListView) closeUploadInput() {
+	m.uploadTo = nil
+	m.visibleCommand = false
+	m.CommandInput.Blur()
+	m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+}
+
+func (m *releaseListView) highlightedRelease() (github.ReleaseInfo, bool) {
+	if m.listErr != nil || len(m.EltList.GetVisibleRows()) == 0 {
+		return github.ReleaseInfo{}, false
+	}
+	release, ok := m.EltList.HighlightedRow().Data["release"].(github.ReleaseInfo)
+	return release, ok
+}
+
+func (m *releaseListView) highlightedTag() (github.TagInfo, bool) {
+	if m.listErr != nil || len(m.EltList.GetVisibleRows()) == 0 {
+		return github.TagInfo{}, false
+	}
+	tag, ok := m.EltList.HighlightedRow().Data["tag"].(github.TagInfo)
+	return tag, ok
+}
+
+func (m *releaseListView) releaseOf(tag string) (github.ReleaseInfo, bool) {
+	for _, release := range m.releases {
+		if release.Tag == tag {
+			return release, true
+		}
+	}
+	return github.ReleaseInfo{}, false
+}
+
+// rebuild refreshes the header and the table after the list or the
+// status changed
+func (m *releaseListView) rebuild() {
+	title, count := "Releases", len(m.releases)
+	if m.showTags {
+		title, count = "Tags", len(m.tags)
+	}
+	m.TopFields = []string{m.owner, m.repoName, fmt.Sprintf("%s (%d)", title, count)}
+	if m.listErr != nil {
+		m.TopFields[2] = title
+	}
+	if m.status != "" {
+		m.TopFields = append(m.TopFields, m.status)
+	}
+
+	m.EltList = m.buildTable(m.EltList.GetHighlightedRowIndex())
+	if constants.WindowSize.Height != 0 {
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	}
+}
+
+func (m *releaseListView) View() string {
+	if m.loading {
+		if m.showTags {
+			return m.RenderTopFields() + "\n\nLoading tags..."
+		}
+		return m.RenderTopFields() + "\n\nLoading releases..."
+	}
+
+	var main string
+	switch {
+	case m.listErr != nil:
+		main = constants.ErrorStyle.Render(fmt.Sprintf("Could not load the %s: %v", strings.ToLower(m.TopFields[2]), m.listErr))
+	case len(m.EltList.GetVisibleRows()) == 0 && m.showTags:
+		main = "No tags."
+	case len(m.EltList.GetVisibleRows()) == 0:
+		main = fmt.Sprintf("No releases. Press %s to create one.", m.keys.Create.Help().Key)
+	default:
+		for i, row := range m.EltList.GetVisibleRows() {
+			row.Data["arrow"] = ""
+			if i == m.EltList.GetHighlightedRowIndex() {
+				row.Data["arrow"] = theme.Icons.Arrow
+			}
+		}
+		main = m.EltList.View()
+	}
+
+	if m.visibleCommand {
+		return fmt.Sprintf(
+			"%s\n%s\n%s\n%s",
+			m.RenderTopFields(),
+			constants.CommandStyle.BorderForeground(theme.Current.Accent).Render(m.CommandInput.View()),
+			constants.MainStyle.Render(main),
+			m.RenderBottomFields(),
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s",
+		m.RenderTopFields(),
+		constants.MainStyle.Render(main),
+		m.RenderBottomFields(),
+	)
+}
+
+func (m *releaseListView) buildTable(highlighted int) table.Model {
+	var columns []table.Column
+	rows := []table.Row{}
+	if m.showTags {
+		columns = []table.Column{
+			table.NewColumn("arrow", " ", 3),
+			table.NewColumn("name", "Tag", 30),
+			table.NewColumn("sha", "Commit", 10),
+			table.NewColumn("release", "Release", 50),
+		}
+		for _, tag := range m.tags {
+			name := ""
+			if release, ok := m.releaseOf(tag.Name); ok {
+				name = releaseTitle(release)
+			}
+			rows = append(rows, table.NewRow(table.RowData{
+				"arrow":   "",
+				"name":    tag.Name,
+				"sha":     shortSHA(tag.SHA),
+				"release": name,
+				"tag":     tag,
+			}))
+		}
+	} else {
+		columns = []table.Column{
+			table.NewColumn("arrow", " ", 3),
+			table.NewColumn("tag", "Tag", 16),
+			table.NewColumn("name", "Name", 26),
+			table.NewColumn("flags", "Flags", 20),
+			table.NewColumn("assets", "Assets", 7),
+			table.NewColumn("downloads", "Downloads", 10),
+			table.NewColumn("published", "Published", 17),
+			table.NewColumn("author", "Author", 13),
+		}
+		latest := latestRelease(m.releases)
+		for i, release := range m.releases {
+			downloads := 0
+			for _, asset := range release.Assets {
+				downloads += asset.Downloads
+			}
+			published := "-"
+			if !release.PublishedAt.IsZero() {
+				published = release.PublishedAt.Format("2006-01-02 15:04")
+			}
+			rows = append(rows, table.NewRow(table.RowData{
+				"arrow":     "",
+				"tag":       release.Tag,
+				"name":      release.Name,
+				"flags":     releaseFlags(release, i == latest),
+				"assets":    fmt.Sprintf("%d", len(release.Assets)),
+				"downloads": fmt.Sprintf("%d", downloads),
+				"published": published,
+				"author":    release.Author,
+				"release":   release,
+			}))
+		}
+	}
+
+	return table.New(columns).WithRows(rows).
+		WithKeyMap(m.keys.tableKeyMap()).
+		Focused(true).
+		Border(table.Border{}).
+		WithBaseStyle(constants.BaseTableStyle).
+		HighlightStyle(constants.HighlightedLineStyle).
+		WithFooterVisibility(false).
+		WithHighlightedRow(min(highlighted, max(len(rows)-1, 0)))
+}
+
+// latestRelease returns the index of the release GitHub shows as latest:
+// the most recent one which is neither a draft nor a pre-release
+func latestRelease(releases []github.ReleaseInfo) int {
+	for i, release := range releases {
+		if !release.Draft && !release.Prerelease {
+			return i
+		}
+	}
+	return -1
+}
+
+func releaseFlags(release github.ReleaseInfo, latest bool) string {
+	var flags []string
+	if release.Draft {
+		flags = append(flags, "draft")
+	}
+	if release.Prerelease {
+		flags = append(flags, "pre-release")
+	}
+	if latest {
+		flags = append(flags, "latest")
+	}
+	return strings.Join(flags, ", ")
+}
+
+// releaseTitle names a release by its name, or its tag when unnamed
+func releaseTitle(release github.ReleaseInfo) string {
+	if release.Name != "" {
+		return release.Name
+	}
+	return release.Tag
+}
+
+func (m *releaseListView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Select, m.keys.Create, m.keys.Upload, m.keys.Publish, m.keys.Scope, m.keys.Refresh, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
+
+func (m *releaseListView) capturingInput() bool {
+	return m.visibleCommand
+}
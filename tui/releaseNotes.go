@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+	"github.com/muesli/termenv"
+)
+
+type releaseNotesView struct {
+	commonElements
+
+	// Service
+	sess *session
+
+	// Context
+	owner    string
+	repoName string
+	release  github.ReleaseInfo
+
+	// UI
+	rendered []string // lines of the notes, rendered for the current width
+	offset   int      // first line shown
+	keys     KeyMap
+
+	// Navigation
+	parentView tea.Model
+}
+
+func (m *releaseNotesView) resizeMain(w int, h int) {
+	headerHeight := lipgloss.Height(m.RenderTopFields())
+	footerHeight := lipgloss.Height(m.RenderBottomFields())
+	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2)
+	m.rendered = m.lines(w - 4)
+	m.scroll(0)
+}
+
+// NewReleaseNotes creates a view showing the notes and the assets of a
+// release. Back returns to parentView.
+func NewReleaseNotes(sess *session, owner, repoName string, release github.ReleaseInfo, parentView tea.Model) (tea.Model, tea.Cmd) {
+	m := &releaseNotesView{
+		sess:       sess,
+		owner:      owner,
+		repoName:   repoName,
+		release:    release,
+		parentView: parentView,
+		keys:       keyMapFor(viewReleaseNotes),
+	}
+
+	m.InitTop(owner, repoName, "Release "+release.Tag)
+	m.TopFields = []string{owner, repoName, "Release " + release.Tag}
+	m.InitBottom()
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Back, m.keys.Help)
+
+	if constants.WindowSize.Height != 0 {
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	}
+
+	return m, nil
+}
+
+func (m *releaseNotesView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *releaseNotesView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case tea.WindowSizeMsg:
+		constants.WindowSize = msg
+		m.resizeMain(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case isQuit(msg, m.keys):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return m.parentView, m.parentView.Init()
+		case key.Matches(msg, m.keys.Down):
+			m.scroll(1)
+		case key.Matches(msg, m.keys.Up):
+			m.scroll(-1)
+		case key.Matches(msg, m.keys.PageDown):
+			m.scroll(m.pageHeight())
+		case key.Matches(msg, m.keys.PageUp):
+			m.scroll(-m.pageHeight())
+		}
+	}
+
+	return m, nil
+}
+
+func (m *releaseNotesView) pageHeight() int {
+	return max(constants.MainStyle.GetHeight(), 1)
+}
+
+func (m *releaseNotesView) scroll(delta int) {
+	m.offset = max(min(m.offset+delta, len(m.rendered)-m.pageHeight()), 0)
+}
+
+// lines renders the release header, its notes and its assets
+func (m *releaseNotesView) lines(width int) []string {
+	r := m.release
+	emphasis := lipgloss.NewStyle().Bold(true).Foreground(theme.Current.Emphasis)
+	muted := lipgloss.NewStyle().Foreground(theme.Current.Muted)
+
+	title := releaseTitle(r)
+	if flags := releaseFlags(r, false); flags != "" {
+		title += "  " + muted.Render("("+flags+")")
+	}
+	published := "not published"
+	if !r.PublishedAt.IsZero() {
+		published = "published " + r.PublishedAt.Format("2006-01-02 15:04")
+	}
+	lines := []string{
+		emphasis.Render(title),
+		muted.Render(fmt.Sprintf("%s by %s, created %s, %s", r.Tag, r.Author, r.CreatedAt.Format("2006-01-02 15:04"), published)),
+		"",
+	}
+
+	if strings.TrimSpace(r.Body) == "" {
+		lines = append(lines, muted.Render("No release notes."))
+	} else {
+		lines = append(lines, strings.Split(renderMarkdown(r.Body, width), "\n")...)
+	}
+
+	lines = append(lines, "", emphasis.Render(fmt.Sprintf("Assets (%d)", len(r.Assets))))
+	for _, asset := range r.Assets {
+		lines = append(lines, fmt.Sprintf("%-50s %10s  %s", asset.Name, formatSize(int64(asset.Size)), muted.Render(fmt.Sprintf("%d download(s)", asset.Downloads))))
+	}
+	if len(r.Assets) == 0 {
+		lines = append(lines, muted.Render("No assets"))
+	}
+	return lines
+}
+
+// renderMarkdown renders markdown for the terminal, falling back to the
+// source when it cannot be rendered
+func renderMarkdown(source string, width int) string {
+	style := "light"
+	switch {
+	case lipgloss.ColorProfile() == termenv.Ascii:
+		style = "notty"
+	case lipgloss.HasDarkBackground():
+		style = "dark"
+	}
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStandardStyle(style),
+		glamour.WithWordWrap(max(width, 20)),
+		glamour.WithColorProfile(lipgloss.ColorProfile()),
+	)
+	if err == nil {
+		var out string
+		if out, err = renderer.Render(source); err == nil {
+			return strings.Trim(out, "\n")
+		}
+	}
+	slog.Debug("Rendering markdown failed", "error", err)
+	return source
+}
+
+// formatSize renders a size in bytes with a binary unit, e.g. "1.5 MiB"
+func formatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+func (m *releaseNotesView) View() string {
+	end := min(m.offset+m.pageHeight(), len(m.rendered))
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s",
+		m.RenderTopFields(),
+		constants.MainStyle.Render(strings.Join(m.rendered[m.offset:end], "\n")),
+		m.RenderBottomFields(),
+	)
+}
+
+func (m *releaseNotesView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
@@ -4,19 +4,21 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/evertras/bubble-table/table"
 	"github.com/jjournet/tgr/github"
 	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
 )
 
 type repoSelection struct {
 	commonElements
 
 	// Service
-	ghService *github.GitHubService
+	sess *session
 
 	// Context
 	owner  string
@@ -30,6 +32,7 @@ type repoSelection struct {
 	// UI
 	RepoList       table.Model
 	visibleCommand bool
+	keys           KeyMap
 }
 
 func (m *repoSelection) resizeMain(w int, h int) {
@@ -46,24 +49,25 @@ func (m *repoSelection) resizeMain(w int, h int) {
 }
 
 // NewRepoSelection creates a new repository selection model
-func NewRepoSelection(ghService *github.GitHubService, owner string, isUser bool) (tea.Model, tea.Cmd) {
+func NewRepoSelection(sess *session, owner string, isUser bool) (tea.Model, tea.Cmd) {
 	m := &repoSelection{
-		ghService: ghService,
-		owner:     owner,
-		isUser:    isUser,
-		loading:   true,
+		sess:    sess,
+		owner:   owner,
+		isUser:  isUser,
+		loading: true,
+		keys:    keyMapFor(viewRepoSelection),
 	}
 
 	m.InitTop("Repository Selection", owner)
 	m.TopFields = []string{owner, "Repository Selection", "(Loading...)"}
 	m.InitBottom()
-	m.BottomFields = []string{"(q) Quit", "(enter) Select", "(/) Filter", "(backspace) Back", "Page: ?"}
+	m.BottomFields = append(footerFields(m.keys.Quit, m.keys.Select, m.keys.Filter, m.keys.Pin, m.keys.Back, m.keys.Help), "Page: ?")
 
 	m.visibleCommand = false
 	m.CommandInput = textinput.New()
 
 	// Load repos asynchronously
-	return m, ghService.LoadReposCmd(owner, isUser)
+	return m, loadReposCmd(sess, owner, isUser)
 }
 
 func (m *repoSelection) Init() tea.Cmd {
@@ -75,7 +79,7 @@ func (m *repoSelection) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 
-	case github.ReposLoadedMsg:
+	case reposLoadedMsg:
 		if msg.Err != nil {
 			m.err = msg.Err
 			m.loading = false
@@ -102,7 +106,7 @@ func (m *repoSelection) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		if m.loading {
-			if msg.String() == "q" || msg.String() == "ctrl+c" {
+			if isQuit(msg, m.keys) {
 				return m, tea.Quit
 			}
 			return m, nil
@@ -114,19 +118,29 @@ func (m *repoSelection) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Normal key handling
-		switch msg.String() {
-		case "q", "ctrl+c":
+		switch {
+		case isQuit(msg, m.keys):
 			return m, tea.Quit
-		case "enter":
+		case key.Matches(msg, m.keys.Select):
+			if len(m.RepoList.GetVisibleRows()) == 0 {
+				return m, nil
+			}
+			repoName := m.RepoList.HighlightedRow().Data["repo"].(string)
+			return NewRepoView(m.sess, m.owner, repoName)
+		case key.Matches(msg, m.keys.Pin):
+			if len(m.RepoList.GetVisibleRows()) == 0 {
+				return m, nil
+			}
 			repoName := m.RepoList.HighlightedRow().Data["repo"].(string)
-			return NewRepoView(m.ghService, m.owner, repoName)
-		case "/":
+			m.TopFields[2] = m.sess.togglePin(m.owner + "/" + repoName)
+			return m, nil
+		case key.Matches(msg, m.keys.Filter):
 			m.visibleCommand = true
 			m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
 			m.CommandInput.Focus()
 			return m, nil
-		case "backspace":
-			return NewProfileSelection(m.ghService)
+		case key.Matches(msg, m.keys.Back):
+			return NewProfileSelection(m.sess)
 		default:
 			slog.Debug("Update: default case", "key", msg.String())
 			var cmd tea.Cmd
@@ -195,21 +209,25 @@ func (m *repoSelection) View() string {
 		return m.RenderTopFields() + "\n\nLoading repositories..."
 	}
 
-	// Update arrows
+	// Update arrows and pins
 	for i, row := range m.RepoList.GetVisibleRows() {
 		row.Data["arrow"] = ""
 		if i == m.RepoList.GetHighlightedRowIndex() {
-			row.Data["arrow"] = "\uf0a9"
+			row.Data["arrow"] = theme.Icons.Arrow
+		}
+		row.Data["pin"] = ""
+		if m.sess.isFavorite(m.owner + "/" + row.Data["repo"].(string)) {
+			row.Data["pin"] = theme.Icons.Pinned
 		}
 	}
 
-	m.BottomFields[4] = fmt.Sprintf("Page: %d/%d", m.RepoList.CurrentPage(), m.RepoList.MaxPages())
+	m.BottomFields[len(m.BottomFields)-1] = fmt.Sprintf("Page: %d/%d", m.RepoList.CurrentPage(), m.RepoList.MaxPages())
 
 	if m.visibleCommand {
 		return fmt.Sprintf(
 			"%s\n%s\n%s\n%s",
 			m.RenderTopFields(),
-			constants.CommandStyle.BorderForeground(lipgloss.Color("#77c2f9")).Render(m.CommandInput.View()),
+			constants.CommandStyle.BorderForeground(theme.Current.Accent).Render(m.CommandInput.View()),
 			constants.MainStyle.Render(m.RepoList.View()),
 			m.RenderBottomFields(),
 		)
@@ -218,7 +236,7 @@ func (m *repoSelection) View() string {
 	return fmt.Sprintf(
 		"%s\n%s\n%s",
 		m.RenderTopFields(),
-		constants.MainStyle.BorderForeground(lipgloss.Color("#77c2f9")).Render(m.RepoList.View()),
+		constants.MainStyle.BorderForeground(theme.Current.Accent).Render(m.RepoList.View()),
 		m.RenderBottomFields(),
 	)
 }
@@ -226,6 +244,7 @@ func (m *repoSelection) View() string {
 func (m *repoSelection) buildRepoTable(repos []github.RepoInfo) table.Model {
 	columns := []table.Column{
 		table.NewColumn("arrow", " ", 3),
+		table.NewColumn("pin", " ", 3),
 		table.NewColumn("repo", "Repository", 40).WithFiltered(true),
 		table.NewColumn("desc", "Description", 100),
 	}
@@ -239,6 +258,7 @@ func (m *repoSelection) buildRepoTable(repos []github.RepoInfo) table.Model {
 	}
 
 	return table.New(columns).WithRows(rows).
+		WithKeyMap(m.keys.tableKeyMap()).
 		Focused(true).
 		Border(table.Border{}).
 		WithBaseStyle(constants.BaseTableStyle).
@@ -247,3 +267,11 @@ func (m *repoSelection) buildRepoTable(repos []github.RepoInfo) table.Model {
 		WithFooterVisibility(false).
 		WithHighlightedRow(0)
 }
+
+func (m *repoSelection) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Select, m.keys.Filter, m.keys.Pin, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
+
+func (m *repoSelection) capturingInput() bool {
+	return m.visibleCommand
+}
@@ -2,12 +2,15 @@ package tui
 
 import (
 	"fmt"
+	"sort"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/evertras/bubble-table/table"
 	"github.com/jjournet/tgr/github"
 	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
 	"github.com/jjournet/tgr/types"
 )
 
@@ -15,7 +18,7 @@ type repoView struct {
 	commonElements
 
 	// Service
-	ghService *github.GitHubService
+	sess *session
 
 	// Context
 	owner    string
@@ -30,6 +33,7 @@ type repoView struct {
 
 	// UI
 	EltList table.Model
+	keys    KeyMap
 }
 
 func (m *repoView) resizeMain(w int, h int) {
@@ -39,24 +43,27 @@ func (m *repoView) resizeMain(w int, h int) {
 }
 
 // NewRepoView creates a new repository view model
-func NewRepoView(ghService *github.GitHubService, owner, repoName string) (tea.Model, tea.Cmd) {
+func NewRepoView(sess *session, owner, repoName string) (tea.Model, tea.Cmd) {
 	m := &repoView{
-		ghService: ghService,
-		owner:     owner,
-		repoName:  repoName,
-		loading:   true,
+		sess:     sess,
+		owner:    owner,
+		repoName: repoName,
+		loading:  true,
+		keys:     keyMapFor(viewRepoSummary),
 	}
 
 	m.InitTop(owner, repoName, "Loading...")
 	m.TopFields = []string{owner, repoName, "Repository Summary"}
 	m.InitBottom()
-	m.BottomFields = []string{"(q) Quit", "(enter) Select", "(backspace) Back"}
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Select, m.keys.Back, m.keys.Help)
+
+	m.sess.visitRepo(owner + "/" + repoName)
 
 	// Load repo details and workflows asynchronously
 	return m, tea.Batch(
-		ghService.LoadRepoDetailsCmd(owner, repoName),
-		ghService.LoadWorkflowsCmd(owner, repoName),
-		ghService.LoadIssuesCmd(owner, repoName),
+		loadRepoDetailsCmd(sess, owner, repoName),
+		loadWorkflowsCmd(sess, owner, repoName),
+		loadIssuesCmd(sess, owner, repoName),
 	)
 }
 
@@ -67,7 +74,7 @@ func (m *repoView) Init() tea.Cmd {
 func (m *repoView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
-	case github.RepoDetailsLoadedMsg:
+	case repoDetailsLoadedMsg:
 		if msg.Err != nil {
 			m.err = msg.Err
 			m.loading = false
@@ -77,7 +84,7 @@ func (m *repoView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.checkLoadingComplete()
 		return m, nil
 
-	case github.WorkflowsLoadedMsg:
+	case workflowsLoadedMsg:
 		if msg.Err != nil {
 			m.err = msg.Err
 			m.loading = false
@@ -87,7 +94,7 @@ func (m *repoView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.checkLoadingComplete()
 		return m, nil
 
-	case github.IssuesLoadedMsg:
+	case issuesLoadedMsg:
 		if msg.Err != nil {
 			m.err = msg.Err
 			m.loading = false
@@ -104,25 +111,46 @@ func (m *repoView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		if m.loading {
-			if msg.String() == "q" || msg.String() == "ctrl+c" {
+			if isQuit(msg, m.keys) {
 				return m, tea.Quit
 			}
 			return m, nil
 		}
 
-		switch msg.String() {
-		case "q", "ctrl+c":
+		switch {
+		case isQuit(msg, m.keys):
 			return m, tea.Quit
-		case "backspace":
-			return NewRepoSelection(m.ghService, m.owner, true) // TODO: track isUser properly
-		case "enter":
+		case key.Matches(msg, m.keys.Back):
+			return NewRepoSelection(m.sess, m.owner, true) // TODO: track isUser properly
+		case key.Matches(msg, m.keys.Select):
 			// get the selected option
 			row := m.EltList.HighlightedRow()
+			if row.Data["id"] == types.PROJECT {
+				return NewProjectList(m.sess, m.owner, m.repoName)
+			}
 			if row.Data["id"] == types.WORKFLOW {
-				return NewWorkflowList(m.ghService, m.owner, m.repoName)
+				return NewWorkflowList(m.sess, m.owner, m.repoName)
 			}
 			if row.Data["id"] == types.ISSUE {
-				return NewIssueList(m.ghService, m.owner, m.repoName)
+				return NewIssueList(m.sess, m.owner, m.repoName)
+			}
+			if row.Data["id"] == types.BRANCH {
+				return NewBranchList(m.sess, m.owner, m.repoName, m.repoDetails.MainBranch)
+			}
+			if row.Data["id"] == types.ENVIRONMENT {
+				return NewEnvironmentList(m.sess, m.owner, m.repoName)
+			}
+			if row.Data["id"] == types.VARIABLE {
+				return NewVariableList(m.sess, m.owner, m.repoName)
+			}
+			if row.Data["id"] == types.RELEASE {
+				return NewReleaseList(m.sess, m.owner, m.repoName, m.repoDetails.MainBranch)
+			}
+			if row.Data["id"] == types.CACHE {
+				return NewCacheList(m.sess, m.owner, m.repoName)
+			}
+			if row.Data["id"] == types.RUNNER {
+				return NewRunnerList(m.sess, m.owner, m.repoName)
 			}
 		}
 	}
@@ -159,7 +187,7 @@ func (m *repoView) View() string {
 	for i, row := range m.EltList.GetVisibleRows() {
 		row.Data["arrow"] = ""
 		if i == m.EltList.GetHighlightedRowIndex() {
-			row.Data["arrow"] = "\uf0a9"
+			row.Data["arrow"] = theme.Icons.Arrow
 		}
 	}
 	return fmt.Sprintf(
@@ -183,7 +211,7 @@ func (m *repoView) buildSummaryListModel() table.Model {
 	items = append(items, table.NewRow(table.RowData{
 		"indicator": "",
 		"type":      types.ConvertRepoElementType(types.PROJECT),
-		"value":     m.repoName,
+		"value":     fmt.Sprintf("Projects linked to %s", m.repoName),
 		"id":        types.PROJECT,
 	}))
 
@@ -211,10 +239,70 @@ func (m *repoView) buildSummaryListModel() table.Model {
 		"id":        types.ISSUE,
 	}))
 
+	// Display Branches
+	items = append(items, table.NewRow(table.RowData{
+		"indicator": "",
+		"type":      types.ConvertRepoElementType(types.BRANCH),
+		"value":     fmt.Sprintf("Default branch: %s", m.repoDetails.MainBranch),
+		"id":        types.BRANCH,
+	}))
+
+	// Display Environments
+	items = append(items, table.NewRow(table.RowData{
+		"indicator": "",
+		"type":      types.ConvertRepoElementType(types.ENVIRONMENT),
+		"value":     "Deployment environments",
+		"id":        types.ENVIRONMENT,
+	}))
+
+	// Display Variables
+	items = append(items, table.NewRow(table.RowData{
+		"indicator": "",
+		"type":      types.ConvertRepoElementType(types.VARIABLE),
+		"value":     "Actions variables and secrets",
+		"id":        types.VARIABLE,
+	}))
+
+	// Display Releases
+	items = append(items, table.NewRow(table.RowData{
+		"indicator": "",
+		"type":      types.ConvertRepoElementType(types.RELEASE),
+		"value":     "Releases and tags",
+		"id":        types.RELEASE,
+	}))
+
+	// Display Caches
+	items = append(items, table.NewRow(table.RowData{
+		"indicator": "",
+		"type":      types.ConvertRepoElementType(types.CACHE),
+		"value":     "Actions caches",
+		"id":        types.CACHE,
+	}))
+
+	// Display Runners
+	items = append(items, table.NewRow(table.RowData{
+		"indicator": "",
+		"type":      types.ConvertRepoElementType(types.RUNNER),
+		"value":     "Self-hosted runners",
+		"id":        types.RUNNER,
+	}))
+
 	// Display Languages
+	// Largest language first, so the line is stable between renders
+	names := make([]string, 0, len(m.repoDetails.Languages))
+	for lang := range m.repoDetails.Languages {
+		names = append(names, lang)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		li, lj := m.repoDetails.Languages[names[i]], m.repoDetails.Languages[names[j]]
+		if li != lj {
+			return li > lj
+		}
+		return names[i] < names[j]
+	})
 	var langs string
-	for lang, size := range m.repoDetails.Languages {
-		langs += fmt.Sprintf("%s (%d) ", lang, size)
+	for _, lang := range names {
+		langs += fmt.Sprintf("%s (%d) ", lang, m.repoDetails.Languages[lang])
 	}
 	items = append(items, table.NewRow(table.RowData{
 		"indicator": "",
@@ -224,6 +312,7 @@ func (m *repoView) buildSummaryListModel() table.Model {
 	}))
 
 	return table.New(columns).WithRows(items).
+		WithKeyMap(m.keys.tableKeyMap()).
 		Focused(true).
 		Border(table.Border{}).
 		WithBaseStyle(constants.BaseTableStyle).
@@ -232,3 +321,7 @@ func (m *repoView) buildSummaryListModel() table.Model {
 		WithHeaderVisibility(false).
 		WithHighlightedRow(0)
 }
+
+func (m *repoView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Select, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.Quit}
+}
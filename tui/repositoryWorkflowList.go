@@ -2,12 +2,19 @@ package tui
 
 import (
 	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/evertras/bubble-table/table"
 	"github.com/jjournet/tgr/github"
 	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
 	"github.com/jjournet/tgr/types"
 )
 
@@ -15,19 +22,24 @@ type repoWorkflowListView struct {
 	commonElements
 
 	// Service
-	ghService *github.GitHubService
+	sess *session
 
 	// Context
 	owner    string
 	repoName string
 
 	// State
-	workflows []github.WorkflowInfo
-	loading   bool
-	err       error
+	workflows   []github.WorkflowInfo
+	dispatch    map[int64]bool // whether a workflow has a workflow_dispatch trigger, when known
+	usage       map[int64]*github.WorkflowUsage
+	summaryErrs map[int64]error // why the summary of a workflow is incomplete
+	loading     bool
+	err         error
+	status      string // result of the last action
 
 	// UI
 	EltList table.Model
+	keys    KeyMap
 }
 
 func (m *repoWorkflowListView) resizeMain(w int, h int) {
@@ -37,21 +49,23 @@ func (m *repoWorkflowListView) resizeMain(w int, h int) {
 }
 
 // NewWorkflowList creates a new workflow list view model
-func NewWorkflowList(ghService *github.GitHubService, owner, repoName string) (tea.Model, tea.Cmd) {
+func NewWorkflowList(sess *session, owner, repoName string) (tea.Model, tea.Cmd) {
 	m := &repoWorkflowListView{
-		ghService: ghService,
-		owner:     owner,
-		repoName:  repoName,
-		loading:   true,
+		sess:     sess,
+		owner:    owner,
+		repoName: repoName,
+		loading:  true,
+		keys:     keyMapFor(viewWorkflowList),
 	}
+	m.keys.Select = withDesc(m.keys.Select, "View Runs")
 
 	m.InitTop(owner, repoName, "Workflow List")
 	m.TopFields = []string{owner, repoName, "Loading workflows..."}
 	m.InitBottom()
-	m.BottomFields = []string{"(q) Quit", "(enter) View Runs", "(t) Trigger", "(backspace) Back"}
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Select, m.keys.Trigger, m.keys.Enable, m.keys.Source, m.keys.Analytics, m.keys.Back, m.keys.Help)
 
 	// Load workflows asynchronously
-	return m, ghService.LoadWorkflowsCmd(owner, repoName)
+	return m, loadWorkflowsCmd(sess, owner, repoName)
 }
 
 func (m *repoWorkflowListView) Init() tea.Cmd {
@@ -61,7 +75,7 @@ func (m *repoWorkflowListView) Init() tea.Cmd {
 func (m *repoWorkflowListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
-	case github.WorkflowsLoadedMsg:
+	case workflowsLoadedMsg:
 		if msg.Err != nil {
 			m.err = msg.Err
 			m.loading = false
@@ -69,16 +83,45 @@ func (m *repoWorkflowListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		m.workflows = msg.Workflows
+		m.dispatch = map[int64]bool{}
+		m.usage = map[int64]*github.WorkflowUsage{}
+		m.summaryErrs = map[int64]error{}
 		m.loading = false
-		m.TopFields[2] = fmt.Sprintf("Workflow List (%d workflows)", len(m.workflows))
+		m.rebuild()
 
-		// Build UI table
-		m.EltList = m.buildWorkflowListModel()
+		// Triggers and usage come later, they need a call per workflow
+		return m, loadWorkflowSummariesCmd(m.sess, m.owner, m.repoName, m.workflows)
 
-		if constants.WindowSize.Height != 0 {
-			m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	case workflowSummaryLoadedMsg:
+		if msg.Dispatch != nil {
+			m.dispatch[msg.WorkflowID] = *msg.Dispatch
 		}
+		if msg.Usage != nil {
+			m.usage[msg.WorkflowID] = msg.Usage
+		}
+		if msg.Err != nil {
+			slog.Debug("Loading workflow summary failed", "workflow", msg.WorkflowID, "error", msg.Err)
+			m.summaryErrs[msg.WorkflowID] = msg.Err
+		}
+		m.rebuild()
+		return m, nil
 
+	case workflowStateChangedMsg:
+		i := slices.IndexFunc(m.workflows, func(w github.WorkflowInfo) bool { return w.ID == msg.WorkflowID })
+		if i < 0 {
+			return m, nil
+		}
+		switch {
+		case msg.Err != nil:
+			m.status = fmt.Sprintf("Could not change %s: %v", m.workflows[i].Name, msg.Err)
+		case msg.Enabled:
+			m.workflows[i].State = "active"
+			m.status = "Enabled " + m.workflows[i].Name
+		default:
+			m.workflows[i].State = "disabled_manually"
+			m.status = "Disabled " + m.workflows[i].Name
+		}
+		m.rebuild()
 		return m, nil
 
 	case tea.WindowSizeMsg:
@@ -88,31 +131,57 @@ func (m *repoWorkflowListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		if m.loading {
-			if msg.String() == "q" || msg.String() == "ctrl+c" {
+			if isQuit(msg, m.keys) {
 				return m, tea.Quit
 			}
 			return m, nil
 		}
 
-		switch msg.String() {
-		case "q", "ctrl+c":
+		switch {
+		case isQuit(msg, m.keys):
 			return m, tea.Quit
-		case "backspace":
-			return NewRepoView(m.ghService, m.owner, m.repoName)
-		case "enter":
+		case key.Matches(msg, m.keys.Back):
+			return NewRepoView(m.sess, m.owner, m.repoName)
+		case key.Matches(msg, m.keys.Select):
 			// get the selected option
 			row := m.EltList.HighlightedRow()
 			if row.Data["type"] == types.WORKFLOW {
 				workflowID := row.Data["id"].(int64)
-				return NewWorkflowRunList(m.ghService, m.owner, m.repoName, workflowID)
+				return NewWorkflowRunList(m.sess, m.owner, m.repoName, workflowID)
 			}
-		case "t", "T":
+		case key.Matches(msg, m.keys.Trigger):
 			// Trigger the selected workflow
 			row := m.EltList.HighlightedRow()
 			if row.Data["type"] == types.WORKFLOW {
 				workflowID := row.Data["id"].(int64)
 				workflowPath := row.Data["path"].(string)
-				return NewWorkflowInputForm(m.ghService, m.owner, m.repoName, workflowID, workflowPath, m)
+				if dispatch, known := m.dispatch[workflowID]; known && !dispatch {
+					m.status = fmt.Sprintf("%s has no workflow_dispatch trigger", row.Data["workflow"])
+					m.rebuild()
+					return m, nil
+				}
+				return NewWorkflowInputForm(m.sess, m.owner, m.repoName, workflowID, workflowPath, m)
+			}
+		case key.Matches(msg, m.keys.Enable):
+			row := m.EltList.HighlightedRow()
+			if workflow, ok := row.Data["workflowInfo"].(github.WorkflowInfo); ok {
+				enable := workflow.State != "active"
+				m.status = "Disabling " + workflow.Name + "..."
+				if enable {
+					m.status = "Enabling " + workflow.Name + "..."
+				}
+				m.rebuild()
+				return m, setWorkflowEnabledCmd(m.sess, m.owner, m.repoName, workflow.ID, enable)
+			}
+		case key.Matches(msg, m.keys.Source):
+			row := m.EltList.HighlightedRow()
+			if workflow, ok := row.Data["workflowInfo"].(github.WorkflowInfo); ok {
+				return NewWorkflowSource(m.sess, m.owner, m.repoName, workflow, m)
+			}
+		case key.Matches(msg, m.keys.Analytics):
+			row := m.EltList.HighlightedRow()
+			if workflow, ok := row.Data["workflowInfo"].(github.WorkflowInfo); ok {
+				return NewWorkflowAnalytics(m.sess, m.owner, m.repoName, workflow, m)
 			}
 		}
 	}
@@ -126,6 +195,45 @@ func (m *repoWorkflowListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// rebuild refreshes the header and the table after the list, the
+// summaries or the status changed
+func (m *repoWorkflowListView) rebuild() {
+	m.TopFields = []string{m.owner, m.repoName, fmt.Sprintf("Workflow List (%d workflows)", len(m.workflows))}
+	if m.status != "" {
+		m.TopFields = append(m.TopFields, m.status)
+	}
+	if len(m.summaryErrs) > 0 {
+		m.TopFields = append(m.TopFields, fmt.Sprintf("Summary incomplete for %d workflow(s)", len(m.summaryErrs)))
+	}
+
+	m.EltList = m.buildWorkflowListModel(m.EltList.GetHighlightedRowIndex())
+	if constants.WindowSize.Height != 0 {
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	}
+}
+
+// formatBillable renders the billable time of a workflow per runner OS,
+// e.g. "Ubuntu 1h12m, macOS 8m"
+func formatBillable(usage *github.WorkflowUsage) string {
+	if usage == nil {
+		return ""
+	}
+	if len(usage.Billable) == 0 {
+		return "none"
+	}
+	names := map[string]string{"UBUNTU": "Ubuntu", "MACOS": "macOS", "WINDOWS": "Windows"}
+	var parts []string
+	for _, os := range slices.Sorted(maps.Keys(usage.Billable)) {
+		name, ok := names[os]
+		if !ok {
+			name = os
+		}
+		d := usage.Billable[os].Round(time.Minute)
+		parts = append(parts, name+" "+strings.TrimSuffix(d.String(), "0s"))
+	}
+	return strings.Join(parts, ", ")
+}
+
 func (m *repoWorkflowListView) View() string {
 	if m.err != nil {
 		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit or 'backspace' to go back", m.err)
@@ -138,7 +246,7 @@ func (m *repoWorkflowListView) View() string {
 	for i, row := range m.EltList.GetVisibleRows() {
 		row.Data["arrow"] = ""
 		if i == m.EltList.GetHighlightedRowIndex() {
-			row.Data["arrow"] = "\uf0a9"
+			row.Data["arrow"] = theme.Icons.Arrow
 		}
 	}
 
@@ -150,31 +258,49 @@ func (m *repoWorkflowListView) View() string {
 	)
 }
 
-func (m *repoWorkflowListView) buildWorkflowListModel() table.Model {
+func (m *repoWorkflowListView) buildWorkflowListModel(highlighted int) table.Model {
 	columns := []table.Column{
 		table.NewColumn("arrow", " ", 3),
 		table.NewColumn("workflow", "Workflow", 30),
-		table.NewColumn("state", "State", 100),
-		table.NewColumn("id", "ID", 10),
-		table.NewColumn("type", "Type", 10),
+		table.NewColumn("state", "State", 20),
+		table.NewColumn("dispatch", "Dispatch", 10),
+		table.NewColumn("billable", "Billable this cycle", 36),
+		table.NewColumn("id", "ID", 12),
 	}
 
 	rows := []table.Row{}
 	for _, workflow := range m.workflows {
+		dispatch := ""
+		if m.dispatch[workflow.ID] {
+			dispatch = theme.Icons.Dispatch
+		}
+		var billable any = formatBillable(m.usage[workflow.ID])
+		if err, ok := m.summaryErrs[workflow.ID]; ok {
+			billable = table.NewStyledCell(err.Error(), constants.ErrorStyle)
+		}
 		rows = append(rows, table.NewRow(table.RowData{
-			"workflow": workflow.Name,
-			"state":    workflow.State,
-			"id":       workflow.ID,
-			"type":     types.WORKFLOW,
-			"path":     workflow.Path,
+			"workflow":     workflow.Name,
+			"state":        workflow.State,
+			"dispatch":     dispatch,
+			"billable":     billable,
+			"id":           workflow.ID,
+			"type":         types.WORKFLOW,
+			"path":         workflow.Path,
+			"workflowInfo": workflow,
 		}))
 	}
 
 	noBorder := table.Border{}
 	return table.New(columns).WithRows(rows).
+		WithKeyMap(m.keys.tableKeyMap()).
 		Focused(true).
 		Border(noBorder).
 		WithBaseStyle(constants.BaseTableStyle).
 		HighlightStyle(constants.HighlightedLineStyle).
-		Filtered(true)
+		Filtered(true).
+		WithHighlightedRow(min(highlighted, max(len(rows)-1, 0)))
+}
+
+func (m *repoWorkflowListView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Select, m.keys.Trigger, m.keys.Enable, m.keys.Source, m.keys.Analytics, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
 }
@@ -0,0 +1,210 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+// Fields of the run filter form, in focus order
+const (
+	runFilterBranch = iota
+	runFilterActor
+	runFilterEvent
+	runFilterStatus
+	runFilterCreated
+	runFilterHeadSHA
+	runFilterFieldCount
+)
+
+// runStatuses are the values of the status parameter of the API: the
+// statuses of a run and its conclusions
+var runStatuses = []string{
+	"queued", "requested", "waiting", "pending", "in_progress", "completed",
+	"success", "failure", "cancelled", "skipped", "neutral", "timed_out", "action_required", "stale",
+}
+
+type runFilterFormView struct {
+	// State
+	inputs       [runFilterFieldCount]textinput.Model
+	focusedIndex int
+	err          error
+
+	// Return to parent
+	parentView tea.Model
+
+	// UI
+	keys KeyMap
+}
+
+// NewRunFilterForm creates a form editing the filter of a run list as an
+// overlay. The parent receives a runFilterChangedMsg when it is applied.
+func NewRunFilterForm(filter github.RunFilter, parentView tea.Model) (tea.Model, tea.Cmd) {
+	m := &runFilterFormView{
+		parentView: parentView,
+		keys:       keyMapFor(viewRunFilterForm),
+	}
+	for i, value := range [...]string{filter.Branch, filter.Actor, filter.Event, filter.Status, filter.Created, filter.HeadSHA} {
+		m.inputs[i] = newFormInput(value)
+		m.inputs[i].Width = 40
+	}
+	m.inputs[runFilterBranch].Focus()
+	// Printable keys are typed into the fields, they cannot be actions here
+	m.keys.Select = withDesc(withoutRunes(m.keys.Select), "Apply")
+	m.keys.NextField = withoutRunes(m.keys.NextField)
+	m.keys.PrevField = withoutRunes(m.keys.PrevField)
+	m.keys.Cancel = withoutRunes(m.keys.Cancel)
+
+	return m, nil
+}
+
+func (m *runFilterFormView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *runFilterFormView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	msgKey, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msgKey, m.keys.Cancel):
+		return m.parentView, nil
+
+	case key.Matches(msgKey, m.keys.NextField):
+		m.focus((m.focusedIndex + 1) % runFilterFieldCount)
+
+	case key.Matches(msgKey, m.keys.PrevField):
+		m.focus((m.focusedIndex + runFilterFieldCount - 1) % runFilterFieldCount)
+
+	case key.Matches(msgKey, m.keys.Select):
+		filter, err := m.filter()
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		return m.parentView, func() tea.Msg { return runFilterChangedMsg{Filter: filter} }
+
+	default:
+		m.err = nil
+		var cmd tea.Cmd
+		m.inputs[m.focusedIndex], cmd = m.inputs[m.focusedIndex].Update(msgKey)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+// focus moves the focus to a field
+func (m *runFilterFormView) focus(index int) {
+	m.inputs[m.focusedIndex].Blur()
+	m.focusedIndex = index
+	m.inputs[index].Focus()
+}
+
+// filter validates the form. Only the status is checked, the API refuses
+// the other values itself.
+func (m *runFilterFormView) filter() (github.RunFilter, error) {
+	value := func(field int) string {
+		return strings.TrimSpace(m.inputs[field].Value())
+	}
+	filter := github.RunFilter{
+		Branch:  value(runFilterBranch),
+		Actor:   value(runFilterActor),
+		Event:   value(runFilterEvent),
+		Status:  value(runFilterStatus),
+		Created: value(runFilterCreated),
+		HeadSHA: value(runFilterHeadSHA),
+	}
+	if filter.Status != "" && !slices.Contains(runStatuses, filter.Status) {
+		return filter, fmt.Errorf("unknown status %q", filter.Status)
+	}
+	return filter, nil
+}
+
+func (m *runFilterFormView) View() string {
+	var popup strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.Current.OnPrimary).
+		Background(theme.Current.Primary).
+		Padding(0, 2)
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Current.Muted).
+		Bold(true).
+		Width(10)
+	focusedStyle := lipgloss.NewStyle().
+		Foreground(theme.Current.Emphasis).
+		Background(theme.Current.Surface).
+		Padding(0, 1)
+	unfocusedStyle := lipgloss.NewStyle().
+		Foreground(theme.Current.Text).
+		Padding(0, 1)
+	instrStyle := lipgloss.NewStyle().
+		Foreground(theme.Current.Subtle).
+		Italic(true)
+
+	popup.WriteString(titleStyle.Render("Filter Runs"))
+	popup.WriteString("\n\n")
+
+	labels := [...]string{"Branch:", "Actor:", "Event:", "Status:", "Created:", "Head SHA:"}
+	for i, label := range labels {
+		popup.WriteString(labelStyle.Render(label))
+		if m.focusedIndex == i {
+			popup.WriteString(focusedStyle.Render(m.inputs[i].View()))
+		} else {
+			popup.WriteString(unfocusedStyle.Render(m.inputs[i].View()))
+		}
+		popup.WriteString("\n")
+	}
+
+	if m.err != nil {
+		popup.WriteString("\n")
+		errorStyle := lipgloss.NewStyle().
+			Foreground(theme.Current.Failure)
+		popup.WriteString(errorStyle.Render(fmt.Sprintf("%s %v", theme.Icons.JobFailure, m.err)))
+		popup.WriteString("\n")
+	}
+
+	popup.WriteString("\n")
+	popup.WriteString(instrStyle.Render("Empty fields are not filtered on."))
+	popup.WriteString("\n")
+	popup.WriteString(instrStyle.Render("Created: >=2025-01-01 or 2025-01-01..2025-01-31"))
+	popup.WriteString("\n\n")
+	popup.WriteString(instrStyle.Render(helpLine(m.keys.NextField, m.keys.PrevField)))
+	popup.WriteString("\n")
+	popup.WriteString(instrStyle.Render(helpLine(m.keys.Select, m.keys.Cancel)))
+
+	popupStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Current.Primary).
+		Padding(1, 2).
+		Width(60)
+
+	return lipgloss.Place(
+		constants.WindowSize.Width,
+		constants.WindowSize.Height,
+		lipgloss.Center,
+		lipgloss.Center,
+		popupStyle.Render(popup.String()),
+		lipgloss.WithWhitespaceChars(" "),
+	)
+}
+
+func (m *runFilterFormView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Select, m.keys.NextField, m.keys.PrevField, m.keys.Cancel}
+}
+
+func (m *runFilterFormView) capturingInput() bool {
+	return true
+}
@@ -0,0 +1,383 @@
+package tui
+
+import (
+	"cmp"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+	"github.com/jjournet/tgr/config"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+const (
+	// monitorRecentRuns is the number of completed runs kept per repository
+	monitorRecentRuns = 5
+	// monitorMaxBackoff bounds how much polling slows down while idle
+	monitorMaxBackoff = 8
+)
+
+// monitorRun is a run listed by the monitor with its repository
+type monitorRun struct {
+	Repo string // "owner/repo"
+	github.RunInfo
+}
+
+// monitorTickMsg asks the monitor to poll again. Gen tells apart the
+// ticks of a polling loop replaced after coming back from a drill-down.
+type monitorTickMsg struct {
+	Gen int
+}
+
+// monitorClockMsg refreshes the elapsed time of the active runs between
+// two polls. Like monitorTickMsg, each poll starts its own clock.
+type monitorClockMsg struct {
+	Gen int
+}
+
+type runMonitor struct {
+	commonElements
+
+	// Service
+	sess *session
+
+	// Context
+	repos []string
+
+	// State
+	runs    map[string][]github.RunInfo
+	seen    map[int64]string // status of each run at the previous poll
+	errs    map[string]error
+	pending int
+	loading bool
+
+	// Polling
+	gen     int
+	backoff int // multiplier of the refresh interval while every run is completed
+
+	// UI
+	EltList table.Model
+	keys    KeyMap
+}
+
+// monitorRepos returns the repositories watched by the monitor: the
+// configured ones, else the favorites
+func (s *session) monitorRepos() []string {
+	if len(s.settings.Monitor.Repos) > 0 {
+		return s.settings.Monitor.Repos
+	}
+	var repos []string
+	for _, repo := range s.dashboardRepos() {
+		if repo.Pinned {
+			repos = append(repos, repo.Name)
+		}
+	}
+	return repos
+}
+
+func (m *runMonitor) resizeMain(w int, h int) {
+	headerHeight := lipgloss.Height(m.RenderTopFields())
+	footerHeight := lipgloss.Height(m.RenderBottomFields())
+	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2)
+	m.EltList = m.EltList.WithPageSize(h - headerHeight - footerHeight - 3)
+}
+
+// NewRunMonitor creates a view watching the runs of several repositories
+func NewRunMonitor(sess *session) (tea.Model, tea.Cmd) {
+	m := &runMonitor{
+		sess:    sess,
+		repos:   sess.monitorRepos(),
+		runs:    map[string][]github.RunInfo{},
+		seen:    map[int64]string{},
+		errs:    map[string]error{},
+		loading: true,
+		backoff: 1,
+		keys:    keyMapFor(viewRunMonitor),
+	}
+	m.keys.Select = withDesc(m.keys.Select, "Watch")
+	m.keys.Refresh = withDesc(m.keys.Refresh, "Refresh Now")
+
+	m.InitTop("Run Monitor")
+	m.TopFields = []string{"Run Monitor", fmt.Sprintf("(%d repos)", len(m.repos)), "Loading..."}
+	m.InitBottom()
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Select, m.keys.Refresh, m.keys.Back, m.keys.Help)
+
+	m.EltList = m.buildMonitorTable(0)
+	if constants.WindowSize.Height != 0 {
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	}
+
+	return m, m.poll()
+}
+
+// Init restarts polling when coming back from the run watch view
+func (m *runMonitor) Init() tea.Cmd {
+	m.backoff = 1
+	return m.poll()
+}
+
+// poll loads the runs of every repository and schedules the next poll,
+// cancelling the ticks already scheduled and the loads still in flight
+func (m *runMonitor) poll() tea.Cmd {
+	m.gen++
+	m.pending = 0
+	if len(m.repos) == 0 {
+		m.loading = false
+		return nil
+	}
+
+	cmds := []tea.Cmd{m.tick(), m.clock()}
+	for _, repo := range m.repos {
+		owner, repoName, ok := config.SplitRepo(repo)
+		if !ok {
+			continue
+		}
+		m.pending++
+		cmds = append(cmds, loadMonitorRunsCmd(m.sess, owner, repoName, m.gen))
+	}
+	return tea.Batch(cmds...)
+}
+
+// clock schedules the next refresh of the elapsed times
+func (m *runMonitor) clock() tea.Cmd {
+	gen := m.gen
+	return tick(time.Second, func(time.Time) tea.Msg {
+		return monitorClockMsg{Gen: gen}
+	})
+}
+
+func (m *runMonitor) tick() tea.Cmd {
+	gen := m.gen
+	return tick(m.sess.settings.Refresh.Monitor.Duration*time.Duration(m.backoff), func(time.Time) tea.Msg {
+		return monitorTickMsg{Gen: gen}
+	})
+}
+
+func (m *runMonitor) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case monitorTickMsg:
+		if msg.Gen != m.gen {
+			return m, nil
+		}
+		// Poll at the configured interval while runs are active, and
+		// slow down while everything is completed
+		if m.hasActiveRuns() {
+			m.backoff = 1
+		} else {
+			m.backoff = min(m.backoff*2, monitorMaxBackoff)
+		}
+		return m, m.poll()
+
+	case monitorClockMsg:
+		if msg.Gen != m.gen {
+			return m, nil
+		}
+		if m.hasActiveRuns() {
+			m.EltList = m.buildMonitorTable(m.EltList.GetHighlightedRowIndex())
+			if constants.WindowSize.Height != 0 {
+				m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+			}
+		}
+		return m, m.clock()
+
+	case monitorRunsLoadedMsg:
+		// Results of a poll replaced by a refresh are older than the
+		// ones on their way
+		if msg.Gen != m.gen {
+			return m, nil
+		}
+		m.pending = max(m.pending-1, 0)
+		var cmds []tea.Cmd
+		if msg.Err != nil {
+			slog.Debug("Loading monitored runs failed", "repo", msg.Repo, "error", msg.Err)
+			m.errs[msg.Repo] = msg.Err
+		} else {
+			m.runs[msg.Repo] = msg.Runs
+			delete(m.errs, msg.Repo)
+			for _, run := range msg.Runs {
+				if prev, ok := m.seen[run.ID]; ok && prev != "completed" && run.Status == "completed" {
+					cmds = append(cmds, m.sess.notifyRunCmd(msg.Repo, run.Title, run.Branch, run.ID, run.Conclusion))
+				}
+				m.seen[run.ID] = run.Status
+			}
+		}
+		if m.pending == 0 {
+			m.loading = false
+			m.updateStatusField()
+		}
+		m.EltList = m.buildMonitorTable(m.EltList.GetHighlightedRowIndex())
+		if constants.WindowSize.Height != 0 {
+			m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+		}
+		return m, tea.Batch(cmds...)
+
+	case tea.WindowSizeMsg:
+		constants.WindowSize = msg
+		m.resizeMain(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case isQuit(msg, m.keys):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return NewDashboard(m.sess)
+		case key.Matches(msg, m.keys.Refresh):
+			m.backoff = 1
+			return m, m.poll()
+		case key.Matches(msg, m.keys.Select):
+			if len(m.EltList.GetVisibleRows()) == 0 {
+				return m, nil
+			}
+			run := m.EltList.HighlightedRow().Data["run"].(monitorRun)
+			owner, repoName, _ := config.SplitRepo(run.Repo)
+			return NewWorkflowRunWatch(m.sess, owner, repoName, run.WorkflowID, run.ID, m)
+		}
+	}
+
+	var cmd tea.Cmd
+	m.EltList, cmd = m.EltList.Update(msg)
+	return m, cmd
+}
+
+func (m *runMonitor) hasActiveRuns() bool {
+	for _, runs := range m.runs {
+		for _, run := range runs {
+			if run.Status != "completed" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (m *runMonitor) updateStatusField() {
+	active := 0
+	for _, run := range m.monitorRuns() {
+		if run.Status != "completed" {
+			active++
+		}
+	}
+	status := fmt.Sprintf("%d active", active)
+	if len(m.errs) > 0 {
+		status += fmt.Sprintf(", %d failed to load", len(m.errs))
+	}
+	m.TopFields[2] = status
+}
+
+// monitorRuns returns the active runs and the latest completed runs of
+// every repository, most recent first
+func (m *runMonitor) monitorRuns() []monitorRun {
+	var runs []monitorRun
+	for repo, repoRuns := range m.runs {
+		completed := 0
+		for _, run := range repoRuns {
+			if run.Status == "completed" {
+				if completed == monitorRecentRuns {
+					continue
+				}
+				completed++
+			}
+			runs = append(runs, monitorRun{Repo: repo, RunInfo: run})
+		}
+	}
+	slices.SortFunc(runs, func(a, b monitorRun) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.ID, a.ID)
+	})
+	return runs
+}
+
+func (m *runMonitor) View() string {
+	if len(m.repos) == 0 {
+		return fmt.Sprintf(
+			"%s\n%s\n%s",
+			m.RenderTopFields(),
+			constants.MainStyle.Render("No repository to monitor.\n\nPin repositories or list them under monitor.repos in the config file."),
+			m.RenderBottomFields(),
+		)
+	}
+
+	if m.loading {
+		return m.RenderTopFields() + "\n\nLoading workflow runs..."
+	}
+
+	for i, row := range m.EltList.GetVisibleRows() {
+		row.Data["arrow"] = ""
+		if i == m.EltList.GetHighlightedRowIndex() {
+			row.Data["arrow"] = theme.Icons.Arrow
+		}
+	}
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s",
+		m.RenderTopFields(),
+		constants.MainStyle.Render(m.EltList.View()),
+		m.RenderBottomFields(),
+	)
+}
+
+func (m *runMonitor) buildMonitorTable(highlighted int) table.Model {
+	columns := []table.Column{
+		table.NewColumn("arrow", " ", 3),
+		table.NewColumn("indicator", " ", 3),
+		table.NewColumn("repo", "Repository", 20),
+		table.NewColumn("workflow", "Workflow", 20),
+		table.NewColumn("branch", "Branch", 20),
+		table.NewColumn("actor", "Actor", 15),
+		table.NewColumn("status", "Status", 12),
+		table.NewColumn("elapsed", "Elapsed", 10),
+	}
+
+	runs := m.monitorRuns()
+	rows := []table.Row{}
+	for _, run := range runs {
+		rows = append(rows, makeMonitorRow(run))
+	}
+
+	return table.New(columns).WithRows(rows).
+		WithKeyMap(m.keys.tableKeyMap()).
+		Focused(true).
+		Border(table.Border{}).
+		WithBaseStyle(constants.BaseTableStyle).
+		HighlightStyle(constants.HighlightedLineStyle).
+		WithFooterVisibility(false).
+		WithHighlightedRow(min(highlighted, max(len(runs)-1, 0)))
+}
+
+func makeMonitorRow(run monitorRun) table.Row {
+	indicator, color := theme.RunStatus(run.Status, run.Conclusion)
+
+	status := run.Status
+	elapsed := time.Since(run.CreatedAt)
+	if run.Status == "completed" {
+		status = run.Conclusion
+		elapsed = run.UpdatedAt.Sub(run.CreatedAt)
+	}
+
+	return table.NewRow(table.RowData{
+		"arrow":     "",
+		"indicator": table.NewStyledCell(indicator, lipgloss.NewStyle().Foreground(color)),
+		"repo":      run.Repo,
+		"workflow":  run.Title,
+		"branch":    run.Branch,
+		"actor":     run.Actor,
+		"status":    status,
+		"elapsed":   elapsed.Round(time.Second).String(),
+		"run":       run,
+	})
+}
+
+func (m *runMonitor) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Select, m.keys.Refresh, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
@@ -0,0 +1,428 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+type runnerListView struct {
+	commonElements
+
+	// Service
+	sess *session
+
+	// Context
+	owner    string
+	repoName string
+
+	// State
+	org        bool // runners of the organization rather than of the repository
+	showGroups bool // runner groups of the organization rather than runners
+	runners    []github.RunnerInfo
+	groups     []github.RunnerGroupInfo
+	jobs       map[string]github.RunnerJob // by runner name
+	token      *github.RegistrationToken   // last registration token created
+	loading    bool
+	listErr    error  // shown instead of the list, the other scope stays reachable
+	status     string // result of the last action
+
+	// Actions
+	confirmRemove *github.RunnerInfo // runner waiting for the removal to be confirmed
+
+	// UI
+	EltList table.Model
+	keys    KeyMap
+}
+
+func (m *runnerListView) resizeMain(w int, h int) {
+	headerHeight := lipgloss.Height(m.RenderTopFields())
+	footerHeight := lipgloss.Height(m.RenderBottomFields())
+	tokenHeight := 0
+	if m.token != nil {
+		tokenHeight = lipgloss.Height(m.tokenHint()) + 1
+	}
+	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2)
+	m.EltList = m.EltList.WithPageSize(h - headerHeight - footerHeight - 3 - tokenHeight)
+}
+
+// NewRunnerList creates a view listing the self-hosted runners of a
+// repository or of its organization, with the job they are running, and
+// the runner groups of the organization
+func NewRunnerList(sess *session, owner, repoName string) (tea.Model, tea.Cmd) {
+	m := &runnerListView{
+		sess:     sess,
+		owner:    owner,
+		repoName: repoName,
+		keys:     keyMapFor(viewRunnerList),
+	}
+	m.keys.Create = withDesc(m.keys.Create, "Registration token")
+	m.keys.Delete = withDesc(m.keys.Delete, "Remove runner")
+	m.keys.Scope = withDesc(m.keys.Scope, "Repository/organization")
+	m.keys.Group = withDesc(m.keys.Group, "Runner groups")
+
+	m.InitTop(owner, repoName, "Runners")
+	m.InitBottom()
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Create, m.keys.Delete, m.keys.Scope, m.keys.Group, m.keys.Back, m.keys.Help)
+
+	return m, m.load()
+}
+
+func (m *runnerListView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *runnerListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case runnersLoadedMsg:
+		if msg.Org != m.org || m.showGroups {
+			return m, nil // the scope changed while loading
+		}
+		m.loading = false
+		m.runners, m.listErr = msg.Runners, msg.Err
+		m.jobs = map[string]github.RunnerJob{}
+		for _, job := range msg.Jobs {
+			m.jobs[job.Runner] = job
+		}
+		m.rebuild()
+		return m, nil
+
+	case runnerGroupsLoadedMsg:
+		if !m.showGroups {
+			return m, nil
+		}
+		m.loading = false
+		m.groups, m.listErr = msg.Groups, msg.Err
+		m.rebuild()
+		return m, nil
+
+	case runnerActionMsg:
+		switch {
+		case msg.Err != nil && msg.Action == "token":
+			m.status = fmt.Sprintf("Could not create a registration token: %v", msg.Err)
+		case msg.Err != nil:
+			m.status = fmt.Sprintf("Could not remove runner %s: %v", msg.Runner, msg.Err)
+		case msg.Action == "token":
+			m.token = msg.Token
+			m.status = "Registration token created"
+		default:
+			m.status = "Removed runner " + msg.Runner
+			return m, m.load()
+		}
+		m.rebuild()
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		constants.WindowSize = msg
+		m.resizeMain(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading {
+			if isQuit(msg, m.keys) {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		if m.confirmRemove != nil {
+			runner := *m.confirmRemove
+			m.confirmRemove = nil
+			if key.Matches(msg, m.keys.Select) {
+				m.status = "Removing " + runner.Name + "..."
+				m.rebuild()
+				return m, removeRunnerCmd(m.sess, m.owner, m.scopeRepo(), runner)
+			}
+			m.status = ""
+			m.rebuild()
+			return m, nil
+		}
+
+		switch {
+		case isQuit(msg, m.keys):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return NewRepoView(m.sess, m.owner, m.repoName)
+		case key.Matches(msg, m.keys.Refresh):
+			m.status = ""
+			return m, m.load()
+		case key.Matches(msg, m.keys.Scope):
+			m.org = !m.org
+			m.showGroups = false
+			m.token = nil
+			m.status = ""
+			return m, m.load()
+		case key.Matches(msg, m.keys.Group):
+			// Runner groups only exist in organizations
+			m.showGroups = !m.showGroups
+			m.org = true
+			m.status = ""
+			return m, m.load()
+		}
+
+		if m.showGroups {
+			break
+		}
+		switch {
+		case key.Matches(msg, m.keys.Create):
+			m.status = "Creating a registration token..."
+			m.rebuild()
+			return m, createRegistrationTokenCmd(m.sess, m.owner, m.scopeRepo())
+		case key.Matches(msg, m.keys.Delete):
+			runner, ok := m.highlighted()
+			if !ok {
+				return m, nil
+			}
+			m.confirmRemove = &runner
+			m.status = fmt.Sprintf("Remove runner %s from %s? (%s) Confirm, any other key cancels", runner.Name, m.scopeName(), m.keys.Select.Keys()[0])
+			if job, ok := m.jobs[runner.Name]; ok {
+				m.status = fmt.Sprintf("Remove runner %s, running %s, from %s? (%s) Confirm, any other key cancels", runner.Name, jobTitle(job), m.scopeName(), m.keys.Select.Keys()[0])
+			}
+			m.rebuild()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.EltList, cmd = m.EltList.Update(msg)
+	return m, cmd
+}
+
+// load reloads the runners or the runner groups of the current scope
+func (m *runnerListView) load() tea.Cmd {
+	m.loading = true
+	m.listErr = nil
+	m.TopFields = []string{m.owner, m.repoName, fmt.Sprintf("%s of %s", m.title(), m.scopeName())}
+	if m.showGroups {
+		return loadRunnerGroupsCmd(m.sess, m.owner)
+	}
+	return loadRunnersCmd(m.sess, m.owner, m.repoName, m.org)
+}
+
+// scopeRepo is the repository the runners are registered to, empty for
+// the organization
+func (m *runnerListView) scopeRepo() string {
+	if m.org {
+		return ""
+	}
+	return m.repoName
+}
+
+func (m *runnerListView) scopeName() string {
+	if m.org {
+		return "organization " + m.owner
+	}
+	return "repository " + m.owner + "/" + m.repoName
+}
+
+func (m *runnerListView) title() string {
+	if m.showGroups {
+		return "Runner groups"
+	}
+	return "Runners"
+}
+
+// tokenHint tells how to register a runner with the last token created
+func (m *runnerListView) tokenHint() string {
+	url := "https://github.com/" + m.owner
+	if m.scopeRepo() != "" {
+		url += "/" + m.repoName
+	}
+	return fmt.Sprintf("Register a runner before %s with:\n./config.sh --url %s --token %s",
+		m.token.ExpiresAt.Local().Format("2006-01-02 15:04"), url, m.token.Token)
+}
+
+func (m *runnerListView) highlighted() (github.RunnerInfo, bool) {
+	if m.listErr != nil || len(m.EltList.GetVisibleRows()) == 0 {
+		return github.RunnerInfo{}, false
+	}
+	runner, ok := m.EltList.HighlightedRow().Data["runner"].(github.RunnerInfo)
+	return runner, ok
+}
+
+// jobTitle describes a job as "<workflow> #<run>: <job>"
+func jobTitle(job github.RunnerJob) string {
+	return fmt.Sprintf("%s #%d: %s", job.Workflow, job.RunNumber, job.Job)
+}
+
+// runnerStatus tells whether a runner is offline, idle or busy
+func runnerStatus(runner github.RunnerInfo) string {
+	switch {
+	case runner.Status != "online":
+		return runner.Status
+	case runner.Busy:
+		return "busy"
+	default:
+		return "idle"
+	}
+}
+
+// rebuild refreshes the header and the table after the list or the
+// status changed
+func (m *runnerListView) rebuild() {
+	count := len(m.runners)
+	if m.showGroups {
+		count = len(m.groups)
+	}
+	m.TopFields = []string{m.owner, m.repoName, fmt.Sprintf("%s of %s (%d)", m.title(), m.scopeName(), count)}
+	if m.listErr != nil {
+		m.TopFields[2] = fmt.Sprintf("%s of %s", m.title(), m.scopeName())
+	} else if !m.showGroups {
+		var online, busy int
+		for _, runner := range m.runners {
+			if runner.Status == "online" {
+				online++
+			}
+			if runner.Busy {
+				busy++
+			}
+		}
+		m.TopFields = append(m.TopFields, fmt.Sprintf("%d online, %d busy", online, busy))
+	}
+	if m.status != "" {
+		m.TopFields = append(m.TopFields, m.status)
+	}
+
+	if m.showGroups {
+		m.EltList = m.buildGroupTable(m.EltList.GetHighlightedRowIndex())
+	} else {
+		m.EltList = m.buildRunnerTable(m.EltList.GetHighlightedRowIndex())
+	}
+	if constants.WindowSize.Height != 0 {
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	}
+}
+
+func (m *runnerListView) View() string {
+	if m.loading {
+		return m.RenderTopFields() + fmt.Sprintf("\n\nLoading %s...", strings.ToLower(m.title()))
+	}
+
+	var main string
+	switch {
+	case m.listErr != nil:
+		main = constants.ErrorStyle.Render(fmt.Sprintf("Could not load the %s of %s: %v", strings.ToLower(m.title()), m.scopeName(), m.listErr))
+	case len(m.EltList.GetVisibleRows()) == 0 && m.showGroups:
+		main = fmt.Sprintf("No runner group in %s.", m.scopeName())
+	case len(m.EltList.GetVisibleRows()) == 0:
+		main = fmt.Sprintf("No self-hosted runner registered to %s.", m.scopeName())
+	default:
+		for i, row := range m.EltList.GetVisibleRows() {
+			row.Data["arrow"] = ""
+			if i == m.EltList.GetHighlightedRowIndex() {
+				row.Data["arrow"] = theme.Icons.Arrow
+			}
+		}
+		main = m.EltList.View()
+	}
+	if m.token != nil && !m.showGroups {
+		main = lipgloss.NewStyle().Foreground(theme.Current.Emphasis).Render(m.tokenHint()) + "\n\n" + main
+	}
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s",
+		m.RenderTopFields(),
+		constants.MainStyle.Render(main),
+		m.RenderBottomFields(),
+	)
+}
+
+func (m *runnerListView) buildRunnerTable(highlighted int) table.Model {
+	columns := []table.Column{
+		table.NewColumn("arrow", " ", 3),
+		table.NewColumn("name", "Runner", 22),
+		table.NewColumn("status", "Status", 8),
+		table.NewColumn("os", "OS", 8),
+	}
+	if m.org {
+		columns = append(columns,
+			table.NewColumn("labels", "Labels", 30),
+			table.NewColumn("group", "Group", 14),
+			table.NewColumn("job", "Current job", 30),
+		)
+	} else {
+		columns = append(columns,
+			table.NewColumn("labels", "Labels", 40),
+			table.NewColumn("job", "Current job", 34),
+		)
+	}
+
+	rows := []table.Row{}
+	for _, runner := range m.runners {
+		// Jobs of other repositories are not known
+		job := ""
+		if j, ok := m.jobs[runner.Name]; ok {
+			job = jobTitle(j)
+		}
+		rows = append(rows, table.NewRow(table.RowData{
+			"arrow":  "",
+			"name":   runner.Name,
+			"status": runnerStatus(runner),
+			"os":     runner.OS,
+			"labels": strings.Join(runner.Labels, ", "),
+			"group":  runner.Group,
+			"job":    job,
+			"runner": runner,
+		}))
+	}
+
+	return table.New(columns).WithRows(rows).
+		WithKeyMap(m.keys.tableKeyMap()).
+		Focused(true).
+		Border(table.Border{}).
+		WithBaseStyle(constants.BaseTableStyle).
+		HighlightStyle(constants.HighlightedLineStyle).
+		WithFooterVisibility(false).
+		WithHighlightedRow(min(highlighted, max(len(rows)-1, 0)))
+}
+
+func (m *runnerListView) buildGroupTable(highlighted int) table.Model {
+	columns := []table.Column{
+		table.NewColumn("arrow", " ", 3),
+		table.NewColumn("name", "Group", 24),
+		table.NewColumn("visibility", "Visibility", 11),
+		table.NewColumn("default", "Default", 8),
+		table.NewColumn("workflows", "Workflows", 11),
+		table.NewColumn("runners", "Runners", 58),
+	}
+
+	rows := []table.Row{}
+	for _, group := range m.groups {
+		isDefault, workflows := "", "all"
+		if group.Default {
+			isDefault = "yes"
+		}
+		if group.RestrictedToWorkflows {
+			workflows = "selected"
+		}
+		rows = append(rows, table.NewRow(table.RowData{
+			"arrow":      "",
+			"name":       group.Name,
+			"visibility": group.Visibility,
+			"default":    isDefault,
+			"workflows":  workflows,
+			"runners":    fmt.Sprintf("%d: %s", len(group.Runners), strings.Join(group.Runners, ", ")),
+		}))
+	}
+
+	return table.New(columns).WithRows(rows).
+		WithKeyMap(m.keys.tableKeyMap()).
+		Focused(true).
+		Border(table.Border{}).
+		WithBaseStyle(constants.BaseTableStyle).
+		HighlightStyle(constants.HighlightedLineStyle).
+		WithFooterVisibility(false).
+		WithHighlightedRow(min(highlighted, max(len(rows)-1, 0)))
+}
+
+func (m *runnerListView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Create, m.keys.Delete, m.keys.Scope, m.keys.Group, m.keys.Refresh, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
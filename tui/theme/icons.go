@@ -0,0 +1,160 @@
+package theme
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// IconSet holds the glyphs used by the views
+type IconSet struct {
+	Logo  string
+	Arrow string
+
+	// Workflow runs
+	Success    string
+	Failure    string
+	Cancelled  string
+	InProgress string
+	Queued     string
+	Unknown    string
+
+	// Jobs and steps
+	JobSuccess string
+	JobFailure string
+	JobRunning string
+	JobPending string
+	JobSkipped string
+
+	// Issues
+	IssueOpen   string
+	IssueClosed string
+
+	// Dashboard
+	Pinned string
+	Recent string
+
+	// Inbox
+	Unread string
+
+	// Branches
+	Protected string
+
+	// Workflows
+	Dispatch string
+
+	Separator string
+}
+
+// NerdFont needs a patched Nerd Font in the terminal
+var NerdFont = IconSet{
+	Logo:        "\uf113",
+	Arrow:       "\uf0a9",
+	Success:     "\uf058",
+	Failure:     "\uea87",
+	Cancelled:   "\uf05e",
+	InProgress:  "\uef0c",
+	Queued:      "󰚭",
+	Unknown:     "\uf128",
+	JobSuccess:  "✓",
+	JobFailure:  "✗",
+	JobRunning:  "●",
+	JobPending:  "○",
+	JobSkipped:  "-",
+	IssueOpen:   "\uf468",
+	IssueClosed: "\uf46a",
+	Pinned:      "\uf08d",
+	Recent:      "\uf017",
+	Unread:      "\uf111",
+	Protected:   "\uf023",
+	Dispatch:    "\uf04b",
+	Separator:   "─",
+}
+
+// ASCII renders on any terminal
+var ASCII = IconSet{
+	Logo:        "tgr",
+	Arrow:       ">",
+	Success:     "+",
+	Failure:     "x",
+	Cancelled:   "/",
+	InProgress:  "*",
+	Queued:      ".",
+	Unknown:     "?",
+	JobSuccess:  "+",
+	JobFailure:  "x",
+	JobRunning:  "*",
+	JobPending:  "o",
+	JobSkipped:  "-",
+	IssueOpen:   "o",
+	IssueClosed: "x",
+	Pinned:      "*",
+	Recent:      "~",
+	Unread:      "*",
+	Protected:   "#",
+	Dispatch:    "+",
+	Separator:   "-",
+}
+
+// Icons is the icon set used by the views
+var Icons = NerdFont
+
+// LoadIcons returns the icon set with the given name: "nerd" (default) or "ascii"
+func LoadIcons(name string) (IconSet, error) {
+	switch name {
+	case "", "nerd":
+		return NerdFont, nil
+	case "ascii":
+		return ASCII, nil
+	default:
+		return IconSet{}, fmt.Errorf("icons: unknown icon set %q (expected nerd or ascii)", name)
+	}
+}
+
+// RunStatus returns the icon and color of a workflow run state
+func RunStatus(status, conclusion string) (string, lipgloss.Color) {
+	switch {
+	case status == "completed" && conclusion == "success":
+		return Icons.Success, Current.Success
+	case status == "completed" && conclusion == "failure":
+		return Icons.Failure, Current.Failure
+	case status == "completed" && conclusion == "cancelled":
+		return Icons.Cancelled, Current.Muted
+	case status == "in_progress":
+		return Icons.InProgress, Current.Pending
+	case status == "queued":
+		return Icons.Queued, Current.Pending
+	default:
+		return Icons.Unknown, Current.Accent
+	}
+}
+
+// JobStatus returns the icon and color of a job or step state
+func JobStatus(status, conclusion string) (string, lipgloss.Color) {
+	switch {
+	case status == "completed" && conclusion == "success":
+		return Icons.JobSuccess, Current.Success
+	case status == "completed" && conclusion == "failure":
+		return Icons.JobFailure, Current.Failure
+	case status == "completed" && conclusion == "skipped":
+		return Icons.JobSkipped, Current.Muted
+	case status == "in_progress":
+		return Icons.JobRunning, Current.Pending
+	case status == "queued":
+		return Icons.JobPending, Current.Pending
+	default:
+		return Icons.JobPending, Current.Muted
+	}
+}
+
+// IssueState returns the icon and color of an issue state
+func IssueState(state string) (string, lipgloss.Color) {
+	switch state {
+	case "open":
+		return Icons.IssueOpen, Current.Success
+	case "closed":
+		return Icons.IssueClosed, Current.Closed
+	default:
+		return Icons.Unknown, Current.Accent
+	}
+}
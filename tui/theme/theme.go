@@ -0,0 +1,272 @@
+package theme
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jjournet/tgr/config"
+)
+
+// Theme names every color used by the views by its meaning
+type Theme struct {
+	Name    string `json:"name"`
+	Extends string `json:"extends,omitempty"`
+
+	// Run, job and issue states
+	Success lipgloss.Color `json:"success"`
+	Failure lipgloss.Color `json:"failure"`
+	Pending lipgloss.Color `json:"pending"`
+	Closed  lipgloss.Color `json:"closed"`
+
+	// Text
+	Text     lipgloss.Color `json:"text"`
+	Emphasis lipgloss.Color `json:"emphasis"`
+	Muted    lipgloss.Color `json:"muted"`
+	Subtle   lipgloss.Color `json:"subtle"`
+	Link     lipgloss.Color `json:"link"`
+
+	// Chrome
+	Accent        lipgloss.Color `json:"accent"`
+	OnAccent      lipgloss.Color `json:"on_accent"`
+	Primary       lipgloss.Color `json:"primary"`
+	OnPrimary     lipgloss.Color `json:"on_primary"`
+	Surface       lipgloss.Color `json:"surface"`
+	Focus         lipgloss.Color `json:"focus"`
+	BarText       lipgloss.Color `json:"bar_text"`
+	BarBackground lipgloss.Color `json:"bar_background"`
+	Badge         lipgloss.Color `json:"badge"`
+	OnBadge       lipgloss.Color `json:"on_badge"`
+}
+
+// Built-in themes
+var (
+	Dark = Theme{
+		Name:          "dark",
+		Success:       "#22EE82",
+		Failure:       "#FF0000",
+		Pending:       "#FFCC00",
+		Closed:        "#b19cd9",
+		Text:          "#C9D1D9",
+		Emphasis:      "#FFFFFF",
+		Muted:         "#8B949E",
+		Subtle:        "#6B7280",
+		Link:          "#58A6FF",
+		Accent:        "#77c2f9",
+		OnAccent:      "#000000",
+		Primary:       "#5865F2",
+		OnPrimary:     "#FFFFFF",
+		Surface:       "#1f2937",
+		Focus:         "205",
+		BarText:       "#C1C6B2",
+		BarBackground: "#353533",
+		Badge:         "#FF5F87",
+		OnBadge:       "#FFFDF5",
+	}
+
+	Light = Theme{
+		Name:          "light",
+		Success:       "#1A7F37",
+		Failure:       "#CF222E",
+		Pending:       "#9A6700",
+		Closed:        "#8250DF",
+		Text:          "#1F2328",
+		Emphasis:      "#000000",
+		Muted:         "#59636E",
+		Subtle:        "#6E7781",
+		Link:          "#0969DA",
+		Accent:        "#0969DA",
+		OnAccent:      "#FFFFFF",
+		Primary:       "#5865F2",
+		OnPrimary:     "#FFFFFF",
+		Surface:       "#EAEEF2",
+		Focus:         "162",
+		BarText:       "#343433",
+		BarBackground: "#D9DCCF",
+		Badge:         "#FF5F87",
+		OnBadge:       "#FFFDF5",
+	}
+
+	HighContrast = Theme{
+		Name:          "high-contrast",
+		Success:       "#00FF00",
+		Failure:       "#FF3333",
+		Pending:       "#FFFF00",
+		Closed:        "#FF00FF",
+		Text:          "#FFFFFF",
+		Emphasis:      "#FFFFFF",
+		Muted:         "#D0D0D0",
+		Subtle:        "#C0C0C0",
+		Link:          "#00FFFF",
+		Accent:        "#00FFFF",
+		OnAccent:      "#000000",
+		Primary:       "#FFFFFF",
+		OnPrimary:     "#000000",
+		Surface:       "#303030",
+		Focus:         "#FFFF00",
+		BarText:       "#000000",
+		BarBackground: "#FFFFFF",
+		Badge:         "#FFFF00",
+		OnBadge:       "#000000",
+	}
+
+	// Colorblind uses the Okabe-Ito palette, which stays distinguishable
+	// with the common forms of color vision deficiency
+	Colorblind = Theme{
+		Name:          "colorblind",
+		Success:       "#56B4E9",
+		Failure:       "#D55E00",
+		Pending:       "#F0E442",
+		Closed:        "#CC79A7",
+		Text:          "#C9D1D9",
+		Emphasis:      "#FFFFFF",
+		Muted:         "#8B949E",
+		Subtle:        "#6B7280",
+		Link:          "#56B4E9",
+		Accent:        "#009E73",
+		OnAccent:      "#000000",
+		Primary:       "#0072B2",
+		OnPrimary:     "#FFFFFF",
+		Surface:       "#1f2937",
+		Focus:         "#E69F00",
+		BarText:       "#C1C6B2",
+		BarBackground: "#353533",
+		Badge:         "#E69F00",
+		OnBadge:       "#000000",
+	}
+)
+
+var builtins = map[string]Theme{
+	Dark.Name:         Dark,
+	Light.Name:        Light,
+	HighContrast.Name: HighContrast,
+	Colorblind.Name:   Colorblind,
+}
+
+// Current is the theme used by the views
+var Current = Dark
+
+// Dir returns the directory holding user themes
+func Dir() string {
+	return filepath.Join(config.Dir(), "themes")
+}
+
+// Load returns the theme with the given name, either built in or read
+// from <dir>/<name>.json. An empty name picks dark or light from the
+// terminal background.
+func Load(name, dir string) (Theme, error) {
+	if name == "" {
+		if lipgloss.HasDarkBackground() {
+			return Dark, nil
+		}
+		return Light, nil
+	}
+	return load(name, dir, nil)
+}
+
+func load(name, dir string, seen []string) (Theme, error) {
+	if t, ok := builtins[name]; ok {
+		return t, nil
+	}
+	if slices.Contains(seen, name) {
+		return Theme{}, fmt.Errorf("theme %q: extends cycle %s", seen[0], strings.Join(append(seen, name), " -> "))
+	}
+
+	path := filepath.Join(dir, name+".json")
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Theme{}, fmt.Errorf("theme %q not found: expected a built-in theme (%s) or %s", name, strings.Join(Names(), ", "), path)
+	} else if err != nil {
+		return Theme{}, err
+	}
+
+	var user Theme
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&user); err != nil {
+		return Theme{}, fmt.Errorf("theme %s: %w", path, err)
+	}
+	for _, c := range user.colors() {
+		if *c.color != "" && !validColor(string(*c.color)) {
+			return Theme{}, fmt.Errorf("theme %s: %s: %q is not a color, expected #rgb, #rrggbb or an ANSI index from 0 to 255", path, c.key, *c.color)
+		}
+	}
+
+	// User themes start from the theme they extend, dark by default
+	base := Dark
+	if user.Extends != "" {
+		base, err = load(user.Extends, dir, append(seen, name))
+		if err != nil {
+			return Theme{}, err
+		}
+	}
+	base.merge(user)
+	base.Name = name
+	return base, nil
+}
+
+// namedColor is a color of a theme with its key in theme files
+type namedColor struct {
+	key   string
+	color *lipgloss.Color
+}
+
+// colors lists the colors of the theme
+func (t *Theme) colors() []namedColor {
+	return []namedColor{
+		{"success", &t.Success},
+		{"failure", &t.Failure},
+		{"pending", &t.Pending},
+		{"closed", &t.Closed},
+		{"text", &t.Text},
+		{"emphasis", &t.Emphasis},
+		{"muted", &t.Muted},
+		{"subtle", &t.Subtle},
+		{"link", &t.Link},
+		{"accent", &t.Accent},
+		{"on_accent", &t.OnAccent},
+		{"primary", &t.Primary},
+		{"on_primary", &t.OnPrimary},
+		{"surface", &t.Surface},
+		{"focus", &t.Focus},
+		{"bar_text", &t.BarText},
+		{"bar_background", &t.BarBackground},
+		{"badge", &t.Badge},
+		{"on_badge", &t.OnBadge},
+	}
+}
+
+// merge overrides the colors set in other
+func (t *Theme) merge(other Theme) {
+	dst, src := t.colors(), other.colors()
+	for i := range dst {
+		if *src[i].color != "" {
+			*dst[i].color = *src[i].color
+		}
+	}
+}
+
+// validColor reports whether c is a hex color or an ANSI color index
+func validColor(c string) bool {
+	if hex, ok := strings.CutPrefix(c, "#"); ok {
+		if len(hex) != 3 && len(hex) != 6 {
+			return false
+		}
+		_, err := strconv.ParseUint(hex, 16, 32)
+		return err == nil
+	}
+	n, err := strconv.ParseUint(c, 10, 8)
+	return err == nil && n <= 255
+}
+
+// Names lists the built-in themes
+func Names() []string {
+	return slices.Sorted(maps.Keys(builtins))
+}
@@ -0,0 +1,64 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadUserTheme(t *testing.T) {
+	dir := t.TempDir()
+	writeTheme(t, dir, "base", `{"extends": "light", "success": "#000001"}`)
+	writeTheme(t, dir, "mine", `{"extends": "base", "failure": "#000002"}`)
+
+	got, err := Load("mine", dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got.Name != "mine" || got.Success != "#000001" || got.Failure != "#000002" || got.Pending != Light.Pending {
+		t.Errorf("unexpected theme: %+v", got)
+	}
+}
+
+func TestLoadThemeErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeTheme(t, dir, "loop", `{"extends": "loop"}`)
+	writeTheme(t, dir, "broken", `{"success": 12}`)
+
+	for _, name := range []string{"missing", "loop", "broken"} {
+		if _, err := Load(name, dir); err == nil {
+			t.Errorf("Load(%q): expected an error", name)
+		}
+	}
+}
+
+func TestLoadThemeInvalid(t *testing.T) {
+	dir := t.TempDir()
+	for name, tc := range map[string]struct{ content, want string }{
+		"typo":    {`{"sucess": "#00FF00"}`, `unknown field "sucess"`},
+		"word":    {`{"failure": "red"}`, `failure: "red" is not a color`},
+		"short":   {`{"muted": "#12345"}`, `muted: "#12345" is not a color`},
+		"notHex":  {`{"link": "#GGGGGG"}`, `link: "#GGGGGG" is not a color`},
+		"tooHigh": {`{"focus": "256"}`, `focus: "256" is not a color`},
+	} {
+		writeTheme(t, dir, name, tc.content)
+		_, err := Load(name, dir)
+		path := filepath.Join(dir, name+".json")
+		if err == nil || !strings.Contains(err.Error(), path) || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("Load(%q) = %v, want an error naming %s and %s", name, err, path, tc.want)
+		}
+	}
+
+	writeTheme(t, dir, "valid", `{"success": "#0F0", "failure": "#FF0000", "focus": "205", "muted": "0"}`)
+	if _, err := Load("valid", dir); err != nil {
+		t.Errorf("Load(valid): %v", err)
+	}
+}
+
+func writeTheme(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name+".json"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,432 @@
+package tui
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+// Steps of the inline form setting a variable or secret
+const (
+	editNone = iota
+	editName
+	editValue
+)
+
+type variableListView struct {
+	commonElements
+
+	// Service
+	sess *session
+
+	// Context
+	owner    string
+	repoName string
+
+	// State
+	scopes      []github.VariableScope // the repository, its environments, then the organization
+	scope       github.VariableScope
+	showSecrets bool
+	variables   []github.VariableInfo
+	secrets     []github.SecretInfo
+	loading     bool
+	listErr     error  // shown instead of the list, the other scopes stay reachable
+	status      string // result of the last action
+
+	// Actions
+	confirmDelete string // name waiting for the deletion to be confirmed
+	editStep      int
+	editName      string
+	creating      bool
+
+	// UI
+	EltList        table.Model
+	visibleCommand bool
+	keys           KeyMap
+}
+
+func (m *variableListView) resizeMain(w int, h int) {
+	headerHeight := lipgloss.Height(m.RenderTopFields())
+	footerHeight := lipgloss.Height(m.RenderBottomFields())
+	cmdHeight := 0
+	if m.visibleCommand {
+		cmdHeight = 3
+	}
+	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2 - cmdHeight)
+	m.EltList = m.EltList.WithPageSize(h - headerHeight - footerHeight - 3 - cmdHeight)
+	constants.CommandStyle = constants.CommandStyle.Width(w - 2).Height(1)
+}
+
+// NewVariableList creates a view managing the Actions variables and
+// secrets of a repository, of its environments and of its organization
+func NewVariableList(sess *session, owner, repoName string) (tea.Model, tea.Cmd) {
+	repoScope := github.VariableScope{Owner: owner, Repo: repoName}
+	m := &variableListView{
+		sess:     sess,
+		owner:    owner,
+		repoName: repoName,
+		scopes:   []github.VariableScope{repoScope, {Owner: owner}},
+		scope:    repoScope,
+		loading:  true,
+		keys:     keyMapFor(viewVariableList),
+	}
+	m.keys.Select = withDesc(m.keys.Select, "Edit value")
+
+	m.InitTop(owner, repoName, "Variables")
+	m.TopFields = []string{owner, repoName, "Variables"}
+	m.InitBottom()
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Select, m.keys.Create, m.keys.Delete, m.keys.Scope, m.keys.Secrets, m.keys.Back, m.keys.Help)
+	m.CommandInput = textinput.New()
+
+	return m, tea.Batch(loadEnvironmentsCmd(sess, owner, repoName), m.load())
+}
+
+func (m *variableListView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *variableListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case environmentsLoadedMsg:
+		if msg.Err != nil {
+			// Environment scopes are optional, the others still work
+			slog.Debug("Loading environments failed", "error", msg.Err)
+			return m, nil
+		}
+		scopes := []github.VariableScope{{Owner: m.owner, Repo: m.repoName}}
+		for _, env := range msg.Environments {
+			scopes = append(scopes, github.VariableScope{Owner: m.owner, Repo: m.repoName, Environment: env.Name})
+		}
+		m.scopes = append(scopes, github.VariableScope{Owner: m.owner})
+		return m, nil
+
+	case variablesLoadedMsg:
+		if msg.Scope != m.scope || m.showSecrets {
+			return m, nil // the scope changed while loading
+		}
+		m.loading = false
+		m.variables, m.listErr = msg.Variables, msg.Err
+		m.rebuild()
+		return m, nil
+
+	case secretsLoadedMsg:
+		if msg.Scope != m.scope || !m.showSecrets {
+			return m, nil
+		}
+		m.loading = false
+		m.secrets, m.listErr = msg.Secrets, msg.Err
+		m.rebuild()
+		return m, nil
+
+	case variableActionMsg:
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Could not %s %s %s: %v", msg.Action, msg.Kind, msg.Name, msg.Err)
+			m.rebuild()
+			return m, nil
+		}
+		if msg.Action == "set" {
+			m.status = fmt.Sprintf("Saved %s %s", msg.Kind, msg.Name)
+		} else {
+			m.status = fmt.Sprintf("Deleted %s %s", msg.Kind, msg.Name)
+		}
+		return m, m.load()
+
+	case tea.WindowSizeMsg:
+		constants.WindowSize = msg
+		m.resizeMain(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading {
+			if isQuit(msg, m.keys) {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		if m.visibleCommand {
+			return m.handleEditInput(msg)
+		}
+
+		if m.confirmDelete != "" {
+			name := m.confirmDelete
+			m.confirmDelete = ""
+			if key.Matches(msg, m.keys.Select) {
+				m.status = "Deleting " + name + "..."
+				m.rebuild()
+				if m.showSecrets {
+					return m, deleteSecretCmd(m.sess, m.scope, name)
+				}
+				return m, deleteVariableCmd(m.sess, m.scope, name)
+			}
+			m.status = ""
+			m.rebuild()
+			return m, nil
+		}
+
+		switch {
+		case isQuit(msg, m.keys):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return NewRepoView(m.sess, m.owner, m.repoName)
+		case key.Matches(msg, m.keys.Refresh):
+			m.status = ""
+			return m, m.load()
+		case key.Matches(msg, m.keys.Scope):
+			i := slices.Index(m.scopes, m.scope)
+			m.scope = m.scopes[(i+1)%len(m.scopes)]
+			m.status = ""
+			return m, m.load()
+		case key.Matches(msg, m.keys.Secrets):
+			m.showSecrets = !m.showSecrets
+			m.status = ""
+			return m, m.load()
+		case key.Matches(msg, m.keys.Create):
+			m.creating = true
+			m.openEditInput(editName, fmt.Sprintf("New %s name: ", m.kind()), "")
+			return m, nil
+		}
+
+		name, ok := m.highlighted()
+		if !ok {
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, m.keys.Select):
+			m.creating = false
+			m.editName = name
+			value := ""
+			if !m.showSecrets {
+				value = m.EltList.HighlightedRow().Data["variable"].(github.VariableInfo).Value
+			}
+			m.openEditInput(editValue, fmt.Sprintf("Value of %s: ", name), value)
+			return m, nil
+		case key.Matches(msg, m.keys.Delete):
+			m.confirmDelete = name
+			m.status = fmt.Sprintf("Delete %s %s? (%s) Confirm, any other key cancels", m.kind(), name, m.keys.Select.Keys()[0])
+			m.rebuild()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.EltList, cmd = m.EltList.Update(msg)
+	return m, cmd
+}
+
+// load reloads the variables or the secrets of the current scope
+func (m *variableListView) load() tea.Cmd {
+	m.loading = true
+	m.listErr = nil
+	m.TopFields = []string{m.owner, m.repoName, fmt.Sprintf("%s of %s", m.title(), m.scope)}
+	if m.showSecrets {
+		return loadSecretsCmd(m.sess, m.scope)
+	}
+	return loadVariablesCmd(m.sess, m.scope)
+}
+
+// openEditInput shows the form at the given step. Secret values are
+// masked while typed.
+func (m *variableListView) openEditInput(step int, prompt, value string) {
+	m.editStep = step
+	m.CommandInput.Prompt = prompt
+	m.CommandInput.EchoMode = textinput.EchoNormal
+	if step == editValue && m.showSecrets {
+		m.CommandInput.EchoMode = textinput.EchoPassword
+	}
+	m.CommandInput.SetValue(value)
+	m.CommandInput.CursorEnd()
+	m.CommandInput.Focus()
+	m.visibleCommand = true
+	m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+}
+
+// handleEditInput reads the name of a new variable or secret, then its
+// value
+func (m *variableListView) handleEditInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeEditInput()
+		return m, nil
+	case "enter":
+		value := m.CommandInput.Value()
+		if m.editStep == editName {
+			if name := strings.TrimSpace(value); name != "" {
+				m.editName = name
+				m.openEditInput(editValue, fmt.Sprintf("Value of %s: ", name), "")
+			}
+			return m, nil
+		}
+		if value == "" {
+			return m, nil
+		}
+		m.closeEditInput()
+		m.status = "Saving " + m.editName + "..."
+		m.rebuild()
+		if m.showSecrets {
+			return m, setSecretCmd(m.sess, m.scope, m.editName, value)
+		}
+		return m, setVariableCmd(m.sess, m.scope, m.editName, value, m.creating)
+	}
+
+	var cmd tea.Cmd
+	m.CommandInput, cmd = m.CommandInput.Update(msg)
+	return m, cmd
+}
+
+func (m *variableListView) closeEditInput() {
+	m.editStep = editNone
+	m.visibleCommand = false
+	m.CommandInput.SetValue("") // do not keep a secret around
+	m.CommandInput.Blur()
+	m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+}
+
+func (m *variableListView) highlighted() (string, bool) {
+	if m.listErr != nil || len(m.EltList.GetVisibleRows()) == 0 {
+		return "", false
+	}
+	name, ok := m.EltList.HighlightedRow().Data["name"].(string)
+	return name, ok
+}
+
+func (m *variableListView) kind() string {
+	if m.showSecrets {
+		return "secret"
+	}
+	return "variable"
+}
+
+func (m *variableListView) title() string {
+	if m.showSecrets {
+		return "Secrets"
+	}
+	return "Variables"
+}
+
+// rebuild refreshes the header and the table after the list or the
+// status changed
+func (m *variableListView) rebuild() {
+	count := len(m.variables)
+	if m.showSecrets {
+		count = len(m.secrets)
+	}
+	m.TopFields = []string{m.owner, m.repoName, fmt.Sprintf("%s of %s (%d)", m.title(), m.scope, count)}
+	if m.listErr != nil {
+		m.TopFields[2] = fmt.Sprintf("%s of %s", m.title(), m.scope)
+	}
+	if m.status != "" {
+		m.TopFields = append(m.TopFields, m.status)
+	}
+
+	m.EltList = m.buildVariableTable(m.EltList.GetHighlightedRowIndex())
+	if constants.WindowSize.Height != 0 {
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	}
+}
+
+func (m *variableListView) View() string {
+	if m.loading {
+		return m.RenderTopFields() + fmt.Sprintf("\n\nLoading %s...", strings.ToLower(m.title()))
+	}
+
+	var main string
+	switch {
+	case m.listErr != nil:
+		main = constants.ErrorStyle.Render(fmt.Sprintf("Could not load the %s of %s: %v", strings.ToLower(m.title()), m.scope, m.listErr))
+	case len(m.EltList.GetVisibleRows()) == 0:
+		main = fmt.Sprintf("No %s in %s.", m.kind(), m.scope)
+	default:
+		for i, row := range m.EltList.GetVisibleRows() {
+			row.Data["arrow"] = ""
+			if i == m.EltList.GetHighlightedRowIndex() {
+				row.Data["arrow"] = theme.Icons.Arrow
+			}
+		}
+		main = m.EltList.View()
+	}
+
+	if m.visibleCommand {
+		return fmt.Sprintf(
+			"%s\n%s\n%s\n%s",
+			m.RenderTopFields(),
+			constants.CommandStyle.BorderForeground(theme.Current.Accent).Render(m.CommandInput.View()),
+			constants.MainStyle.Render(main),
+			m.RenderBottomFields(),
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s",
+		m.RenderTopFields(),
+		constants.MainStyle.Render(main),
+		m.RenderBottomFields(),
+	)
+}
+
+func (m *variableListView) buildVariableTable(highlighted int) table.Model {
+	var columns []table.Column
+	rows := []table.Row{}
+	if m.showSecrets {
+		columns = []table.Column{
+			table.NewColumn("arrow", " ", 3),
+			table.NewColumn("name", "Secret", 40),
+			table.NewColumn("updated", "Updated", 17),
+			table.NewColumn("visibility", "Visibility", 12),
+		}
+		for _, secret := range m.secrets {
+			rows = append(rows, table.NewRow(table.RowData{
+				"arrow":      "",
+				"name":       secret.Name,
+				"updated":    secret.UpdatedAt.Format("2006-01-02 15:04"),
+				"visibility": secret.Visibility,
+			}))
+		}
+	} else {
+		columns = []table.Column{
+			table.NewColumn("arrow", " ", 3),
+			table.NewColumn("name", "Variable", 30),
+			table.NewColumn("value", "Value", 56),
+			table.NewColumn("updated", "Updated", 17),
+			table.NewColumn("visibility", "Visibility", 12),
+		}
+		for _, variable := range m.variables {
+			rows = append(rows, table.NewRow(table.RowData{
+				"arrow":      "",
+				"name":       variable.Name,
+				"value":      strings.ReplaceAll(variable.Value, "\n", " "),
+				"updated":    variable.UpdatedAt.Format("2006-01-02 15:04"),
+				"visibility": variable.Visibility,
+				"variable":   variable,
+			}))
+		}
+	}
+
+	return table.New(columns).WithRows(rows).
+		WithKeyMap(m.keys.tableKeyMap()).
+		Focused(true).
+		Border(table.Border{}).
+		WithBaseStyle(constants.BaseTableStyle).
+		HighlightStyle(constants.HighlightedLineStyle).
+		WithFooterVisibility(false).
+		WithHighlightedRow(min(highlighted, max(len(rows)-1, 0)))
+}
+
+func (m *variableListView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Select, m.keys.Create, m.keys.Delete, m.keys.Scope, m.keys.Secrets, m.keys.Refresh, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
+
+func (m *variableListView) capturingInput() bool {
+	return m.visibleCommand
+}
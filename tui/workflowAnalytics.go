@@ -0,0 +1,341 @@
+package tui
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/jjournet/tgr/analytics"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+)
+
+// Widths of the analytics charts and of the job table columns
+const (
+	analyticsBarWidth  = 24
+	analyticsNameWidth = 30
+	analyticsCellWidth = 9
+)
+
+type workflowAnalyticsView struct {
+	commonElements
+
+	// Service
+	sess *session
+
+	// Context
+	owner    string
+	repoName string
+	workflow github.WorkflowInfo
+
+	// State
+	report  analytics.Report
+	loading bool
+	err     error
+	status  string // result of the last export
+
+	// UI
+	viewport       viewport.Model
+	visibleCommand bool
+	keys           KeyMap
+
+	// Navigation
+	parentView tea.Model
+}
+
+func (m *workflowAnalyticsView) resizeMain(w int, h int) {
+	headerHeight := lipgloss.Height(m.RenderTopFields())
+	footerHeight := lipgloss.Height(m.RenderBottomFields())
+	cmdHeight := 0
+	if m.visibleCommand {
+		cmdHeight = 3
+	}
+	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2 - cmdHeight)
+	constants.CommandStyle = constants.CommandStyle.Width(w - 2).Height(1)
+	m.viewport.Width = w - 2
+	m.viewport.Height = max(h-headerHeight-footerHeight-2-cmdHeight, 1)
+}
+
+// NewWorkflowAnalytics creates a view showing how reliable and how fast
+// the latest completed runs of a workflow were, per job and per step.
+// Back returns to parentView.
+func NewWorkflowAnalytics(sess *session, owner, repoName string, workflow github.WorkflowInfo, parentView tea.Model) (tea.Model, tea.Cmd) {
+	m := &workflowAnalyticsView{
+		sess:       sess,
+		owner:      owner,
+		repoName:   repoName,
+		workflow:   workflow,
+		loading:    true,
+		parentView: parentView,
+		keys:       keyMapFor(viewWorkflowAnalytics),
+	}
+	m.keys.Download = withDesc(m.keys.Download, "Export CSV")
+
+	m.InitTop(owner, repoName, workflow.Name)
+	m.TopFields = []string{owner, repoName, workflow.Name, "Analytics"}
+	m.InitBottom()
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Download, m.keys.Refresh, m.keys.Back, m.keys.Help)
+	m.CommandInput = textinput.New()
+
+	m.viewport = viewport.New(0, 0)
+	m.viewport.KeyMap = viewport.KeyMap{Up: m.keys.Up, Down: m.keys.Down, PageUp: m.keys.PageUp, PageDown: m.keys.PageDown}
+	if constants.WindowSize.Height != 0 {
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	}
+
+	return m, loadWorkflowAnalyticsCmd(sess, owner, repoName, workflow.ID)
+}
+
+func (m *workflowAnalyticsView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *workflowAnalyticsView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case workflowAnalyticsLoadedMsg:
+		m.loading = false
+		m.err = msg.Err
+		if msg.Err == nil {
+			m.report = analytics.Analyze(msg.Runs)
+		}
+		m.rebuild()
+		return m, nil
+
+	case analyticsExportedMsg:
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Could not export: %v", msg.Err)
+		} else {
+			m.status = "Exported to " + msg.Path
+		}
+		m.rebuild()
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		constants.WindowSize = msg
+		m.resizeMain(msg.Width, msg.Height)
+		m.viewport.SetContent(renderAnalytics(m.report))
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.visibleCommand {
+			return m.handleExportInput(msg)
+		}
+
+		switch {
+		case isQuit(msg, m.keys):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return m.parentView, m.parentView.Init()
+		case m.loading:
+			return m, nil
+		case key.Matches(msg, m.keys.Refresh):
+			m.loading = true
+			m.status = ""
+			return m, loadWorkflowAnalyticsCmd(m.sess, m.owner, m.repoName, m.workflow.ID)
+		case key.Matches(msg, m.keys.Download):
+			if m.err != nil || len(m.report.Trend) == 0 {
+				return m, nil
+			}
+			m.CommandInput.Prompt = "Export CSV to: "
+			m.CommandInput.SetValue(strings.TrimSuffix(path.Base(m.workflow.Path), path.Ext(m.workflow.Path)) + "-analytics.csv")
+			m.CommandInput.CursorEnd()
+			m.CommandInput.Focus()
+			m.visibleCommand = true
+			m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// handleExportInput reads the file the CSV is written to
+func (m *workflowAnalyticsView) handleExportInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeExportInput()
+		return m, nil
+	case "enter":
+		file := strings.TrimSpace(m.CommandInput.Value())
+		if file == "" {
+			return m, nil
+		}
+		m.closeExportInput()
+		return m, exportAnalyticsCmd(m.report, file)
+	}
+
+	var cmd tea.Cmd
+	m.CommandInput, cmd = m.CommandInput.Update(msg)
+	return m, cmd
+}
+
+func (m *workflowAnalyticsView) closeExportInput() {
+	m.visibleCommand = false
+	m.CommandInput.Blur()
+	m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+}
+
+// rebuild refreshes the header and the charts after the report or the
+// status changed
+func (m *workflowAnalyticsView) rebuild() {
+	m.TopFields = []string{m.owner, m.repoName, m.workflow.Name, fmt.Sprintf("Analytics of the last %d completed runs", m.report.Workflow.Runs)}
+	if m.status != "" {
+		m.TopFields = append(m.TopFields, m.status)
+	}
+	m.viewport.SetContent(renderAnalytics(m.report))
+	if constants.WindowSize.Height != 0 {
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	}
+}
+
+// renderAnalytics draws the workflow figures with the trend of its runs,
+// then a line per job and per step with a bar of its median duration
+func renderAnalytics(report analytics.Report) string {
+	if len(report.Trend) == 0 {
+		return "This workflow has no completed run yet."
+	}
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Current.Muted).
+		Bold(true).
+		Width(10)
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(theme.Current.Muted)
+	barStyle := lipgloss.NewStyle().
+		Foreground(theme.Current.Primary)
+	headerStyle := lipgloss.NewStyle().
+		Foreground(theme.Current.Emphasis).
+		Bold(true)
+
+	var out strings.Builder
+	line := func(label, value string) {
+		out.WriteString(labelStyle.Render(label) + value + "\n")
+	}
+
+	wf := report.Workflow
+	line("Runs", fmt.Sprintf("%d completed, %d succeeded or failed", wf.Runs, wf.Concluded))
+	trendNote := func(change string) string {
+		if len(report.Trend) < analytics.MinTrendRuns {
+			return ""
+		}
+		return mutedStyle.Render("  newer half " + change)
+	}
+	line("Success", lipgloss.NewStyle().Foreground(theme.Current.Success).Render(analyticsCell(bar(wf.SuccessRate, 1, analyticsBarWidth), analyticsBarWidth+1))+
+		formatRate(wf)+
+		trendNote(fmt.Sprintf("%+.0f pts", report.SuccessChange*100)))
+	line("Duration", formatStats(wf.Duration)+trendNote(fmt.Sprintf("%+.0f%%", report.DurationChange*100)))
+	line("Queue", formatStats(wf.Queue))
+
+	values := make([]float64, len(report.Trend))
+	var results strings.Builder
+	for i, point := range report.Trend {
+		values[i] = point.Duration.Seconds()
+		icon, color := theme.RunStatus("completed", point.Conclusion)
+		results.WriteString(lipgloss.NewStyle().Foreground(color).Render(icon))
+	}
+	line("Trend", barStyle.Render(sparkline(values))+mutedStyle.Render("  duration, oldest to newest"))
+	line("Results", results.String())
+
+	if len(report.Jobs) == 0 {
+		return strings.TrimSuffix(out.String(), "\n")
+	}
+
+	top := 0.0
+	for _, job := range report.Jobs {
+		top = max(top, job.Duration.Median.Seconds())
+	}
+	out.WriteString("\n" + headerStyle.Render(analyticsCell("Job / step", analyticsNameWidth)+
+		analyticsCell("Success", analyticsCellWidth)+analyticsCell("Median", analyticsCellWidth)+
+		analyticsCell("P95", analyticsCellWidth)+analyticsCell("Queue", analyticsCellWidth)+"Median duration") + "\n")
+	row := func(name string, g analytics.Group, queue string) {
+		out.WriteString(analyticsCell(name, analyticsNameWidth) +
+			analyticsCell(formatRate(g), analyticsCellWidth) +
+			analyticsCell(formatDuration(g.Duration.Median), analyticsCellWidth) +
+			analyticsCell(formatDuration(g.Duration.P95), analyticsCellWidth) +
+			analyticsCell(queue, analyticsCellWidth) +
+			barStyle.Render(bar(g.Duration.Median.Seconds(), top, analyticsBarWidth)) + "\n")
+	}
+	for _, job := range report.Jobs {
+		row(job.Name, job, formatDuration(job.Queue.Median))
+		for _, step := range job.Steps {
+			row("  "+step.Name, step, "")
+		}
+	}
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// analyticsCell pads or truncates s to width cells, keeping a space after it
+func analyticsCell(s string, width int) string {
+	s = ansi.Truncate(s, width-1, "…")
+	return s + strings.Repeat(" ", width-ansi.StringWidth(s))
+}
+
+// formatRate renders a success rate as a percentage, or "-" when nothing
+// succeeded or failed
+func formatRate(g analytics.Group) string {
+	if g.Concluded == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", g.SuccessRate*100)
+}
+
+func formatStats(s analytics.Stats) string {
+	if s.Count == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("median %s, p95 %s", formatDuration(s.Median), formatDuration(s.P95))
+}
+
+// formatDuration renders d to the second, or "-" when it is unknown
+func formatDuration(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	return d.Round(time.Second).String()
+}
+
+func (m *workflowAnalyticsView) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit or 'backspace' to go back", m.err)
+	}
+
+	if m.loading {
+		return m.RenderTopFields() + "\n\nLoading runs and jobs..."
+	}
+
+	if m.visibleCommand {
+		return fmt.Sprintf(
+			"%s\n%s\n%s\n%s",
+			m.RenderTopFields(),
+			constants.CommandStyle.BorderForeground(theme.Current.Accent).Render(m.CommandInput.View()),
+			constants.MainStyle.Render(m.viewport.View()),
+			m.RenderBottomFields(),
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s",
+		m.RenderTopFields(),
+		constants.MainStyle.Render(m.viewport.View()),
+		m.RenderBottomFields(),
+	)
+}
+
+func (m *workflowAnalyticsView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Download, m.keys.Refresh, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
+
+func (m *workflowAnalyticsView) capturingInput() bool {
+	return m.visibleCommand
+}
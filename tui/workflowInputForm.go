@@ -2,25 +2,121 @@ package tui
 
 import (
 	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/state"
 	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
 )
 
+// inputField is a workflow_dispatch input of the form. Its widget depends
+// on the type of the input: a text input for strings and numbers, a
+// toggle for booleans and a picker for choices and environments.
 type inputField struct {
-	name        string
-	value       string
-	description string
-	required    bool
-	focused     bool
+	def      github.WorkflowInputDefinition
+	text     textinput.Model
+	options  []string // choices, or the environments of the repository
+	selected int      // index in options
+	checked  bool
+	err      string // why the value is refused, set when triggering
 }
 
+func newInputField(def github.WorkflowInputDefinition, environments []string) inputField {
+	f := inputField{def: def, text: newFormInput(def.Default)}
+	switch def.Type {
+	case "boolean":
+		f.checked = def.Default == "true"
+	case "choice":
+		f.options = def.Options
+	case "environment":
+		f.options = environments
+	}
+	f.selected = max(slices.Index(f.options, def.Default), 0)
+	return f
+}
+
+// newFormInput creates a text input without a blinking cursor, so the form
+// only redraws when typed into
+func newFormInput(value string) textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.Width = 50
+	ti.Cursor.SetMode(cursor.CursorStatic)
+	ti.SetValue(value)
+	return ti
+}
+
+// picker reports whether the field picks its value among options.
+// Environments are typed when the repository has none, or they could
+// not be loaded.
+func (f inputField) picker() bool {
+	return len(f.options) > 0 && (f.def.Type == "choice" || f.def.Type == "environment")
+}
+
+// set gives the field a value, as saved in a preset. Values the field
+// cannot take, such as a removed choice, are ignored.
+func (f *inputField) set(value string) {
+	switch {
+	case f.def.Type == "boolean":
+		f.checked = value == "true"
+	case f.picker():
+		if i := slices.Index(f.options, value); i >= 0 {
+			f.selected = i
+		}
+	default:
+		f.text.SetValue(value)
+	}
+}
+
+func (f inputField) value() string {
+	switch {
+	case f.def.Type == "boolean":
+		return strconv.FormatBool(f.checked)
+	case f.picker():
+		return f.options[f.selected]
+	default:
+		return strings.TrimSpace(f.text.Value())
+	}
+}
+
+// validate checks the value against the type of the input, as GitHub
+// would when dispatching
+func (f inputField) validate() string {
+	value := f.value()
+	switch {
+	case value == "" && f.def.Required:
+		return "required"
+	case value == "":
+		return ""
+	case f.def.Type == "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "must be a number"
+		}
+	case f.def.Type == "choice" && !slices.Contains(f.def.Options, value):
+		return "must be one of " + strings.Join(f.def.Options, ", ")
+	}
+	return ""
+}
+
+// clockSkew is subtracted from the time of a dispatch when looking for its
+// run, as the clocks of GitHub and of the machine may differ
+const clockSkew = 10 * time.Second
+
 type workflowInputFormView struct {
 	// Service
-	ghService *github.GitHubService
+	sess *session
 
 	// Context
 	owner        string
@@ -29,44 +125,180 @@ type workflowInputFormView struct {
 	workflowPath string
 
 	// State
-	branch       string
-	branchInput  string
+	branchInput  textinput.Model
+	branches     []string // of the repository, suggested by the ref picker
+	tags         []string
+	refEdited    bool   // the ref was typed or applied, the default branch is not wanted
+	refErr       string // why the ref is refused
+	suggestion   int    // highlighted ref suggestion
 	inputs       []inputField
-	focusedIndex int
+	environments []string // of the repository, for environment inputs
+	focusedIndex int      // 0 is the branch, then the inputs
 	triggering   bool
 	loading      bool
 	err          error
 	success      bool
 
+	// Linking the dispatched run
+	dispatchedAt time.Time
+	actor        string // the current user, who dispatched
+	attempt      int
+	candidates   []github.RunInfo // runs matching the dispatch, to pick from
+	candidate    int
+	linkErr      string
+
+	// Presets
+	prefill     *state.DispatchInputs // applied once the inputs are loaded
+	note        string                // where the values come from, or what was done
+	dispatched  state.DispatchInputs  // recorded with the run once it is found
+	naming      bool                  // typing the name of a new preset
+	nameInput   textinput.Model
+	choosing    bool // picking a saved preset
+	presetIndex int
+
 	// Return to parent
 	parentView tea.Model
+
+	// UI
+	keys KeyMap
+}
+
+// NewWorkflowInputForm creates a new workflow input form as an overlay,
+// filled with the values of the last dispatch of the workflow
+func NewWorkflowInputForm(sess *session, owner, repoName string, workflowID int64, workflowPath string, parentView tea.Model) (tea.Model, tea.Cmd) {
+	last, ok := sess.state.LastDispatch(state.WorkflowKey(owner, repoName, workflowPath))
+	if !ok {
+		return newWorkflowInputForm(sess, owner, repoName, workflowID, workflowPath, nil, "", parentView)
+	}
+	return newWorkflowInputForm(sess, owner, repoName, workflowID, workflowPath, &last, "Last used values", parentView)
 }
 
-// NewWorkflowInputForm creates a new workflow input form as an overlay
-func NewWorkflowInputForm(ghService *github.GitHubService, owner, repoName string, workflowID int64, workflowPath string, parentView tea.Model) (tea.Model, tea.Cmd) {
+// NewWorkflowRedispatchForm creates a workflow input form filled with the
+// inputs of an earlier run. The API does not return the inputs of a run,
+// so only those of the runs dispatched from tgr are known; the others are
+// dispatched again on their branch with the default values.
+func NewWorkflowRedispatchForm(sess *session, owner, repoName string, run *github.RunDetailInfo, parentView tea.Model) (tea.Model, tea.Cmd) {
+	inputs, ok := sess.state.RunInputs(state.WorkflowKey(owner, repoName, run.WorkflowPath), run.ID)
+	note := fmt.Sprintf("Inputs of run #%d", run.RunNumber)
+	if !ok {
+		inputs = state.DispatchInputs{Ref: run.Branch}
+		note = fmt.Sprintf("The inputs of run #%d are unknown, defaults are used", run.RunNumber)
+	}
+	return newWorkflowInputForm(sess, owner, repoName, run.WorkflowID, run.WorkflowPath, &inputs, note, parentView)
+}
+
+func newWorkflowInputForm(sess *session, owner, repoName string, workflowID int64, workflowPath string, prefill *state.DispatchInputs, note string, parentView tea.Model) (tea.Model, tea.Cmd) {
 	m := &workflowInputFormView{
-		ghService:    ghService,
+		sess:         sess,
 		owner:        owner,
 		repoName:     repoName,
 		workflowID:   workflowID,
 		workflowPath: workflowPath,
-		branchInput:  "main",
+		branchInput:  newFormInput("main"), // until the default branch is known
 		parentView:   parentView,
 		focusedIndex: 0,
 		loading:      true,
+		prefill:      prefill,
+		note:         note,
+		keys:         keyMapFor(viewWorkflowInputForm),
 	}
-
-	return m, m.ghService.LoadWorkflowInputsCmd(m.owner, m.repoName, m.workflowPath)
+	if prefill != nil && prefill.Ref != "" {
+		m.branchInput.SetValue(prefill.Ref)
+		m.refEdited = true
+	}
+	m.branchInput.Focus()
+	// Printable keys are typed into the fields, they cannot be actions here
+	m.keys.Select = withDesc(withoutRunes(m.keys.Select), "Trigger")
+	m.keys.NextField = withoutRunes(m.keys.NextField)
+	m.keys.PrevField = withoutRunes(m.keys.PrevField)
+	m.keys.Cancel = withoutRunes(m.keys.Cancel)
+	m.keys.Up = withoutRunes(m.keys.Up)
+	m.keys.Down = withoutRunes(m.keys.Down)
+	m.keys.SavePreset = withoutRunes(m.keys.SavePreset)
+	m.keys.Presets = withoutRunes(m.keys.Presets)
+
+	return m, tea.Batch(
+		loadRepoDetailsCmd(sess, owner, repoName),
+		loadBranchesCmd(sess, owner, repoName),
+		loadTagsCmd(sess, owner, repoName),
+		loadEnvironmentsCmd(sess, owner, repoName),
+		loadWorkflowInputsCmd(sess, owner, repoName, workflowPath),
+	)
 }
 
 func (m *workflowInputFormView) Init() tea.Cmd {
-	return m.ghService.LoadWorkflowInputsCmd(m.owner, m.repoName, m.workflowPath)
+	return nil
 }
 
 func (m *workflowInputFormView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
-	case github.WorkflowInputsLoadedMsg:
+	case repoDetailsLoadedMsg:
+		if msg.Err != nil {
+			slog.Debug("Loading the default branch failed", "error", msg.Err)
+			return m, nil
+		}
+		if !m.refEdited {
+			m.branchInput.SetValue(msg.Repo.MainBranch)
+		}
+		return m, nil
+
+	// Without branches or tags, refs are only checked when triggering
+	case branchesLoadedMsg:
+		if msg.Err != nil {
+			slog.Debug("Loading branches failed", "error", msg.Err)
+			return m, nil
+		}
+		m.branches = nil
+		for _, branch := range msg.Branches {
+			m.branches = append(m.branches, branch.Name)
+		}
+		return m, nil
+
+	case tagsLoadedMsg:
+		if msg.Err != nil {
+			slog.Debug("Loading tags failed", "error", msg.Err)
+			return m, nil
+		}
+		m.tags = nil
+		for _, tag := range msg.Tags {
+			m.tags = append(m.tags, tag.Name)
+		}
+		return m, nil
+
+	case refCheckedMsg:
+		switch {
+		case msg.Err != nil:
+			m.refErr = "cannot be checked: " + msg.Err.Error()
+		case !msg.Exists:
+			m.refErr = "no such branch or tag"
+		default:
+			return m, m.trigger()
+		}
+		m.triggering = false
+		m.focus(0)
+		return m, nil
+
+	case environmentsLoadedMsg:
+		if msg.Err != nil {
+			// Environment inputs are typed instead
+			slog.Debug("Loading environments failed", "error", msg.Err)
+			return m, nil
+		}
+		m.environments = nil
+		for _, env := range msg.Environments {
+			m.environments = append(m.environments, env.Name)
+		}
+		// The inputs may have been loaded first
+		for i, input := range m.inputs {
+			if input.def.Type == "environment" {
+				m.inputs[i] = newInputField(input.def, m.environments)
+				m.inputs[i].set(input.value())
+			}
+		}
+		return m, nil
+
+	case workflowInputsLoadedMsg:
 		m.loading = false
 		if msg.Err != nil {
 			// If error loading inputs, just show branch input (assume no inputs or error)
@@ -75,133 +307,387 @@ func (m *workflowInputFormView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		for _, input := range msg.Inputs {
-			m.inputs = append(m.inputs, inputField{
-				name:        input.Name,
-				value:       input.Default,
-				description: input.Description,
-				required:    input.Required,
-			})
+			m.inputs = append(m.inputs, newInputField(input, m.environments))
+		}
+		if m.prefill != nil {
+			m.apply(*m.prefill)
 		}
 		return m, nil
 
-	case github.WorkflowTriggeredMsg:
+	case workflowTriggeredMsg:
 		m.triggering = false
 		if msg.Err != nil {
 			m.err = msg.Err
 			return m, nil
 		}
 		m.success = true
+		m.sess.state.RememberDispatch(m.stateKey(), m.dispatched)
+		m.sess.saveState()
 		// Start looking for the new run
-		return m, m.ghService.FindLatestRunCmd(m.owner, m.repoName, m.workflowID)
+		return m, m.findRun()
 
-	case github.LatestRunFoundMsg:
+	case dispatchedRunsFoundMsg:
 		if msg.Err != nil {
-			// If we couldn't find the run, just stay on success screen
-			m.err = fmt.Errorf("triggered successfully but couldn't find run: %v", msg.Err)
+			// The run stays to be found in the run list
+			m.linkErr = fmt.Sprintf("Couldn't find the run: %v", msg.Err)
+			return m, nil
+		}
+		m.actor = msg.Actor
+		switch {
+		case len(msg.Runs) == 1:
+			return m.watch(msg.Runs[0].ID)
+		case len(msg.Runs) > 1:
+			// Several dispatches of the same user and ref at once
+			m.candidates = msg.Runs
+			return m, nil
+		case time.Since(m.dispatchedAt) > runLinkTimeout:
+			m.linkErr = fmt.Sprintf("No run found after %s, look for it in the run list", runLinkTimeout)
 			return m, nil
 		}
-		// Switch to watch view
-		return NewWorkflowRunWatch(m.ghService, m.owner, m.repoName, m.workflowID, msg.RunID)
+		m.attempt++
+		return m, m.findRun()
 
 	case tea.KeyMsg:
+		if len(m.candidates) > 0 {
+			return m.updateCandidates(msg)
+		}
+
 		// If success or error, escape returns to parent
 		if m.success || m.err != nil {
-			if msg.String() == "esc" || msg.String() == "enter" {
+			if key.Matches(msg, m.keys.Cancel) || key.Matches(msg, m.keys.Select) {
 				return m.parentView, nil
 			}
 			return m, nil
 		}
 
-		if m.triggering {
+		if m.triggering || m.loading {
+			return m, nil
+		}
+		if m.naming {
+			return m, m.updateNaming(msg)
+		}
+		if m.choosing {
+			m.updateChoosing(msg)
 			return m, nil
 		}
 
-		switch msg.String() {
-		case "ctrl+c", "esc":
+		if suggestions := m.suggestions(); len(suggestions) > 0 {
+			switch {
+			case key.Matches(msg, m.keys.Up):
+				m.suggestion = max(m.suggestion-1, 0)
+				return m, nil
+			case key.Matches(msg, m.keys.Down):
+				m.suggestion = min(m.suggestion+1, len(suggestions)-1)
+				return m, nil
+			case key.Matches(msg, m.keys.Select):
+				m.branchInput.SetValue(suggestions[m.suggestion].name)
+				m.branchInput.CursorEnd()
+				m.refErr = ""
+				return m, nil
+			}
+		}
+
+		totalFields := 1 + len(m.inputs) // branch + inputs
+		switch {
+		case key.Matches(msg, m.keys.Cancel):
 			return m.parentView, nil
 
-		case "tab", "down":
-			m.focusedIndex++
-			totalFields := 1 + len(m.inputs) // branch + inputs
-			if m.focusedIndex >= totalFields {
-				m.focusedIndex = 0
-			}
+		case key.Matches(msg, m.keys.NextField):
+			m.focus((m.focusedIndex + 1) % totalFields)
 
-		case "shift+tab", "up":
-			m.focusedIndex--
-			if m.focusedIndex < 0 {
-				totalFields := 1 + len(m.inputs)
-				m.focusedIndex = totalFields - 1
-			}
+		case key.Matches(msg, m.keys.PrevField):
+			m.focus((m.focusedIndex + totalFields - 1) % totalFields)
 
-		case "enter":
-			// Trigger the workflow
-			m.triggering = true
+		case key.Matches(msg, m.keys.SavePreset):
+			m.naming = true
+			m.nameInput = newFormInput("")
+			m.nameInput.Focus()
 
-			// Build inputs map
-			inputsMap := make(map[string]interface{})
-			for _, input := range m.inputs {
-				if input.value != "" {
-					inputsMap[input.name] = input.value
-				}
+		case key.Matches(msg, m.keys.Presets):
+			if len(m.sess.state.Presets(m.stateKey())) == 0 {
+				m.note = "No preset saved for this workflow"
+				return m, nil
 			}
+			m.choosing = true
+			m.presetIndex = 0
 
-			return m, m.ghService.TriggerWorkflowCmd(m.owner, m.repoName, m.workflowID, m.branchInput, inputsMap)
-
-		case "backspace":
-			if m.focusedIndex == 0 {
-				if len(m.branchInput) > 0 {
-					m.branchInput = m.branchInput[:len(m.branchInput)-1]
-				}
-			} else {
-				idx := m.focusedIndex - 1
-				if idx < len(m.inputs) && len(m.inputs[idx].value) > 0 {
-					m.inputs[idx].value = m.inputs[idx].value[:len(m.inputs[idx].value)-1]
-				}
+		case key.Matches(msg, m.keys.Select):
+			if !m.validate() {
+				return m, nil
+			}
+			m.triggering = true
+			// The lists hold the first 300 branches and 100 tags, others are
+			// looked up
+			if ref := strings.TrimSpace(m.branchInput.Value()); !m.knownRef(ref) {
+				return m, checkRefCmd(m.sess, m.owner, m.repoName, ref)
 			}
+			return m, m.trigger()
+
+		case m.focusedIndex == 0:
+			var cmd tea.Cmd
+			m.branchInput, cmd = m.branchInput.Update(msg)
+			m.refEdited = true
+			m.refErr = ""
+			m.suggestion = 0
+			return m, cmd
 
 		default:
-			// Add character to focused field
-			if len(msg.String()) == 1 {
-				if m.focusedIndex == 0 {
-					m.branchInput += msg.String()
-				} else {
-					idx := m.focusedIndex - 1
-					if idx < len(m.inputs) {
-						m.inputs[idx].value += msg.String()
-					}
-				}
-			}
+			return m, m.updateInput(&m.inputs[m.focusedIndex-1], msg)
 		}
 	}
 
 	return m, nil
 }
 
+// updateInput changes the focused input: booleans toggle with space,
+// pickers move with space and the arrows, the others are typed into
+func (m *workflowInputFormView) updateInput(f *inputField, msg tea.KeyMsg) tea.Cmd {
+	f.err = ""
+	switch {
+	case f.def.Type == "boolean":
+		if msg.Type == tea.KeySpace {
+			f.checked = !f.checked
+		}
+	case f.picker():
+		switch msg.Type {
+		case tea.KeySpace, tea.KeyRight:
+			f.selected = (f.selected + 1) % len(f.options)
+		case tea.KeyLeft:
+			f.selected = (f.selected + len(f.options) - 1) % len(f.options)
+		}
+	default:
+		var cmd tea.Cmd
+		f.text, cmd = f.text.Update(msg)
+		return cmd
+	}
+	return nil
+}
+
+// trigger dispatches the workflow with the values of the form
+func (m *workflowInputFormView) trigger() tea.Cmd {
+	m.dispatched = m.values()
+	m.dispatchedAt = time.Now()
+	inputs := make(map[string]interface{}, len(m.dispatched.Inputs))
+	for name, value := range m.dispatched.Inputs {
+		inputs[name] = value
+	}
+	return triggerWorkflowCmd(m.sess, m.owner, m.repoName, m.workflowID, m.dispatched.Ref, inputs)
+}
+
+// knownRef reports whether ref is one of the loaded branches or tags
+func (m *workflowInputFormView) knownRef(ref string) bool {
+	name := refName(ref)
+	return slices.Contains(m.branches, name) || slices.Contains(m.tags, name)
+}
+
+// suggestions returns the branches and tags matching the ref being typed,
+// while it is not a known ref
+func (m *workflowInputFormView) suggestions() []refOption {
+	ref := strings.TrimSpace(m.branchInput.Value())
+	if m.focusedIndex != 0 || m.knownRef(ref) {
+		return nil
+	}
+	refs := make([]refOption, 0, len(m.branches)+len(m.tags))
+	for _, branch := range m.branches {
+		refs = append(refs, refOption{name: branch})
+	}
+	for _, tag := range m.tags {
+		refs = append(refs, refOption{name: tag, tag: true})
+	}
+	matches := matchRefs(refs, ref)
+	return matches[:min(len(matches), maxRefSuggestions)]
+}
+
+// findRun looks for the run created by the dispatch, backing off between
+// attempts
+func (m *workflowInputFormView) findRun() tea.Cmd {
+	return findDispatchedRunsCmd(m.sess, m.owner, m.repoName, m.workflowID, m.actor, m.dispatched.Ref,
+		m.dispatchedAt.Add(-clockSkew), runLinkDelay(m.attempt))
+}
+
+// watch records the inputs of the dispatched run and switches to its watch
+// view
+func (m *workflowInputFormView) watch(runID int64) (tea.Model, tea.Cmd) {
+	m.sess.state.RecordRun(m.stateKey(), runID, m.dispatched)
+	m.sess.saveState()
+	return NewWorkflowRunWatch(m.sess, m.owner, m.repoName, m.workflowID, runID, nil)
+}
+
+// updateCandidates handles the choice among the runs matching the dispatch
+func (m *workflowInputFormView) updateCandidates(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
+		return m.parentView, nil
+	case key.Matches(msg, m.keys.Up):
+		m.candidate = max(m.candidate-1, 0)
+	case key.Matches(msg, m.keys.Down):
+		m.candidate = min(m.candidate+1, len(m.candidates)-1)
+	case key.Matches(msg, m.keys.Select):
+		return m.watch(m.candidates[m.candidate].ID)
+	}
+	return m, nil
+}
+
+// updateNaming handles the prompt naming a new preset, which saves the
+// current values under that name
+func (m *workflowInputFormView) updateNaming(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
+		m.naming = false
+	case key.Matches(msg, m.keys.Select):
+		name := strings.TrimSpace(m.nameInput.Value())
+		if name == "" {
+			return nil
+		}
+		m.sess.state.SavePreset(m.stateKey(), state.Preset{Name: name, DispatchInputs: m.values()})
+		m.sess.saveState()
+		m.naming = false
+		m.note = "Saved preset " + name
+	default:
+		var cmd tea.Cmd
+		m.nameInput, cmd = m.nameInput.Update(msg)
+		return cmd
+	}
+	return nil
+}
+
+// updateChoosing handles the list of presets: enter applies the
+// highlighted one and delete removes it
+func (m *workflowInputFormView) updateChoosing(msg tea.KeyMsg) {
+	presets := m.sess.state.Presets(m.stateKey())
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
+		m.choosing = false
+	case key.Matches(msg, m.keys.Up):
+		m.presetIndex = max(m.presetIndex-1, 0)
+	case key.Matches(msg, m.keys.Down):
+		m.presetIndex = min(m.presetIndex+1, len(presets)-1)
+	case key.Matches(msg, m.keys.Select):
+		preset := presets[m.presetIndex]
+		m.apply(preset.DispatchInputs)
+		m.choosing = false
+		m.note = "Preset " + preset.Name
+	case key.Matches(msg, m.keys.Delete):
+		name := presets[m.presetIndex].Name
+		m.sess.state.DeletePreset(m.stateKey(), name)
+		m.sess.saveState()
+		m.note = "Deleted preset " + name
+		m.choosing = len(presets) > 1
+		m.presetIndex = max(min(m.presetIndex, len(presets)-2), 0)
+	}
+}
+
+func (m *workflowInputFormView) stateKey() string {
+	return state.WorkflowKey(m.owner, m.repoName, m.workflowPath)
+}
+
+// apply fills the form with saved values. Inputs missing from them keep
+// their current value.
+func (m *workflowInputFormView) apply(values state.DispatchInputs) {
+	if values.Ref != "" {
+		m.branchInput.SetValue(values.Ref)
+		m.refEdited = true
+		m.refErr = ""
+	}
+	for i := range m.inputs {
+		f := &m.inputs[i]
+		f.err = ""
+		if value, ok := values.Inputs[f.def.Name]; ok {
+			f.set(value)
+		}
+	}
+}
+
+// values returns the ref and the non-empty input values of the form
+func (m *workflowInputFormView) values() state.DispatchInputs {
+	values := state.DispatchInputs{Ref: strings.TrimSpace(m.branchInput.Value()), Inputs: map[string]string{}}
+	for _, f := range m.inputs {
+		if value := f.value(); value != "" {
+			values.Inputs[f.def.Name] = value
+		}
+	}
+	return values
+}
+
+// focus moves the focus to a field, the branch being field 0
+func (m *workflowInputFormView) focus(index int) {
+	m.branchInput.Blur()
+	for i := range m.inputs {
+		m.inputs[i].text.Blur()
+	}
+	m.focusedIndex = index
+	if index == 0 {
+		m.branchInput.Focus()
+	} else {
+		m.inputs[index-1].text.Focus()
+	}
+}
+
+// validate checks every input. On failure, the focus moves to the first
+// refused field.
+func (m *workflowInputFormView) validate() bool {
+	first := -1
+	if strings.TrimSpace(m.branchInput.Value()) == "" {
+		m.refErr = "required"
+		first = 0
+	}
+
+	for i := range m.inputs {
+		f := &m.inputs[i]
+		f.err = f.validate()
+		if f.err != "" && first < 0 {
+			first = i + 1
+		}
+	}
+	if first >= 0 {
+		m.focus(first)
+		return false
+	}
+	return true
+}
+
 func (m *workflowInputFormView) View() string {
 	// Create the popup
 	var popup strings.Builder
 
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#FFFFFF")).
-		Background(lipgloss.Color("#5865F2")).
+		Foreground(theme.Current.OnPrimary).
+		Background(theme.Current.Primary).
 		Padding(0, 2)
 
 	if m.success {
 		popup.WriteString(titleStyle.Render("Workflow Triggered Successfully"))
 		popup.WriteString("\n\n")
 		successStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#22EE82"))
-		popup.WriteString(successStyle.Render("✓ Workflow has been queued for execution"))
+			Foreground(theme.Current.Success)
+		popup.WriteString(successStyle.Render(theme.Icons.JobSuccess + " Workflow has been queued for execution"))
 		popup.WriteString("\n\n")
-		popup.WriteString("Press ESC or Enter to continue")
+		switch {
+		case len(m.candidates) > 0:
+			popup.WriteString("Several runs match this dispatch, pick yours:\n")
+			for i, run := range m.candidates {
+				line := fmt.Sprintf("#%d  %s  %s  %s", run.RunNumber, run.Branch, run.CreatedAt.Format("2006-01-02 15:04:05"), run.Status)
+				popup.WriteString(m.renderValue(i == m.candidate, line))
+				popup.WriteString("\n")
+			}
+			popup.WriteString("\n")
+			popup.WriteString(helpLine(withDesc(m.keys.Select, "Watch"), m.keys.Cancel))
+		case m.linkErr != "":
+			popup.WriteString(m.linkErr)
+			popup.WriteString("\n\n")
+			popup.WriteString("Press ESC or Enter to continue")
+		default:
+			popup.WriteString("Looking for the run...")
+			popup.WriteString("\n\n")
+			popup.WriteString("Press ESC or Enter to continue")
+		}
 	} else if m.err != nil {
 		popup.WriteString(titleStyle.Render("Error Triggering Workflow"))
 		popup.WriteString("\n\n")
 		errorStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF0000"))
-		popup.WriteString(errorStyle.Render(fmt.Sprintf("✗ %v", m.err)))
+			Foreground(theme.Current.Failure)
+		popup.WriteString(errorStyle.Render(fmt.Sprintf("%s %v", theme.Icons.JobFailure, m.err)))
 		popup.WriteString("\n\n")
 		popup.WriteString("Press ESC to continue")
 	} else if m.triggering {
@@ -216,99 +702,171 @@ func (m *workflowInputFormView) View() string {
 		popup.WriteString(titleStyle.Render("Trigger Workflow"))
 		popup.WriteString("\n\n")
 
-		// Branch input
 		labelStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#8B949E")).
+			Foreground(theme.Current.Muted).
 			Bold(true)
+		descStyle := lipgloss.NewStyle().
+			Foreground(theme.Current.Subtle).
+			Italic(true)
+		errorStyle := lipgloss.NewStyle().
+			Foreground(theme.Current.Failure)
+		instrStyle := lipgloss.NewStyle().
+			Foreground(theme.Current.Subtle).
+			Italic(true)
 
-		focusedStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Background(lipgloss.Color("#1f2937")).
-			Padding(0, 1)
+		if m.note != "" {
+			popup.WriteString(descStyle.Render(m.note))
+			popup.WriteString("\n\n")
+		}
 
-		unfocusedStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#C9D1D9")).
-			Padding(0, 1)
+		if m.choosing {
+			m.renderPresets(&popup, labelStyle, instrStyle)
+			return m.place(popup.String())
+		}
 
+		// Branch input
 		popup.WriteString(labelStyle.Render("Branch/Ref:"))
+		if m.refErr != "" {
+			popup.WriteString("  " + errorStyle.Render(m.refErr))
+		}
 		popup.WriteString("\n")
-		if m.focusedIndex == 0 {
-			popup.WriteString(focusedStyle.Render(m.branchInput + "█"))
-		} else {
-			popup.WriteString(unfocusedStyle.Render(m.branchInput))
+		popup.WriteString(m.renderValue(m.focusedIndex == 0, m.branchInput.View()))
+		popup.WriteString("\n")
+		for i, ref := range m.suggestions() {
+			line := "  " + ref.name
+			if i == m.suggestion {
+				line = theme.Icons.Arrow + " " + ref.name
+			}
+			popup.WriteString(" " + line)
+			if ref.tag {
+				popup.WriteString(descStyle.Render(" tag"))
+			}
+			popup.WriteString("\n")
 		}
-		popup.WriteString("\n\n")
 
-		// Input fields
+		// Input fields, one line for the label and one for the value
 		for i, input := range m.inputs {
-			popup.WriteString(labelStyle.Render(input.name))
-			if input.required {
-				popup.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Render("*"))
+			popup.WriteString(labelStyle.Render(input.def.Name))
+			if input.def.Required {
+				popup.WriteString(errorStyle.Render("*"))
 			}
-			popup.WriteString("\n")
-			if input.description != "" {
-				descStyle := lipgloss.NewStyle().
-					Foreground(lipgloss.Color("#6B7280")).
-					Italic(true)
-				popup.WriteString(descStyle.Render(input.description))
-				popup.WriteString("\n")
+			if input.def.Type != "" && input.def.Type != "string" {
+				popup.WriteString(descStyle.Render(" (" + input.def.Type + ")"))
 			}
-
-			if m.focusedIndex == i+1 {
-				popup.WriteString(focusedStyle.Render(input.value + "█"))
-			} else {
-				popup.WriteString(unfocusedStyle.Render(input.value))
+			switch {
+			case input.err != "":
+				popup.WriteString("  " + errorStyle.Render(input.err))
+			case input.def.Description != "":
+				popup.WriteString("  " + descStyle.Render(input.def.Description))
 			}
+			popup.WriteString("\n")
+			popup.WriteString(m.renderValue(m.focusedIndex == i+1, m.renderInput(input)))
+			popup.WriteString("\n")
+		}
+
+		if m.naming {
+			popup.WriteString("\n")
+			popup.WriteString(labelStyle.Render("Preset name:"))
+			popup.WriteString("\n")
+			popup.WriteString(m.renderValue(true, m.nameInput.View()))
 			popup.WriteString("\n\n")
+			popup.WriteString(instrStyle.Render(helpLine(withDesc(m.keys.Select, "Save"), m.keys.Cancel)))
+			return m.place(popup.String())
 		}
 
 		// Instructions
-		instrStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#6B7280")).
-			Italic(true)
-		popup.WriteString(instrStyle.Render("Tab/↓: Next field  Shift+Tab/↑: Previous field"))
 		popup.WriteString("\n")
-		popup.WriteString(instrStyle.Render("Enter: Trigger  ESC: Cancel"))
+		popup.WriteString(instrStyle.Render(helpLine(m.keys.NextField, m.keys.PrevField)))
+		popup.WriteString("\n")
+		popup.WriteString(instrStyle.Render("space: Toggle/next choice  left/right: Choose"))
+		popup.WriteString("\n")
+		popup.WriteString(instrStyle.Render(helpLine(m.keys.SavePreset, m.keys.Presets)))
+		popup.WriteString("\n")
+		popup.WriteString(instrStyle.Render(helpLine(m.keys.Select, m.keys.Cancel)))
+	}
+
+	return m.place(popup.String())
+}
+
+// renderPresets lists the presets of the workflow with their ref and values
+func (m *workflowInputFormView) renderPresets(popup *strings.Builder, labelStyle, instrStyle lipgloss.Style) {
+	popup.WriteString(labelStyle.Render("Presets:"))
+	popup.WriteString("\n")
+	for i, preset := range m.sess.state.Presets(m.stateKey()) {
+		values := []string{"ref " + preset.Ref}
+		for _, name := range slices.Sorted(maps.Keys(preset.Inputs)) {
+			values = append(values, name+"="+preset.Inputs[name])
+		}
+		line := preset.Name + ": " + strings.Join(values, ", ")
+		popup.WriteString(m.renderValue(i == m.presetIndex, ansi.Truncate(line, 50, "…")))
+		popup.WriteString("\n")
 	}
+	popup.WriteString("\n")
+	popup.WriteString(instrStyle.Render(helpLine(withDesc(m.keys.Select, "Apply"), m.keys.Delete, m.keys.Cancel)))
+}
 
+// place centers the popup in the window
+func (m *workflowInputFormView) place(content string) string {
 	// Style the popup box
 	popupStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#5865F2")).
+		BorderForeground(theme.Current.Primary).
 		Padding(1, 2).
 		Width(60)
 
-	popupBox := popupStyle.Render(popup.String())
-
 	// Center the popup
-	width := constants.WindowSize.Width
-	height := constants.WindowSize.Height
-
-	popupHeight := lipgloss.Height(popupBox)
-	popupWidth := lipgloss.Width(popupBox)
-
-	overlayStyle := lipgloss.NewStyle().
-		Width(width).
-		Height(height).
-		Align(lipgloss.Center, lipgloss.Center)
-
-	// Position the popup
-	verticalPadding := (height - popupHeight) / 2
-	horizontalPadding := (width - popupWidth) / 2
-
-	positioned := lipgloss.Place(
-		width,
-		height,
+	return lipgloss.Place(
+		constants.WindowSize.Width,
+		constants.WindowSize.Height,
 		lipgloss.Center,
 		lipgloss.Center,
-		popupBox,
+		popupStyle.Render(content),
 		lipgloss.WithWhitespaceChars(" "),
 	)
+}
+
+// renderValue renders the value line of a field, highlighted when focused
+func (m *workflowInputFormView) renderValue(focused bool, value string) string {
+	if focused {
+		return lipgloss.NewStyle().
+			Foreground(theme.Current.Emphasis).
+			Background(theme.Current.Surface).
+			Padding(0, 1).
+			Render(value)
+	}
+	return lipgloss.NewStyle().
+		Foreground(theme.Current.Text).
+		Padding(0, 1).
+		Render(value)
+}
+
+// renderInput renders the widget of an input: a checkbox, the options
+// with the selected one bracketed, or the text typed
+func (m *workflowInputFormView) renderInput(f inputField) string {
+	switch {
+	case f.def.Type == "boolean":
+		if f.checked {
+			return "[x] true"
+		}
+		return "[ ] false"
+	case f.picker():
+		options := make([]string, len(f.options))
+		for i, option := range f.options {
+			options[i] = " " + option + " "
+			if i == f.selected {
+				options[i] = "[" + option + "]"
+			}
+		}
+		return strings.Join(options, "")
+	default:
+		return f.text.View()
+	}
+}
 
-	_ = overlayStyle
-	_ = verticalPadding
-	_ = horizontalPadding
+func (m *workflowInputFormView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Select, m.keys.NextField, m.keys.PrevField, m.keys.Cancel}
+}
 
-	// Overlay on parent view
-	return positioned
+func (m *workflowInputFormView) capturingInput() bool {
+	return !m.loading && !m.success && m.err == nil
 }
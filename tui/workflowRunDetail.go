@@ -4,17 +4,19 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/jjournet/tgr/github"
 	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
 )
 
 type workflowRunDetailView struct {
 	commonElements
 
 	// Service
-	ghService *github.GitHubService
+	sess *session
 
 	// Context
 	owner      string
@@ -26,6 +28,9 @@ type workflowRunDetailView struct {
 	runDetail *github.RunDetailInfo
 	loading   bool
 	err       error
+
+	// UI
+	keys KeyMap
 }
 
 func (m *workflowRunDetailView) resizeMain(w int, h int) {
@@ -35,26 +40,28 @@ func (m *workflowRunDetailView) resizeMain(w int, h int) {
 }
 
 // NewWorkflowRunDetail creates a new workflow run detail view model
-func NewWorkflowRunDetail(ghService *github.GitHubService, owner, repoName string, workflowID, runID int64) (tea.Model, tea.Cmd) {
+func NewWorkflowRunDetail(sess *session, owner, repoName string, workflowID, runID int64) (tea.Model, tea.Cmd) {
 	m := &workflowRunDetailView{
-		ghService:  ghService,
+		sess:       sess,
 		owner:      owner,
 		repoName:   repoName,
 		workflowID: workflowID,
 		runID:      runID,
 		loading:    true,
+		keys:       keyMapFor(viewWorkflowRunDetail),
 	}
+	m.keys.Trigger = withDesc(m.keys.Trigger, "Re-dispatch")
 
 	m.InitTop(owner, repoName, fmt.Sprintf("Loading run #%d...", runID))
 	m.TopFields = []string{owner, repoName, fmt.Sprintf("Run #%d", runID)}
 	m.InitBottom()
-	m.BottomFields = []string{"(q) Quit", "(backspace) Back"}
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Trigger, m.keys.Artifacts, m.keys.Back, m.keys.Help)
 
 	if constants.WindowSize.Height != 0 {
 		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
 	}
 
-	return m, ghService.LoadRunDetailCmd(owner, repoName, runID)
+	return m, loadRunDetailCmd(sess, owner, repoName, runID)
 }
 
 func (m *workflowRunDetailView) Init() tea.Cmd {
@@ -64,7 +71,7 @@ func (m *workflowRunDetailView) Init() tea.Cmd {
 func (m *workflowRunDetailView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
-	case github.RunDetailLoadedMsg:
+	case runDetailLoadedMsg:
 		if msg.Err != nil {
 			m.err = msg.Err
 			m.loading = false
@@ -88,17 +95,21 @@ func (m *workflowRunDetailView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		if m.loading {
-			if msg.String() == "q" || msg.String() == "ctrl+c" {
+			if isQuit(msg, m.keys) {
 				return m, tea.Quit
 			}
 			return m, nil
 		}
 
-		switch msg.String() {
-		case "q", "ctrl+c":
+		switch {
+		case isQuit(msg, m.keys):
 			return m, tea.Quit
-		case "backspace":
-			return NewWorkflowRunList(m.ghService, m.owner, m.repoName, m.workflowID)
+		case key.Matches(msg, m.keys.Back):
+			return NewWorkflowRunList(m.sess, m.owner, m.repoName, m.workflowID)
+		case key.Matches(msg, m.keys.Artifacts):
+			return NewArtifactList(m.sess, m.owner, m.repoName, m.runID, m.runDetail.RunNumber, m)
+		case key.Matches(msg, m.keys.Trigger):
+			return NewWorkflowRedispatchForm(m.sess, m.owner, m.repoName, m.runDetail, m)
 		}
 	}
 
@@ -117,22 +128,7 @@ func (m *workflowRunDetailView) View() string {
 	var content strings.Builder
 
 	// Status and Conclusion
-	statusColor := lipgloss.Color("#77c2f9")
-	statusIcon := "\uf128"
-
-	if m.runDetail.Status == "completed" && m.runDetail.Conclusion == "success" {
-		statusIcon = "\uf058"
-		statusColor = lipgloss.Color("#22EE82")
-	} else if m.runDetail.Status == "in_progress" {
-		statusIcon = "\uef0c"
-		statusColor = lipgloss.Color("#FFCC00")
-	} else if m.runDetail.Status == "queued" {
-		statusIcon = "󰚭"
-		statusColor = lipgloss.Color("#FFCC00")
-	} else if m.runDetail.Status == "completed" && m.runDetail.Conclusion == "failure" {
-		statusIcon = "\uea87"
-		statusColor = lipgloss.Color("#FF0000")
-	}
+	statusIcon, statusColor := theme.RunStatus(m.runDetail.Status, m.runDetail.Conclusion)
 
 	statusStyle := lipgloss.NewStyle().
 		Foreground(statusColor).
@@ -140,7 +136,7 @@ func (m *workflowRunDetailView) View() string {
 
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#FFFFFF"))
+		Foreground(theme.Current.Emphasis)
 
 	content.WriteString(statusStyle.Render(fmt.Sprintf("%s %s", statusIcon, strings.ToUpper(m.runDetail.Status))))
 	if m.runDetail.Conclusion != "" {
@@ -153,11 +149,11 @@ func (m *workflowRunDetailView) View() string {
 
 	// Metadata
 	labelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#8B949E")).
+		Foreground(theme.Current.Muted).
 		Bold(true)
 
 	valueStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#C9D1D9"))
+		Foreground(theme.Current.Text)
 
 	content.WriteString(labelStyle.Render("Run Number: "))
 	content.WriteString(valueStyle.Render(fmt.Sprintf("#%d", m.runDetail.RunNumber)))
@@ -192,11 +188,11 @@ func (m *workflowRunDetailView) View() string {
 	content.WriteString("\n\n")
 
 	// URLs
-	content.WriteString(strings.Repeat("─", constants.WindowSize.Width-4))
+	content.WriteString(strings.Repeat(theme.Icons.Separator, constants.WindowSize.Width-4))
 	content.WriteString("\n\n")
 
 	urlStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#58A6FF")).
+		Foreground(theme.Current.Link).
 		Underline(true)
 
 	content.WriteString(labelStyle.Render("GitHub URL: "))
@@ -218,3 +214,7 @@ func (m *workflowRunDetailView) View() string {
 		m.RenderBottomFields(),
 	)
 }
+
+func (m *workflowRunDetailView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Trigger, m.keys.Artifacts, m.keys.Back, m.keys.Quit}
+}
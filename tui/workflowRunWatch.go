@@ -2,21 +2,25 @@ package tui
 
 import (
 	"fmt"
+	"log/slog"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/jjournet/tgr/github"
 	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
 )
 
 type workflowRunWatchView struct {
 	commonElements
 
 	// Service
-	ghService *github.GitHubService
+	sess *session
 
 	// Context
 	owner      string
@@ -27,12 +31,22 @@ type workflowRunWatchView struct {
 	// State
 	runDetail *github.RunDetailInfo
 	jobs      []github.JobInfo
+	pending   []github.PendingDeploymentInfo // environments waiting for a review
+	status    string                         // result of the last review
 	loading   bool
 	err       error
 	viewport  viewport.Model
+	keys      KeyMap
+
+	// Review comment, typed before approving or rejecting
+	visibleCommand bool
+	approve        bool
 
 	// Refresh
 	refreshInterval time.Duration
+
+	// Navigation
+	parentView tea.Model // view to return to, the run list when nil
 }
 
 type tickMsg time.Time
@@ -40,28 +54,42 @@ type tickMsg time.Time
 func (m *workflowRunWatchView) resizeMain(w int, h int) {
 	headerHeight := lipgloss.Height(m.RenderTopFields())
 	footerHeight := lipgloss.Height(m.RenderBottomFields())
-	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2)
+	cmdHeight := 0
+	if m.visibleCommand {
+		cmdHeight = 3
+	}
+	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2 - cmdHeight)
 	m.viewport.Width = w - 4 // Account for borders/padding
-	m.viewport.Height = h - headerHeight - footerHeight - 2
+	m.viewport.Height = h - headerHeight - footerHeight - 2 - cmdHeight
+	constants.CommandStyle = constants.CommandStyle.Width(w - 2).Height(1)
 }
 
-// NewWorkflowRunWatch creates a new workflow run watch view model
-func NewWorkflowRunWatch(ghService *github.GitHubService, owner, repoName string, workflowID, runID int64) (tea.Model, tea.Cmd) {
+// NewWorkflowRunWatch creates a new workflow run watch view model. Back
+// returns to parentView, or to the runs of the workflow when it is nil.
+func NewWorkflowRunWatch(sess *session, owner, repoName string, workflowID, runID int64, parentView tea.Model) (tea.Model, tea.Cmd) {
 	m := &workflowRunWatchView{
-		ghService:       ghService,
+		parentView:      parentView,
+		sess:            sess,
 		owner:           owner,
 		repoName:        repoName,
 		workflowID:      workflowID,
 		runID:           runID,
 		loading:         true,
-		refreshInterval: 5 * time.Second,
+		refreshInterval: sess.settings.Refresh.RunWatch.Duration,
 		viewport:        viewport.New(0, 0),
+		keys:            keyMapFor(viewWorkflowRunWatch),
 	}
+	m.keys.Refresh = withDesc(m.keys.Refresh, "Refresh Now")
+	m.viewport.KeyMap.Up = m.keys.Up
+	m.viewport.KeyMap.Down = m.keys.Down
+	m.viewport.KeyMap.PageUp = m.keys.PageUp
+	m.viewport.KeyMap.PageDown = m.keys.PageDown
 
 	m.InitTop(owner, repoName, fmt.Sprintf("Watching run #%d...", runID))
 	m.TopFields = []string{owner, repoName, fmt.Sprintf("Watch Run #%d", runID)}
 	m.InitBottom()
-	m.BottomFields = []string{"(q) Quit", "(backspace) Back", "(r) Refresh Now"}
+	m.updateFooter()
+	m.CommandInput = textinput.New()
 
 	if constants.WindowSize.Height != 0 {
 		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
@@ -69,14 +97,14 @@ func NewWorkflowRunWatch(ghService *github.GitHubService, owner, repoName string
 
 	// Initial load
 	return m, tea.Batch(
-		ghService.LoadRunDetailCmd(owner, repoName, runID),
-		ghService.LoadRunJobsCmd(owner, repoName, runID),
+		loadRunDetailCmd(sess, owner, repoName, runID),
+		loadRunJobsCmd(sess, owner, repoName, runID),
 		m.tick(),
 	)
 }
 
 func (m *workflowRunWatchView) tick() tea.Cmd {
-	return tea.Tick(m.refreshInterval, func(t time.Time) tea.Msg {
+	return tick(m.refreshInterval, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
@@ -95,37 +123,66 @@ func (m *workflowRunWatchView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		return m, tea.Batch(
-			m.ghService.LoadRunDetailCmd(m.owner, m.repoName, m.runID),
-			m.ghService.LoadRunJobsCmd(m.owner, m.repoName, m.runID),
+			loadRunDetailCmd(m.sess, m.owner, m.repoName, m.runID),
+			loadRunJobsCmd(m.sess, m.owner, m.repoName, m.runID),
 			m.tick(),
 		)
 
-	case github.RunDetailLoadedMsg:
+	case runDetailLoadedMsg:
 		if msg.Err != nil {
 			m.err = msg.Err
 			return m, nil
 		}
+		// Only notify runs seen running, not runs opened once completed
+		finished := m.runDetail != nil && m.runDetail.Status != "completed" && msg.Run.Status == "completed"
 		m.runDetail = msg.Run
+		var cmds []tea.Cmd
+		if msg.Run.Status == "waiting" {
+			cmds = append(cmds, loadPendingDeploymentsCmd(m.sess, m.owner, m.repoName, m.runID))
+		} else if m.pending != nil {
+			m.pending = nil
+			m.updateFooter()
+		}
 		m.checkLoadingComplete()
-		atBottom := m.viewport.AtBottom()
-		m.viewport.SetContent(m.renderContent())
-		if atBottom {
-			m.viewport.GotoBottom()
+		m.refreshContent()
+		if finished {
+			cmds = append(cmds, m.sess.notifyRunCmd(m.owner+"/"+m.repoName, msg.Run.Name, msg.Run.Branch, msg.Run.ID, msg.Run.Conclusion))
 		}
+		return m, tea.Batch(cmds...)
+
+	case pendingDeploymentsLoadedMsg:
+		if msg.Err != nil {
+			slog.Debug("Loading pending deployments failed", "run", m.runID, "error", msg.Err)
+			return m, nil
+		}
+		m.pending = msg.Deployments
+		m.updateFooter()
+		m.refreshContent()
 		return m, nil
 
-	case github.RunJobsLoadedMsg:
+	case deploymentsReviewedMsg:
+		verb := "Rejected"
+		if msg.Approve {
+			verb = "Approved"
+		}
+		m.status = fmt.Sprintf("%s %s", verb, strings.Join(msg.Environments, ", "))
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Review failed: %v", msg.Err)
+		}
+		m.checkLoadingComplete()
+		return m, tea.Batch(
+			loadRunDetailCmd(m.sess, m.owner, m.repoName, m.runID),
+			loadRunJobsCmd(m.sess, m.owner, m.repoName, m.runID),
+		)
+
+	case runJobsLoadedMsg:
 		if msg.Err != nil {
 			m.err = msg.Err
 			return m, nil
 		}
 		m.jobs = msg.Jobs
 		m.checkLoadingComplete()
-		atBottom := m.viewport.AtBottom()
-		m.viewport.SetContent(m.renderContent())
-		if atBottom {
-			m.viewport.GotoBottom()
-		}
+		m.refreshContent()
 		return m, nil
 
 	case tea.WindowSizeMsg:
@@ -134,15 +191,38 @@ func (m *workflowRunWatchView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
+		if m.visibleCommand {
+			return m.handleCommentInput(msg)
+		}
+
+		switch {
+		case isQuit(msg, m.keys):
 			return m, tea.Quit
-		case "backspace":
-			return NewWorkflowRunList(m.ghService, m.owner, m.repoName, m.workflowID)
-		case "r":
+		case key.Matches(msg, m.keys.Approve), key.Matches(msg, m.keys.Reject):
+			if len(m.reviewable()) == 0 {
+				return m, nil
+			}
+			m.approve = key.Matches(msg, m.keys.Approve)
+			verb := "Reject"
+			if m.approve {
+				verb = "Approve"
+			}
+			m.CommandInput.Prompt = fmt.Sprintf("%s %s, comment: ", verb, environmentNames(m.reviewable()))
+			m.CommandInput.SetValue("")
+			m.CommandInput.Focus()
+			m.visibleCommand = true
+			m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+			return m, nil
+		case key.Matches(msg, m.keys.Back):
+			if m.parentView != nil {
+				// Init lets the parent resume its polling
+				return m.parentView, m.parentView.Init()
+			}
+			return NewWorkflowRunList(m.sess, m.owner, m.repoName, m.workflowID)
+		case key.Matches(msg, m.keys.Refresh):
 			return m, tea.Batch(
-				m.ghService.LoadRunDetailCmd(m.owner, m.repoName, m.runID),
-				m.ghService.LoadRunJobsCmd(m.owner, m.repoName, m.runID),
+				loadRunDetailCmd(m.sess, m.owner, m.repoName, m.runID),
+				loadRunJobsCmd(m.sess, m.owner, m.repoName, m.runID),
 			)
 		}
 	}
@@ -152,10 +232,73 @@ func (m *workflowRunWatchView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// handleCommentInput reads the review comment, then approves or rejects
+// the deployments the user can review
+func (m *workflowRunWatchView) handleCommentInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "esc":
+		m.visibleCommand = false
+		m.CommandInput.Blur()
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+		if msg.String() == "esc" {
+			return m, nil
+		}
+		return m, reviewDeploymentsCmd(m.sess, m.owner, m.repoName, m.runID, m.reviewable(), m.approve, m.CommandInput.Value())
+	}
+
+	var cmd tea.Cmd
+	m.CommandInput, cmd = m.CommandInput.Update(msg)
+	return m, cmd
+}
+
+// reviewable returns the pending deployments the user can approve or reject
+func (m *workflowRunWatchView) reviewable() []github.PendingDeploymentInfo {
+	var reviewable []github.PendingDeploymentInfo
+	for _, d := range m.pending {
+		if d.CanApprove {
+			reviewable = append(reviewable, d)
+		}
+	}
+	return reviewable
+}
+
+func environmentNames(deployments []github.PendingDeploymentInfo) string {
+	names := make([]string, len(deployments))
+	for i, d := range deployments {
+		names[i] = d.Environment
+	}
+	return strings.Join(names, ", ")
+}
+
+// updateFooter shows the review keys while deployments wait for the user
+func (m *workflowRunWatchView) updateFooter() {
+	if len(m.reviewable()) > 0 {
+		m.BottomFields = footerFields(m.keys.Quit, m.keys.Back, m.keys.Approve, m.keys.Reject, m.keys.Refresh, m.keys.Help)
+	} else {
+		m.BottomFields = footerFields(m.keys.Quit, m.keys.Back, m.keys.Refresh, m.keys.Help)
+	}
+}
+
+// refreshContent renders the run again, following the end of the log
+// when it was already shown
+func (m *workflowRunWatchView) refreshContent() {
+	if m.loading {
+		return
+	}
+	atBottom := m.viewport.AtBottom()
+	m.viewport.SetContent(m.renderContent())
+	if atBottom {
+		m.viewport.GotoBottom()
+	}
+}
+
 func (m *workflowRunWatchView) checkLoadingComplete() {
 	if m.runDetail != nil && m.jobs != nil {
 		m.loading = false
-		m.TopFields[2] = fmt.Sprintf("Watch Run #%d - %s (%s)", m.runDetail.RunNumber, m.runDetail.Name, m.runDetail.Status)
+		m.TopFields = []string{m.owner, m.repoName, fmt.Sprintf("Watch Run #%d - %s (%s)", m.runDetail.RunNumber, m.runDetail.Name, m.runDetail.Status)}
+		if m.status != "" {
+			m.TopFields = append(m.TopFields, m.status)
+		}
 	}
 }
 
@@ -163,22 +306,7 @@ func (m *workflowRunWatchView) renderContent() string {
 	var content strings.Builder
 
 	// Run Status Header
-	statusColor := lipgloss.Color("#77c2f9")
-	statusIcon := "\uf128"
-
-	if m.runDetail.Status == "completed" && m.runDetail.Conclusion == "success" {
-		statusIcon = "\uf058"
-		statusColor = lipgloss.Color("#22EE82")
-	} else if m.runDetail.Status == "in_progress" {
-		statusIcon = "\uef0c"
-		statusColor = lipgloss.Color("#FFCC00")
-	} else if m.runDetail.Status == "queued" {
-		statusIcon = "󰚭"
-		statusColor = lipgloss.Color("#FFCC00")
-	} else if m.runDetail.Status == "completed" && m.runDetail.Conclusion == "failure" {
-		statusIcon = "\uea87"
-		statusColor = lipgloss.Color("#FF0000")
-	}
+	statusIcon, statusColor := theme.RunStatus(m.runDetail.Status, m.runDetail.Conclusion)
 
 	statusStyle := lipgloss.NewStyle().Foreground(statusColor).Bold(true)
 	content.WriteString(statusStyle.Render(fmt.Sprintf("%s %s", statusIcon, strings.ToUpper(m.runDetail.Status))))
@@ -194,24 +322,32 @@ func (m *workflowRunWatchView) renderContent() string {
 	content.WriteString(fmt.Sprintf("  (%s)", duration.Round(time.Second)))
 	content.WriteString("\n\n")
 
+	// Deployments waiting for a review
+	if len(m.pending) > 0 {
+		pendingStyle := lipgloss.NewStyle().Foreground(theme.Current.Pending).Bold(true)
+		mutedStyle := lipgloss.NewStyle().Foreground(theme.Current.Muted)
+		content.WriteString(pendingStyle.Render("Waiting for review"))
+		content.WriteString("\n")
+		for _, d := range m.pending {
+			line := "  " + d.Environment
+			if len(d.Reviewers) > 0 {
+				line += "  reviewers: " + strings.Join(d.Reviewers, ", ")
+			}
+			if d.WaitTimer > 0 {
+				line += fmt.Sprintf("  wait timer: %dm", d.WaitTimer)
+			}
+			if !d.CanApprove {
+				line += mutedStyle.Render("  (you cannot review)")
+			}
+			content.WriteString(line)
+			content.WriteString("\n")
+		}
+		content.WriteString("\n")
+	}
+
 	// Jobs and Steps
 	for _, job := range m.jobs {
-		jobIcon := "○"
-		jobColor := lipgloss.Color("#8B949E") // Grey
-
-		if job.Status == "completed" && job.Conclusion == "success" {
-			jobIcon = "✓"
-			jobColor = lipgloss.Color("#22EE82")
-		} else if job.Status == "in_progress" {
-			jobIcon = "●" // or spinner
-			jobColor = lipgloss.Color("#FFCC00")
-		} else if job.Status == "queued" {
-			jobIcon = "○"
-			jobColor = lipgloss.Color("#FFCC00")
-		} else if job.Status == "completed" && job.Conclusion == "failure" {
-			jobIcon = "✗"
-			jobColor = lipgloss.Color("#FF0000")
-		}
+		jobIcon, jobColor := theme.JobStatus(job.Status, job.Conclusion)
 
 		jobStyle := lipgloss.NewStyle().Foreground(jobColor).Bold(true)
 		content.WriteString(jobStyle.Render(fmt.Sprintf("%s %s", jobIcon, job.Name)))
@@ -223,34 +359,19 @@ func (m *workflowRunWatchView) renderContent() string {
 				jobEnd = job.CompletedAt
 			}
 			jobDuration := jobEnd.Sub(job.StartedAt)
-			content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#5865F2")).Render(fmt.Sprintf(" (%s)", jobDuration.Round(time.Second))))
+			content.WriteString(lipgloss.NewStyle().Foreground(theme.Current.Primary).Render(fmt.Sprintf(" (%s)", jobDuration.Round(time.Second))))
 		}
 		content.WriteString("\n")
 
 		// Steps
 		for _, step := range job.Steps {
-			stepIcon := "  ○"
-			stepColor := lipgloss.Color("#8B949E")
-
-			if step.Status == "completed" && step.Conclusion == "success" {
-				stepIcon = "  ✓"
-				stepColor = lipgloss.Color("#22EE82")
-			} else if step.Status == "in_progress" {
-				stepIcon = "  ●"
-				stepColor = lipgloss.Color("#FFCC00")
-			} else if step.Status == "queued" {
-				stepIcon = "  ○"
-				stepColor = lipgloss.Color("#8B949E")
-			} else if step.Status == "completed" && step.Conclusion == "failure" {
-				stepIcon = "  ✗"
-				stepColor = lipgloss.Color("#FF0000")
-			} else if step.Status == "completed" && step.Conclusion == "skipped" {
-				stepIcon = "  -"
-				stepColor = lipgloss.Color("#8B949E")
+			stepIcon, stepColor := theme.JobStatus(step.Status, step.Conclusion)
+			if step.Status == "queued" {
+				stepColor = theme.Current.Muted
 			}
 
 			stepStyle := lipgloss.NewStyle().Foreground(stepColor)
-			content.WriteString(stepStyle.Render(fmt.Sprintf("%s %s", stepIcon, step.Name)))
+			content.WriteString(stepStyle.Render(fmt.Sprintf("  %s %s", stepIcon, step.Name)))
 			content.WriteString("\n")
 		}
 		content.WriteString("\n")
@@ -267,6 +388,16 @@ func (m *workflowRunWatchView) View() string {
 		return m.RenderTopFields() + "\n\nLoading run details and jobs..."
 	}
 
+	if m.visibleCommand {
+		return fmt.Sprintf(
+			"%s\n%s\n%s\n%s",
+			m.RenderTopFields(),
+			constants.CommandStyle.BorderForeground(theme.Current.Accent).Render(m.CommandInput.View()),
+			constants.MainStyle.Render(m.viewport.View()),
+			m.RenderBottomFields(),
+		)
+	}
+
 	return fmt.Sprintf(
 		"%s\n%s\n%s",
 		m.RenderTopFields(),
@@ -274,3 +405,14 @@ func (m *workflowRunWatchView) View() string {
 		m.RenderBottomFields(),
 	)
 }
+
+func (m *workflowRunWatchView) helpBindings() []key.Binding {
+	if len(m.reviewable()) > 0 {
+		return []key.Binding{m.keys.Approve, m.keys.Reject, m.keys.Refresh, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+	}
+	return []key.Binding{m.keys.Refresh, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
+
+func (m *workflowRunWatchView) capturingInput() bool {
+	return m.visibleCommand
+}
@@ -2,19 +2,23 @@ package tui
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/evertras/bubble-table/table"
 	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/state"
 	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
 )
 
 type workflowRunListView struct {
 	commonElements
 
 	// Service
-	ghService *github.GitHubService
+	sess *session
 
 	// Context
 	owner      string
@@ -23,11 +27,15 @@ type workflowRunListView struct {
 
 	// State
 	runs    []github.RunInfo
+	filter  github.RunFilter // saved per workflow
+	login   string           // the current user, for the only mine toggle
+	status  string
 	loading bool
 	err     error
 
 	// UI
 	EltList table.Model
+	keys    KeyMap
 }
 
 func (m *workflowRunListView) resizeMain(w int, h int) {
@@ -36,23 +44,26 @@ func (m *workflowRunListView) resizeMain(w int, h int) {
 	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2)
 }
 
-// NewWorkflowRunList creates a new workflow run list view model
-func NewWorkflowRunList(ghService *github.GitHubService, owner, repoName string, workflowID int64) (tea.Model, tea.Cmd) {
+// NewWorkflowRunList creates a new workflow run list view model, with the
+// filter last used for the workflow
+func NewWorkflowRunList(sess *session, owner, repoName string, workflowID int64) (tea.Model, tea.Cmd) {
 	m := &workflowRunListView{
-		ghService:  ghService,
+		sess:       sess,
 		owner:      owner,
 		repoName:   repoName,
 		workflowID: workflowID,
+		filter:     github.RunFilter(sess.state.RunFilter(state.RunFilterKey(owner, repoName, workflowID))),
 		loading:    true,
+		keys:       keyMapFor(viewWorkflowRunList),
 	}
 
 	m.InitTop(owner, repoName, fmt.Sprintf("Loading runs for workflow %d...", workflowID))
 	m.TopFields = []string{owner, repoName, fmt.Sprintf("Workflow Run List for %d", workflowID)}
 	m.InitBottom()
-	m.BottomFields = []string{"(q) Quit", "(enter) Select", "(w) Watch", "(backspace) Back"}
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Select, m.keys.Watch, m.keys.Filter, m.keys.OnlyMine, m.keys.OnlyFailures, m.keys.Back, m.keys.Help)
 
 	// Load workflow runs asynchronously
-	return m, ghService.LoadWorkflowRunsCmd(owner, repoName, workflowID)
+	return m, loadWorkflowRunsCmd(sess, owner, repoName, workflowID, m.filter)
 }
 
 func (m *workflowRunListView) Init() tea.Cmd {
@@ -62,17 +73,22 @@ func (m *workflowRunListView) Init() tea.Cmd {
 func (m *workflowRunListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
-	case github.WorkflowRunsLoadedMsg:
-		if msg.Err != nil {
+	case workflowRunsLoadedMsg:
+		m.loading = false
+		switch {
+		case msg.Err != nil && m.filter == (github.RunFilter{}):
 			m.err = msg.Err
-			m.loading = false
 			return m, nil
+		case msg.Err != nil:
+			// The saved filter may be refused, it stays editable
+			m.runs = nil
+			m.setTopFields()
+			m.TopFields = append(m.TopFields, "Loading runs failed: "+msg.Err.Error())
+		default:
+			m.runs = msg.Runs
+			m.setTopFields()
 		}
 
-		m.runs = msg.Runs
-		m.loading = false
-		m.TopFields[2] = fmt.Sprintf("Workflow Run List (%d runs)", len(m.runs))
-
 		// Build UI table
 		m.EltList = m.buildWorkflowRunListModel()
 
@@ -82,6 +98,18 @@ func (m *workflowRunListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, nil
 
+	case runFilterChangedMsg:
+		return m, m.applyFilter(msg.Filter)
+
+	case userLoadedMsg:
+		if msg.Err != nil {
+			m.status = "Loading the current user failed: " + msg.Err.Error()
+			m.setTopFields()
+			return m, nil
+		}
+		m.login = msg.Login
+		return m, m.toggleMine()
+
 	case tea.WindowSizeMsg:
 		constants.WindowSize = msg
 		m.resizeMain(msg.Width, msg.Height)
@@ -89,27 +117,47 @@ func (m *workflowRunListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		if m.loading {
-			if msg.String() == "q" || msg.String() == "ctrl+c" {
+			if isQuit(msg, m.keys) {
 				return m, tea.Quit
 			}
 			return m, nil
 		}
 
-		switch msg.String() {
-		case "q", "ctrl+c":
+		switch {
+		case isQuit(msg, m.keys):
 			return m, tea.Quit
-		case "backspace":
-			return NewWorkflowList(m.ghService, m.owner, m.repoName)
-		case "enter":
+		case key.Matches(msg, m.keys.Back):
+			return NewWorkflowList(m.sess, m.owner, m.repoName)
+		case key.Matches(msg, m.keys.Select):
+			if len(m.EltList.GetVisibleRows()) == 0 {
+				return m, nil
+			}
 			// Get the selected run
 			row := m.EltList.HighlightedRow()
 			runID := row.Data["id"].(int64)
-			return NewWorkflowRunDetail(m.ghService, m.owner, m.repoName, m.workflowID, runID)
-		case "w":
+			return NewWorkflowRunDetail(m.sess, m.owner, m.repoName, m.workflowID, runID)
+		case key.Matches(msg, m.keys.Watch):
+			if len(m.EltList.GetVisibleRows()) == 0 {
+				return m, nil
+			}
 			// Get the selected run
 			row := m.EltList.HighlightedRow()
 			runID := row.Data["id"].(int64)
-			return NewWorkflowRunWatch(m.ghService, m.owner, m.repoName, m.workflowID, runID)
+			return NewWorkflowRunWatch(m.sess, m.owner, m.repoName, m.workflowID, runID, nil)
+		case key.Matches(msg, m.keys.Filter):
+			return NewRunFilterForm(m.filter, m)
+		case key.Matches(msg, m.keys.OnlyMine):
+			if m.login == "" {
+				return m, loadUserCmd(m.sess)
+			}
+			return m, m.toggleMine()
+		case key.Matches(msg, m.keys.OnlyFailures):
+			filter := m.filter
+			filter.Status = "failure"
+			if m.filter.Status == filter.Status {
+				filter.Status = ""
+			}
+			return m, m.applyFilter(filter)
 		}
 	}
 
@@ -122,6 +170,57 @@ func (m *workflowRunListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// toggleMine filters the runs of the current user, or stops filtering them
+func (m *workflowRunListView) toggleMine() tea.Cmd {
+	filter := m.filter
+	filter.Actor = m.login
+	if m.filter.Actor == filter.Actor {
+		filter.Actor = ""
+	}
+	return m.applyFilter(filter)
+}
+
+// applyFilter saves the filter of the workflow and reloads its runs
+func (m *workflowRunListView) applyFilter(filter github.RunFilter) tea.Cmd {
+	m.filter = filter
+	m.sess.state.SetRunFilter(state.RunFilterKey(m.owner, m.repoName, m.workflowID), state.RunFilter(filter))
+	m.sess.saveState()
+	m.status = ""
+	m.loading = true
+	return loadWorkflowRunsCmd(m.sess, m.owner, m.repoName, m.workflowID, filter)
+}
+
+// setTopFields shows the run count, the filter and the status in the top bar
+func (m *workflowRunListView) setTopFields() {
+	m.TopFields = []string{m.owner, m.repoName, fmt.Sprintf("Workflow Run List (%d runs)", len(m.runs))}
+	if filter := describeRunFilter(m.filter); filter != "" {
+		m.TopFields = append(m.TopFields, filter)
+	}
+	if m.status != "" {
+		m.TopFields = append(m.TopFields, m.status)
+	}
+}
+
+// describeRunFilter renders the non-empty fields of a filter, e.g.
+// "branch:main status:failure"
+func describeRunFilter(filter github.RunFilter) string {
+	fields := []struct{ name, value string }{
+		{"branch", filter.Branch},
+		{"actor", filter.Actor},
+		{"event", filter.Event},
+		{"status", filter.Status},
+		{"created", filter.Created},
+		{"sha", filter.HeadSHA},
+	}
+	var parts []string
+	for _, field := range fields {
+		if field.value != "" {
+			parts = append(parts, field.name+":"+field.value)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
 func (m *workflowRunListView) View() string {
 	if m.err != nil {
 		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit or 'backspace' to go back", m.err)
@@ -134,7 +233,7 @@ func (m *workflowRunListView) View() string {
 	for i, row := range m.EltList.GetVisibleRows() {
 		row.Data["arrow"] = ""
 		if i == m.EltList.GetHighlightedRowIndex() {
-			row.Data["arrow"] = "\uf0a9"
+			row.Data["arrow"] = theme.Icons.Arrow
 		}
 	}
 
@@ -164,32 +263,17 @@ func (m *workflowRunListView) buildWorkflowRunListModel() table.Model {
 	}
 
 	return table.New(columns).WithRows(rows).
+		WithKeyMap(m.keys.tableKeyMap()).
 		Focused(true).
 		Border(table.Border{}).
 		WithBaseStyle(constants.BaseTableStyle).
 		HighlightStyle(constants.HighlightedLineStyle).
-		Filtered(true).
 		WithHighlightedRow(0).
 		WithFooterVisibility(false)
 }
 
 func makeRunRow(run github.RunInfo) table.Row {
-	indicator := "\uf128"
-	color := lipgloss.Color("#77c2f9")
-
-	if run.Status == "completed" && run.Conclusion == "success" {
-		indicator = "\uf058"
-		color = lipgloss.Color("#22EE82")
-	} else if run.Status == "in_progress" {
-		indicator = "\uef0c"
-		color = lipgloss.Color("#FFCC00")
-	} else if run.Status == "queued" {
-		indicator = "󰚭"
-		color = lipgloss.Color("#FFCC00")
-	} else if run.Status == "completed" && run.Conclusion == "failure" {
-		indicator = "\uea87"
-		color = lipgloss.Color("#FF0000")
-	}
+	indicator, color := theme.RunStatus(run.Status, run.Conclusion)
 
 	createdAt := run.CreatedAt.Format("2006-01-02 15:04:05")
 
@@ -204,3 +288,7 @@ func makeRunRow(run github.RunInfo) table.Row {
 		"id":         run.ID,
 	})
 }
+
+func (m *workflowRunListView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Select, m.keys.Watch, m.keys.Filter, m.keys.OnlyMine, m.keys.OnlyFailures, m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jjournet/tgr/github"
+	"github.com/jjournet/tgr/tui/constants"
+	"github.com/jjournet/tgr/tui/theme"
+	"github.com/muesli/termenv"
+)
+
+type workflowSourceView struct {
+	commonElements
+
+	// Service
+	sess *session
+
+	// Context
+	owner    string
+	repoName string
+	workflow github.WorkflowInfo
+
+	// State
+	loading bool
+	err     error
+
+	// UI
+	viewport viewport.Model
+	keys     KeyMap
+
+	// Navigation
+	parentView tea.Model
+}
+
+func (m *workflowSourceView) resizeMain(w int, h int) {
+	headerHeight := lipgloss.Height(m.RenderTopFields())
+	footerHeight := lipgloss.Height(m.RenderBottomFields())
+	constants.MainStyle = constants.MainStyle.Width(w - 2).Height(h - headerHeight - footerHeight - 2)
+	m.viewport.Width = w - 2
+	m.viewport.Height = max(h-headerHeight-footerHeight-2, 1)
+}
+
+// NewWorkflowSource creates a view showing the YAML file of a workflow,
+// highlighted. Back returns to parentView.
+func NewWorkflowSource(sess *session, owner, repoName string, workflow github.WorkflowInfo, parentView tea.Model) (tea.Model, tea.Cmd) {
+	m := &workflowSourceView{
+		sess:       sess,
+		owner:      owner,
+		repoName:   repoName,
+		workflow:   workflow,
+		loading:    true,
+		parentView: parentView,
+		keys:       keyMapFor(viewWorkflowSource),
+	}
+
+	m.InitTop(owner, repoName, workflow.Path)
+	m.TopFields = []string{owner, repoName, workflow.Name, workflow.Path}
+	m.InitBottom()
+	m.BottomFields = footerFields(m.keys.Quit, m.keys.Back, m.keys.Help)
+
+	m.viewport = viewport.New(0, 0)
+	m.viewport.KeyMap = viewport.KeyMap{Up: m.keys.Up, Down: m.keys.Down, PageUp: m.keys.PageUp, PageDown: m.keys.PageDown}
+	if constants.WindowSize.Height != 0 {
+		m.resizeMain(constants.WindowSize.Width, constants.WindowSize.Height)
+	}
+
+	return m, loadWorkflowFileCmd(sess, owner, repoName, workflow.Path)
+}
+
+func (m *workflowSourceView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *workflowSourceView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case workflowFileLoadedMsg:
+		m.loading = false
+		m.err = msg.Err
+		m.viewport.SetContent(numberLines(highlightSource(msg.Content, "yaml")))
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		constants.WindowSize = msg
+		m.resizeMain(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case isQuit(msg, m.keys):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return m.parentView, m.parentView.Init()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// highlightSource colors source code for the terminal, falling back to
+// the source when it cannot be highlighted
+func highlightSource(source, language string) string {
+	formatter := "noop"
+	switch lipgloss.ColorProfile() {
+	case termenv.TrueColor:
+		formatter = "terminal16m"
+	case termenv.ANSI256:
+		formatter = "terminal256"
+	case termenv.ANSI:
+		formatter = "terminal16"
+	}
+	style := "github"
+	if lipgloss.HasDarkBackground() {
+		style = "monokai"
+	}
+
+	lexer := lexers.Get(language)
+	if lexer == nil {
+		return source
+	}
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, source)
+	if err == nil {
+		var out strings.Builder
+		if err = formatters.Get(formatter).Format(&out, styles.Get(style), iterator); err == nil {
+			return out.String()
+		}
+	}
+	slog.Debug("Highlighting source failed", "language", language, "error", err)
+	return source
+}
+
+// numberLines prefixes every line with its number
+func numberLines(source string) string {
+	muted := lipgloss.NewStyle().Foreground(theme.Current.Muted)
+	lines := strings.Split(strings.TrimRight(source, "\n"), "\n")
+	width := len(fmt.Sprint(len(lines)))
+	for i, line := range lines {
+		lines[i] = muted.Render(fmt.Sprintf("%*d ", width, i+1)) + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *workflowSourceView) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit or 'backspace' to go back", m.err)
+	}
+
+	if m.loading {
+		return m.RenderTopFields() + "\n\nLoading workflow file..."
+	}
+
+	return fmt.Sprintf(
+		"%s\n%s\n%s",
+		m.RenderTopFields(),
+		constants.MainStyle.Render(m.viewport.View()),
+		m.RenderBottomFields(),
+	)
+}
+
+func (m *workflowSourceView) helpBindings() []key.Binding {
+	return []key.Binding{m.keys.Back, m.keys.Up, m.keys.Down, m.keys.PageUp, m.keys.PageDown, m.keys.Quit}
+}
@@ -10,6 +10,9 @@ const (
 	ENVIRONMENT
 	VARIABLE
 	PROJECT
+	RELEASE
+	CACHE
+	RUNNER
 	LANGUAGES
 	DESCRIPTION
 )
@@ -34,6 +37,12 @@ func ConvertRepoElementType(typeElt int) string {
 		return "Variable"
 	case PROJECT:
 		return "Project"
+	case RELEASE:
+		return "Release"
+	case CACHE:
+		return "Cache"
+	case RUNNER:
+		return "Runner"
 	case DESCRIPTION:
 		return "Description"
 	default: